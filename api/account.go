@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pkg/role"
 	"github.com/milhamh95/simplebank/token"
 )
 
@@ -73,7 +74,8 @@ func (s *Server) getAccount(ctx *gin.Context) {
 		return
 	}
 
-	if account.Owner != authPayload.Username {
+	// bankers and admins can read any account
+	if account.Owner != authPayload.Username && !hasPermission(authPayload, role.Banker, role.Admin) {
 		err := errors.New("account doesn't belong to the authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errResponse(err))
 		return
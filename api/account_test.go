@@ -15,6 +15,7 @@ import (
 	"github.com/milhamh95/simplebank/db/fake"
 	db "github.com/milhamh95/simplebank/db/sqlc"
 	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/pkg/role"
 	"github.com/milhamh95/simplebank/token"
 
 	"github.com/stretchr/testify/require"
@@ -41,6 +42,7 @@ func TestGetAccountAPI(t *testing.T) {
 					tokenMaker,
 					authorizationTypeBearer,
 					user.Username,
+					role.Depositor,
 					time.Minute,
 				)
 			},
@@ -53,6 +55,50 @@ func TestGetAccountAPI(t *testing.T) {
 				requireBodyMatchAccount(t, recorder.Body, account)
 			},
 		},
+		{
+			name:      "other depositor",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Tokener) {
+				addAuthorization(
+					t,
+					req,
+					tokenMaker,
+					authorizationTypeBearer,
+					"otheruser",
+					role.Depositor,
+					time.Minute,
+				)
+			},
+			callGetAccountStub: true,
+			getAccountStub: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "banker reads other user's account",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Tokener) {
+				addAuthorization(
+					t,
+					req,
+					tokenMaker,
+					authorizationTypeBearer,
+					"otheruser",
+					role.Banker,
+					time.Minute,
+				)
+			},
+			callGetAccountStub: true,
+			getAccountStub: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
 		{
 			name:      "not found",
 			accountID: account.ID,
@@ -63,6 +109,7 @@ func TestGetAccountAPI(t *testing.T) {
 					tokenMaker,
 					authorizationTypeBearer,
 					user.Username,
+					role.Depositor,
 					time.Minute,
 				)
 			},
@@ -84,6 +131,7 @@ func TestGetAccountAPI(t *testing.T) {
 					tokenMaker,
 					authorizationTypeBearer,
 					user.Username,
+					role.Depositor,
 					time.Minute,
 				)
 			},
@@ -105,6 +153,7 @@ func TestGetAccountAPI(t *testing.T) {
 					tokenMaker,
 					authorizationTypeBearer,
 					user.Username,
+					role.Depositor,
 					time.Minute,
 				)
 			},
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/denylist"
 	"github.com/milhamh95/simplebank/pkg/config"
 	"github.com/milhamh95/simplebank/pkg/random"
 	"github.com/stretchr/testify/require"
@@ -18,7 +19,7 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 		AccessTokenDuration: time.Minute,
 	}
 
-	server, err := NewServer(cfg, store)
+	server, err := NewServer(cfg, store, denylist.NewMemoryDenylist())
 	require.NoError(t, err)
 
 	return server
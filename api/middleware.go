@@ -1,12 +1,15 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/denylist"
 	"github.com/milhamh95/simplebank/token"
 )
 
@@ -16,7 +19,7 @@ const (
 	authorizationPayloadKey = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Tokener) gin.HandlerFunc {
+func authMiddleware(tokenMaker token.Tokener, tokenDenylist denylist.TokenDenylist, store db.Store) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader(authorizationHeaderKey)
 		if len(authHeader) == 0 {
@@ -40,13 +43,54 @@ func authMiddleware(tokenMaker token.Tokener) gin.HandlerFunc {
 		}
 
 		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
+		payload, err := tokenMaker.VerifyToken(accessToken, token.TokenTypeAccess)
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
 			return
 		}
 
+		denied, err := tokenDenylist.IsDenied(ctx, payload.ID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+
+		if denied {
+			err := errors.New("access token was revoked")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
+			return
+		}
+
+		user, err := store.GetUser(ctx, payload.Username)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
+				return
+			}
+
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+
+		// a password change revokes every token issued before it
+		if payload.IssuedBefore(user.PasswordChangedAt) {
+			err := errors.New("access token was issued before the password changed")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
+			return
+		}
+
 		ctx.Set(authorizationPayloadKey, payload)
 		ctx.Next()
 	}
 }
+
+// hasPermission reports whether the token role is one of accessibleRoles
+func hasPermission(payload *token.Payload, accessibleRoles ...string) bool {
+	for _, accessibleRole := range accessibleRoles {
+		if payload.Role == accessibleRole {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +9,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pkg/role"
 	"github.com/milhamh95/simplebank/token"
 	"github.com/stretchr/testify/require"
 )
@@ -16,10 +20,10 @@ func addAuthorization(
 	t *testing.T,
 	req *http.Request,
 	tokenMaker token.Tokener,
-	authorizationType, username string,
+	authorizationType, username, userRole string,
 	duration time.Duration,
 ) {
-	token, payload, err := tokenMaker.CreateToken(username, duration)
+	token, payload, err := tokenMaker.CreateToken(username, userRole, token.TokenTypeAccess, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
@@ -30,18 +34,20 @@ func addAuthorization(
 func TestAuthMiddlewre(t *testing.T) {
 	testCases := []struct {
 		name          string
-		setupAuth     func(t *testing.T, req *http.Request, tokenMaker token.Tokener)
+		setupAuth     func(t *testing.T, req *http.Request, server *Server)
+		buildStubs    func(store *fake.FakeStore)
 		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
 	}{
 		{
 			name: "success",
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Tokener) {
+			setupAuth: func(t *testing.T, req *http.Request, server *Server) {
 				addAuthorization(
 					t,
 					req,
-					tokenMaker,
+					server.tokenMaker,
 					authorizationTypeBearer,
 					"user",
+					role.Depositor,
 					time.Minute,
 				)
 			},
@@ -51,20 +57,21 @@ func TestAuthMiddlewre(t *testing.T) {
 		},
 		{
 			name:      "no authorization",
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Tokener) {},
+			setupAuth: func(t *testing.T, req *http.Request, server *Server) {},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, rec.Code)
 			},
 		},
 		{
 			name: "unsupported authorization",
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Tokener) {
+			setupAuth: func(t *testing.T, req *http.Request, server *Server) {
 				addAuthorization(
 					t,
 					req,
-					tokenMaker,
+					server.tokenMaker,
 					"unsupported",
 					"user",
+					role.Depositor,
 					time.Minute,
 				)
 			},
@@ -74,13 +81,14 @@ func TestAuthMiddlewre(t *testing.T) {
 		},
 		{
 			name: "invalid authorization format",
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Tokener) {
+			setupAuth: func(t *testing.T, req *http.Request, server *Server) {
 				addAuthorization(
 					t,
 					req,
-					tokenMaker,
+					server.tokenMaker,
 					"",
 					"user",
+					role.Depositor,
 					time.Minute,
 				)
 			},
@@ -90,13 +98,14 @@ func TestAuthMiddlewre(t *testing.T) {
 		},
 		{
 			name: "expired token",
-			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Tokener) {
+			setupAuth: func(t *testing.T, req *http.Request, server *Server) {
 				addAuthorization(
 					t,
 					req,
-					tokenMaker,
+					server.tokenMaker,
 					"",
 					"user",
+					role.Depositor,
 					-time.Minute,
 				)
 			},
@@ -104,16 +113,68 @@ func TestAuthMiddlewre(t *testing.T) {
 				require.Equal(t, http.StatusUnauthorized, rec.Code)
 			},
 		},
+		{
+			name: "refresh token",
+			setupAuth: func(t *testing.T, req *http.Request, server *Server) {
+				refreshToken, _, err := server.tokenMaker.CreateToken("user", role.Depositor, token.TokenTypeRefresh, time.Minute)
+				require.NoError(t, err)
+
+				req.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, refreshToken))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name: "revoked token",
+			setupAuth: func(t *testing.T, req *http.Request, server *Server) {
+				accessToken, payload, err := server.tokenMaker.CreateToken("user", role.Depositor, token.TokenTypeAccess, time.Minute)
+				require.NoError(t, err)
+
+				err = server.tokenDenylist.Deny(context.Background(), payload.ID, payload.ExpiredAt)
+				require.NoError(t, err)
+
+				req.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, accessToken))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name: "issued before password change",
+			setupAuth: func(t *testing.T, req *http.Request, server *Server) {
+				addAuthorization(
+					t,
+					req,
+					server.tokenMaker,
+					authorizationTypeBearer,
+					"user",
+					role.Depositor,
+					time.Minute,
+				)
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetUserReturns(db.User{PasswordChangedAt: time.Now().Add(time.Second)}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			server := newTestServer(t, nil)
+			fakeStore := &fake.FakeStore{}
+			if tc.buildStubs != nil {
+				tc.buildStubs(fakeStore)
+			}
+
+			server := newTestServer(t, fakeStore)
 
 			authPath := "/auth"
 			server.router.GET(
 				authPath,
-				authMiddleware(server.tokenMaker),
+				authMiddleware(server.tokenMaker, server.tokenDenylist, server.store),
 				func(ctx *gin.Context) {
 					ctx.JSON(http.StatusOK, gin.H{})
 				},
@@ -123,7 +184,7 @@ func TestAuthMiddlewre(t *testing.T) {
 			req, err := http.NewRequest(http.MethodGet, authPath, nil)
 			require.NoError(t, err)
 
-			tc.setupAuth(t, req, server.tokenMaker)
+			tc.setupAuth(t, req, server)
 			server.router.ServeHTTP(rec, req)
 			tc.checkResponse(t, rec)
 		})
@@ -8,26 +8,49 @@ import (
 	"github.com/go-playground/validator/v10"
 
 	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/denylist"
 	"github.com/milhamh95/simplebank/pkg/config"
+	"github.com/milhamh95/simplebank/pkg/password"
+	pkgvalidator "github.com/milhamh95/simplebank/pkg/validator"
 	"github.com/milhamh95/simplebank/token"
 )
 
 type Server struct {
-	cfg        config.Config
-	store      db.Store
-	tokenMaker token.Tokener
-	router     *gin.Engine
+	cfg            config.Config
+	store          db.Store
+	tokenMaker     token.Tokener
+	tokenDenylist  denylist.TokenDenylist
+	passwordHasher password.Hasher
+	passwordPolicy *pkgvalidator.PasswordPolicy
+	router         *gin.Engine
 }
 
-func NewServer(cfg config.Config, store db.Store) (*Server, error) {
-	tokenMaker, err := token.NewPaseto(cfg.TokenSymmetricKey)
+func NewServer(cfg config.Config, store db.Store, tokenDenylist denylist.TokenDenylist) (*Server, error) {
+	tokenMaker, err := token.NewTokener(cfg.TokenFormat, cfg.TokenSymmetricKey, cfg.TokenPrivateKeyFile, cfg.TokenPublicKeyFiles)
 	if err != nil {
 		return nil, fmt.Errorf("init token maker: %w", err)
 	}
+
+	passwordPolicy, err := pkgvalidator.LoadPasswordPolicy(pkgvalidator.PasswordPolicy{
+		MinLength:      cfg.PasswordMinLength,
+		MinCharClasses: cfg.PasswordMinCharClasses,
+		MaxRepeated:    cfg.PasswordMaxRepeated,
+	}, cfg.PasswordBreachedFile)
+	if err != nil {
+		return nil, fmt.Errorf("init password policy: %w", err)
+	}
+
 	server := &Server{
-		cfg:        cfg,
-		store:      store,
-		tokenMaker: tokenMaker,
+		cfg:           cfg,
+		store:         store,
+		tokenMaker:    tokenMaker,
+		tokenDenylist: tokenDenylist,
+		passwordHasher: password.NewArgon2idHasher(password.Argon2idParams{
+			Memory:      cfg.PasswordArgon2Memory,
+			Iterations:  cfg.PasswordArgon2Iterations,
+			Parallelism: cfg.PasswordArgon2Parallelism,
+		}),
+		passwordPolicy: passwordPolicy,
 	}
 
 	v, ok := binding.Validator.Engine().(*validator.Validate)
@@ -49,14 +72,14 @@ func (s *Server) setupRouter() {
 
 	authRoutes := router.Group("/").
 		Use(
-			authMiddleware(s.tokenMaker),
+			authMiddleware(s.tokenMaker, s.tokenDenylist, s.store),
 		)
 
 	authRoutes.POST("/accounts", s.createAccount)
 	authRoutes.GET("/accounts/:id", s.getAccount)
 	authRoutes.GET("/accounts", s.listAccount)
 
-	authRoutes.POST("/transfers", s.createAccount)
+	authRoutes.POST("/transfers", s.createTransfer)
 
 	s.router = router
 }
@@ -65,6 +88,19 @@ func errResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
 
+// fieldViolationsResponse reports every error of a field, like the field violations of the grpc api
+func fieldViolationsResponse(field string, errs []error) gin.H {
+	violations := make([]gin.H, 0, len(errs))
+	for _, err := range errs {
+		violations = append(violations, gin.H{"field": field, "description": err.Error()})
+	}
+
+	return gin.H{
+		"error":            fmt.Sprintf("invalid %s", field),
+		"field_violations": violations,
+	}
+}
+
 func (s *Server) Start(addr string) error {
 	return s.router.Run(addr)
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/milhamh95/simplebank/token"
 )
 
 type renewAccessTokenRequest struct {
@@ -26,7 +27,7 @@ func (s *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
-	refreshPayload, err := s.tokenMaker.VerifyToken(req.RefreshToken)
+	refreshPayload, err := s.tokenMaker.VerifyToken(req.RefreshToken, token.TokenTypeRefresh)
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, errResponse(err))
 		return
@@ -42,6 +43,13 @@ func (s *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
+	// the gRPC gateway rotates refresh tokens, a rotated token must not be renewed here either
+	if session.ReplacedBy.Valid {
+		err := fmt.Errorf("rotated session")
+		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+		return
+	}
+
 	if session.IsBlocked {
 		err := fmt.Errorf("blocked session")
 		ctx.JSON(http.StatusUnauthorized, errResponse(err))
@@ -66,8 +74,27 @@ func (s *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
+	// the role is read again, a role change applies to the next access token
+	user, err := s.store.GetUser(ctx, refreshPayload.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	if refreshPayload.IssuedBefore(user.PasswordChangedAt) {
+		err := fmt.Errorf("refresh token was issued before the password changed")
+		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+		return
+	}
+
 	accessToken, accessPayload, err := s.tokenMaker.CreateToken(
-		refreshPayload.Username,
+		user.Username,
+		user.Role,
+		token.TokenTypeAccess,
 		s.cfg.AccessTokenDuration,
 	)
 	if err != nil {
@@ -13,9 +13,11 @@ import (
 
 type transferRequest struct {
 	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min 1"`
+	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1,nefield=FromAccountID"`
 	Amount        int64  `json:"amount" binding:"required,gt=0"`
 	Currency      string `json:"currency" binding:"required,oneof=USD EUR CAD"`
+	// IdempotencyKey is optional, retrying a transfer with the same key returns the original result
+	IdempotencyKey string `json:"idempotency_key" binding:"omitempty,min=8,max=128"`
 }
 
 func (s *Server) createTransfer(ctx *gin.Context) {
@@ -44,13 +46,25 @@ func (s *Server) createTransfer(ctx *gin.Context) {
 	}
 
 	arg := db.TransferTxParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+		FromAccountID:  req.FromAccountID,
+		ToAccountID:    req.ToAccountID,
+		Amount:         req.Amount,
+		Username:       authPayload.Username,
+		IdempotencyKey: req.IdempotencyKey,
 	}
 
 	result, err := s.store.TransferTrx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			ctx.JSON(http.StatusConflict, errResponse(err))
+			return
+		}
+
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
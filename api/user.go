@@ -2,6 +2,7 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"time"
 
@@ -9,12 +10,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	db "github.com/milhamh95/simplebank/db/sqlc"
-	"github.com/milhamh95/simplebank/pkg/password"
+	"github.com/milhamh95/simplebank/token"
 )
 
 type createUserRequest struct {
 	Username string `json:"username" binding:"required,alphanum"`
-	Password string `json:"password" binding:"required,min=6"`
+	Password string `json:"password" binding:"required"`
 	FullName string `json:"full_name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 }
@@ -44,7 +45,13 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
-	hashedPassword, err := password.HashPassword(req.Password)
+	passwordErrs := server.passwordPolicy.Validate(req.Password, req.Username, req.Email)
+	if len(passwordErrs) > 0 {
+		ctx.JSON(http.StatusBadRequest, fieldViolationsResponse("password", passwordErrs))
+		return
+	}
+
+	hashedPassword, err := server.passwordHasher.HashPassword(req.Password)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
@@ -107,14 +114,22 @@ func (s *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
-	err = password.CheckPassword(req.Password, user.HashedPassword)
+	err = s.passwordHasher.CheckPassword(req.Password, user.HashedPassword)
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, errResponse(err))
 		return
 	}
 
+	// this api doesn't support the second login step, users with TOTP enabled have to use the grpc api
+	if user.TotpEnabled {
+		ctx.JSON(http.StatusForbidden, errResponse(errors.New("two-factor authentication is required")))
+		return
+	}
+
 	accessToken, accessPayload, err := s.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
+		token.TokenTypeAccess,
 		s.cfg.AccessTokenDuration,
 	)
 	if err != nil {
@@ -124,7 +139,9 @@ func (s *Server) loginUser(ctx *gin.Context) {
 
 	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(
 		user.Username,
-		s.cfg.AccessTokenDuration,
+		user.Role,
+		token.TokenTypeRefresh,
+		s.cfg.RefreshTokenDuration,
 	)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
@@ -139,6 +156,7 @@ func (s *Server) loginUser(ctx *gin.Context) {
 		ClientIp:     ctx.ClientIP(),
 		IsBlocked:    false,
 		ExpiresAt:    refreshPayload.ExpiredAt,
+		FamilyID:     refreshPayload.ID,
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
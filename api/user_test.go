@@ -99,6 +99,20 @@ func TestCreateUserAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, rec.Code)
 			},
 		},
+		{
+			name: "password contains username",
+			body: gin.H{
+				"username":  user.Username,
+				"password":  "my_" + user.Username,
+				"full_name": user.FullName,
+				"email":     user.Email,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			checkResponse: func(rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+				require.Contains(t, rec.Body.String(), "must not contain the username")
+			},
+		},
 		{
 			name: "password is too short",
 			body: gin.H{
@@ -24,6 +24,72 @@ type FakeStore struct {
 		result1 db.Account
 		result2 error
 	}
+	BlockSessionFamilyStub        func(context.Context, uuid.UUID) error
+	blockSessionFamilyMutex       sync.RWMutex
+	blockSessionFamilyArgsForCall []struct {
+		arg1 context.Context
+		arg2 uuid.UUID
+	}
+	blockSessionFamilyReturns struct {
+		result1 error
+	}
+	blockSessionFamilyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	BlockUserSessionsStub        func(context.Context, db.BlockUserSessionsParams) error
+	blockUserSessionsMutex       sync.RWMutex
+	blockUserSessionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.BlockUserSessionsParams
+	}
+	blockUserSessionsReturns struct {
+		result1 error
+	}
+	blockUserSessionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ClaimOutboxMessagesStub        func(context.Context, db.ClaimOutboxMessagesParams) ([]db.Outbox, error)
+	claimOutboxMessagesMutex       sync.RWMutex
+	claimOutboxMessagesArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ClaimOutboxMessagesParams
+	}
+	claimOutboxMessagesReturns struct {
+		result1 []db.Outbox
+		result2 error
+	}
+	claimOutboxMessagesReturnsOnCall map[int]struct {
+		result1 []db.Outbox
+		result2 error
+	}
+	CountResetPasswordsSinceStub        func(context.Context, db.CountResetPasswordsSinceParams) (int64, error)
+	countResetPasswordsSinceMutex       sync.RWMutex
+	countResetPasswordsSinceArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.CountResetPasswordsSinceParams
+	}
+	countResetPasswordsSinceReturns struct {
+		result1 int64
+		result2 error
+	}
+	countResetPasswordsSinceReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	CountVerifyEmailsSinceStub        func(context.Context, db.CountVerifyEmailsSinceParams) (int64, error)
+	countVerifyEmailsSinceMutex       sync.RWMutex
+	countVerifyEmailsSinceArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.CountVerifyEmailsSinceParams
+	}
+	countVerifyEmailsSinceReturns struct {
+		result1 int64
+		result2 error
+	}
+	countVerifyEmailsSinceReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	CreateAccountStub        func(context.Context, db.CreateAccountParams) (db.Account, error)
 	createAccountMutex       sync.RWMutex
 	createAccountArgsForCall []struct {
@@ -52,6 +118,76 @@ type FakeStore struct {
 		result1 db.Entry
 		result2 error
 	}
+	CreateIdempotencyKeyStub        func(context.Context, db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error)
+	createIdempotencyKeyMutex       sync.RWMutex
+	createIdempotencyKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.CreateIdempotencyKeyParams
+	}
+	createIdempotencyKeyReturns struct {
+		result1 db.IdempotencyKey
+		result2 error
+	}
+	createIdempotencyKeyReturnsOnCall map[int]struct {
+		result1 db.IdempotencyKey
+		result2 error
+	}
+	CreateLoginLinkStub        func(context.Context, db.CreateLoginLinkParams) (db.LoginLink, error)
+	createLoginLinkMutex       sync.RWMutex
+	createLoginLinkArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.CreateLoginLinkParams
+	}
+	createLoginLinkReturns struct {
+		result1 db.LoginLink
+		result2 error
+	}
+	createLoginLinkReturnsOnCall map[int]struct {
+		result1 db.LoginLink
+		result2 error
+	}
+	CreateOutboxMessageStub        func(context.Context, db.CreateOutboxMessageParams) (db.Outbox, error)
+	createOutboxMessageMutex       sync.RWMutex
+	createOutboxMessageArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.CreateOutboxMessageParams
+	}
+	createOutboxMessageReturns struct {
+		result1 db.Outbox
+		result2 error
+	}
+	createOutboxMessageReturnsOnCall map[int]struct {
+		result1 db.Outbox
+		result2 error
+	}
+	CreateRecoveryCodeStub        func(context.Context, db.CreateRecoveryCodeParams) (db.RecoveryCode, error)
+	createRecoveryCodeMutex       sync.RWMutex
+	createRecoveryCodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.CreateRecoveryCodeParams
+	}
+	createRecoveryCodeReturns struct {
+		result1 db.RecoveryCode
+		result2 error
+	}
+	createRecoveryCodeReturnsOnCall map[int]struct {
+		result1 db.RecoveryCode
+		result2 error
+	}
+	CreateResetPasswordStub        func(context.Context, db.CreateResetPasswordParams) (db.ResetPassword, error)
+	createResetPasswordMutex       sync.RWMutex
+	createResetPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.CreateResetPasswordParams
+	}
+	createResetPasswordReturns struct {
+		result1 db.ResetPassword
+		result2 error
+	}
+	createResetPasswordReturnsOnCall map[int]struct {
+		result1 db.ResetPassword
+		result2 error
+	}
 	CreateSessionStub        func(context.Context, db.CreateSessionParams) (db.Session, error)
 	createSessionMutex       sync.RWMutex
 	createSessionArgsForCall []struct {
@@ -134,6 +270,97 @@ type FakeStore struct {
 	deleteAccountReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteExpiredSessionsStub        func(context.Context) (int64, error)
+	deleteExpiredSessionsMutex       sync.RWMutex
+	deleteExpiredSessionsArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteExpiredSessionsReturns struct {
+		result1 int64
+		result2 error
+	}
+	deleteExpiredSessionsReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	DeleteRecoveryCodesStub        func(context.Context, string) error
+	deleteRecoveryCodesMutex       sync.RWMutex
+	deleteRecoveryCodesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteRecoveryCodesReturns struct {
+		result1 error
+	}
+	deleteRecoveryCodesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DepositTxStub        func(context.Context, db.CashTxParams) (db.CashTxResult, error)
+	depositTxMutex       sync.RWMutex
+	depositTxArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.CashTxParams
+	}
+	depositTxReturns struct {
+		result1 db.CashTxResult
+		result2 error
+	}
+	depositTxReturnsOnCall map[int]struct {
+		result1 db.CashTxResult
+		result2 error
+	}
+	DisableTOTPTxStub        func(context.Context, string) (db.User, error)
+	disableTOTPTxMutex       sync.RWMutex
+	disableTOTPTxArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	disableTOTPTxReturns struct {
+		result1 db.User
+		result2 error
+	}
+	disableTOTPTxReturnsOnCall map[int]struct {
+		result1 db.User
+		result2 error
+	}
+	EnableTOTPTxStub        func(context.Context, db.EnableTOTPTxParams) (db.User, error)
+	enableTOTPTxMutex       sync.RWMutex
+	enableTOTPTxArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.EnableTOTPTxParams
+	}
+	enableTOTPTxReturns struct {
+		result1 db.User
+		result2 error
+	}
+	enableTOTPTxReturnsOnCall map[int]struct {
+		result1 db.User
+		result2 error
+	}
+	ExpireResetPasswordsStub        func(context.Context, string) error
+	expireResetPasswordsMutex       sync.RWMutex
+	expireResetPasswordsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	expireResetPasswordsReturns struct {
+		result1 error
+	}
+	expireResetPasswordsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ExpireVerifyEmailsStub        func(context.Context, string) error
+	expireVerifyEmailsMutex       sync.RWMutex
+	expireVerifyEmailsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	expireVerifyEmailsReturns struct {
+		result1 error
+	}
+	expireVerifyEmailsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetAccountStub        func(context.Context, int64) (db.Account, error)
 	getAccountMutex       sync.RWMutex
 	getAccountArgsForCall []struct {
@@ -148,6 +375,20 @@ type FakeStore struct {
 		result1 db.Account
 		result2 error
 	}
+	GetAccountByOwnerAndCurrencyStub        func(context.Context, db.GetAccountByOwnerAndCurrencyParams) (db.Account, error)
+	getAccountByOwnerAndCurrencyMutex       sync.RWMutex
+	getAccountByOwnerAndCurrencyArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.GetAccountByOwnerAndCurrencyParams
+	}
+	getAccountByOwnerAndCurrencyReturns struct {
+		result1 db.Account
+		result2 error
+	}
+	getAccountByOwnerAndCurrencyReturnsOnCall map[int]struct {
+		result1 db.Account
+		result2 error
+	}
 	GetAccountForUpdateStub        func(context.Context, int64) (db.Account, error)
 	getAccountForUpdateMutex       sync.RWMutex
 	getAccountForUpdateArgsForCall []struct {
@@ -162,6 +403,34 @@ type FakeStore struct {
 		result1 db.Account
 		result2 error
 	}
+	GetActiveResetPasswordStub        func(context.Context, string) (db.ResetPassword, error)
+	getActiveResetPasswordMutex       sync.RWMutex
+	getActiveResetPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getActiveResetPasswordReturns struct {
+		result1 db.ResetPassword
+		result2 error
+	}
+	getActiveResetPasswordReturnsOnCall map[int]struct {
+		result1 db.ResetPassword
+		result2 error
+	}
+	GetActiveVerifyEmailStub        func(context.Context, db.GetActiveVerifyEmailParams) (db.VerifyEmail, error)
+	getActiveVerifyEmailMutex       sync.RWMutex
+	getActiveVerifyEmailArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.GetActiveVerifyEmailParams
+	}
+	getActiveVerifyEmailReturns struct {
+		result1 db.VerifyEmail
+		result2 error
+	}
+	getActiveVerifyEmailReturnsOnCall map[int]struct {
+		result1 db.VerifyEmail
+		result2 error
+	}
 	GetEntryStub        func(context.Context, int64) (db.Entry, error)
 	getEntryMutex       sync.RWMutex
 	getEntryArgsForCall []struct {
@@ -176,6 +445,34 @@ type FakeStore struct {
 		result1 db.Entry
 		result2 error
 	}
+	GetIdempotencyKeyStub        func(context.Context, db.GetIdempotencyKeyParams) (db.IdempotencyKey, error)
+	getIdempotencyKeyMutex       sync.RWMutex
+	getIdempotencyKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.GetIdempotencyKeyParams
+	}
+	getIdempotencyKeyReturns struct {
+		result1 db.IdempotencyKey
+		result2 error
+	}
+	getIdempotencyKeyReturnsOnCall map[int]struct {
+		result1 db.IdempotencyKey
+		result2 error
+	}
+	GetResetPasswordByCodeStub        func(context.Context, db.GetResetPasswordByCodeParams) (db.ResetPassword, error)
+	getResetPasswordByCodeMutex       sync.RWMutex
+	getResetPasswordByCodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.GetResetPasswordByCodeParams
+	}
+	getResetPasswordByCodeReturns struct {
+		result1 db.ResetPassword
+		result2 error
+	}
+	getResetPasswordByCodeReturnsOnCall map[int]struct {
+		result1 db.ResetPassword
+		result2 error
+	}
 	GetSessionStub        func(context.Context, uuid.UUID) (db.Session, error)
 	getSessionMutex       sync.RWMutex
 	getSessionArgsForCall []struct {
@@ -218,6 +515,34 @@ type FakeStore struct {
 		result1 db.User
 		result2 error
 	}
+	GetUserByEmailStub        func(context.Context, string) (db.User, error)
+	getUserByEmailMutex       sync.RWMutex
+	getUserByEmailArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getUserByEmailReturns struct {
+		result1 db.User
+		result2 error
+	}
+	getUserByEmailReturnsOnCall map[int]struct {
+		result1 db.User
+		result2 error
+	}
+	GetUserForUpdateStub        func(context.Context, string) (db.User, error)
+	getUserForUpdateMutex       sync.RWMutex
+	getUserForUpdateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getUserForUpdateReturns struct {
+		result1 db.User
+		result2 error
+	}
+	getUserForUpdateReturnsOnCall map[int]struct {
+		result1 db.User
+		result2 error
+	}
 	ListAccountsStub        func(context.Context, db.ListAccountsParams) ([]db.Account, error)
 	listAccountsMutex       sync.RWMutex
 	listAccountsArgsForCall []struct {
@@ -232,6 +557,20 @@ type FakeStore struct {
 		result1 []db.Account
 		result2 error
 	}
+	ListActiveSessionsStub        func(context.Context, string) ([]db.Session, error)
+	listActiveSessionsMutex       sync.RWMutex
+	listActiveSessionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	listActiveSessionsReturns struct {
+		result1 []db.Session
+		result2 error
+	}
+	listActiveSessionsReturnsOnCall map[int]struct {
+		result1 []db.Session
+		result2 error
+	}
 	ListEntriesStub        func(context.Context, db.ListEntriesParams) ([]db.Entry, error)
 	listEntriesMutex       sync.RWMutex
 	listEntriesArgsForCall []struct {
@@ -260,6 +599,100 @@ type FakeStore struct {
 		result1 []db.Transfer
 		result2 error
 	}
+	MarkOutboxMessageFailedStub        func(context.Context, db.MarkOutboxMessageFailedParams) error
+	markOutboxMessageFailedMutex       sync.RWMutex
+	markOutboxMessageFailedArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.MarkOutboxMessageFailedParams
+	}
+	markOutboxMessageFailedReturns struct {
+		result1 error
+	}
+	markOutboxMessageFailedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MarkOutboxMessageSentStub        func(context.Context, int64) error
+	markOutboxMessageSentMutex       sync.RWMutex
+	markOutboxMessageSentArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	markOutboxMessageSentReturns struct {
+		result1 error
+	}
+	markOutboxMessageSentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RequestPasswordResetTxStub        func(context.Context, db.RequestPasswordResetTxParams) (db.RequestPasswordResetTxResult, error)
+	requestPasswordResetTxMutex       sync.RWMutex
+	requestPasswordResetTxArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.RequestPasswordResetTxParams
+	}
+	requestPasswordResetTxReturns struct {
+		result1 db.RequestPasswordResetTxResult
+		result2 error
+	}
+	requestPasswordResetTxReturnsOnCall map[int]struct {
+		result1 db.RequestPasswordResetTxResult
+		result2 error
+	}
+	ResendVerifyEmailTxStub        func(context.Context, db.ResendVerifyEmailTxParams) (db.ResendVerifyEmailTxResult, error)
+	resendVerifyEmailTxMutex       sync.RWMutex
+	resendVerifyEmailTxArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ResendVerifyEmailTxParams
+	}
+	resendVerifyEmailTxReturns struct {
+		result1 db.ResendVerifyEmailTxResult
+		result2 error
+	}
+	resendVerifyEmailTxReturnsOnCall map[int]struct {
+		result1 db.ResendVerifyEmailTxResult
+		result2 error
+	}
+	ResetPasswordTxStub        func(context.Context, db.ResetPasswordTxParams) (db.ResetPasswordTxResult, error)
+	resetPasswordTxMutex       sync.RWMutex
+	resetPasswordTxArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.ResetPasswordTxParams
+	}
+	resetPasswordTxReturns struct {
+		result1 db.ResetPasswordTxResult
+		result2 error
+	}
+	resetPasswordTxReturnsOnCall map[int]struct {
+		result1 db.ResetPasswordTxResult
+		result2 error
+	}
+	RotateSessionStub        func(context.Context, db.RotateSessionParams) (db.Session, error)
+	rotateSessionMutex       sync.RWMutex
+	rotateSessionArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.RotateSessionParams
+	}
+	rotateSessionReturns struct {
+		result1 db.Session
+		result2 error
+	}
+	rotateSessionReturnsOnCall map[int]struct {
+		result1 db.Session
+		result2 error
+	}
+	RotateSessionTxStub        func(context.Context, db.RotateSessionTxParams) (db.Session, error)
+	rotateSessionTxMutex       sync.RWMutex
+	rotateSessionTxArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.RotateSessionTxParams
+	}
+	rotateSessionTxReturns struct {
+		result1 db.Session
+		result2 error
+	}
+	rotateSessionTxReturnsOnCall map[int]struct {
+		result1 db.Session
+		result2 error
+	}
 	TransferTrxStub        func(context.Context, db.TransferTxParams) (db.TransferTxResult, error)
 	transferTrxMutex       sync.RWMutex
 	transferTrxArgsForCall []struct {
@@ -288,6 +721,46 @@ type FakeStore struct {
 		result1 db.Account
 		result2 error
 	}
+	UpdateIdempotencyKeyResponseStub        func(context.Context, db.UpdateIdempotencyKeyResponseParams) error
+	updateIdempotencyKeyResponseMutex       sync.RWMutex
+	updateIdempotencyKeyResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.UpdateIdempotencyKeyResponseParams
+	}
+	updateIdempotencyKeyResponseReturns struct {
+		result1 error
+	}
+	updateIdempotencyKeyResponseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateLoginLinkStub        func(context.Context, db.UpdateLoginLinkParams) (db.LoginLink, error)
+	updateLoginLinkMutex       sync.RWMutex
+	updateLoginLinkArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.UpdateLoginLinkParams
+	}
+	updateLoginLinkReturns struct {
+		result1 db.LoginLink
+		result2 error
+	}
+	updateLoginLinkReturnsOnCall map[int]struct {
+		result1 db.LoginLink
+		result2 error
+	}
+	UpdateResetPasswordStub        func(context.Context, db.UpdateResetPasswordParams) (db.ResetPassword, error)
+	updateResetPasswordMutex       sync.RWMutex
+	updateResetPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.UpdateResetPasswordParams
+	}
+	updateResetPasswordReturns struct {
+		result1 db.ResetPassword
+		result2 error
+	}
+	updateResetPasswordReturnsOnCall map[int]struct {
+		result1 db.ResetPassword
+		result2 error
+	}
 	UpdateUserStub        func(context.Context, db.UpdateUserParams) (db.User, error)
 	updateUserMutex       sync.RWMutex
 	updateUserArgsForCall []struct {
@@ -302,6 +775,20 @@ type FakeStore struct {
 		result1 db.User
 		result2 error
 	}
+	UpdateUserTxStub        func(context.Context, db.UpdateUserTxParams) (db.UpdateUserTxResult, error)
+	updateUserTxMutex       sync.RWMutex
+	updateUserTxArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.UpdateUserTxParams
+	}
+	updateUserTxReturns struct {
+		result1 db.UpdateUserTxResult
+		result2 error
+	}
+	updateUserTxReturnsOnCall map[int]struct {
+		result1 db.UpdateUserTxResult
+		result2 error
+	}
 	UpdateVerifyEmailStub        func(context.Context, db.UpdateVerifyEmailParams) (db.VerifyEmail, error)
 	updateVerifyEmailMutex       sync.RWMutex
 	updateVerifyEmailArgsForCall []struct {
@@ -316,6 +803,34 @@ type FakeStore struct {
 		result1 db.VerifyEmail
 		result2 error
 	}
+	UseRecoveryCodeStub        func(context.Context, db.UseRecoveryCodeParams) (db.RecoveryCode, error)
+	useRecoveryCodeMutex       sync.RWMutex
+	useRecoveryCodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.UseRecoveryCodeParams
+	}
+	useRecoveryCodeReturns struct {
+		result1 db.RecoveryCode
+		result2 error
+	}
+	useRecoveryCodeReturnsOnCall map[int]struct {
+		result1 db.RecoveryCode
+		result2 error
+	}
+	UseTOTPStepStub        func(context.Context, db.UseTOTPStepParams) (int64, error)
+	useTOTPStepMutex       sync.RWMutex
+	useTOTPStepArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.UseTOTPStepParams
+	}
+	useTOTPStepReturns struct {
+		result1 int64
+		result2 error
+	}
+	useTOTPStepReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	VerifyEmailTxStub        func(context.Context, db.VerifyEmailTxParams) (db.VerifyEmailTxResult, error)
 	verifyEmailTxMutex       sync.RWMutex
 	verifyEmailTxArgsForCall []struct {
@@ -330,8 +845,22 @@ type FakeStore struct {
 		result1 db.VerifyEmailTxResult
 		result2 error
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	WithdrawTxStub        func(context.Context, db.CashTxParams) (db.CashTxResult, error)
+	withdrawTxMutex       sync.RWMutex
+	withdrawTxArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.CashTxParams
+	}
+	withdrawTxReturns struct {
+		result1 db.CashTxResult
+		result2 error
+	}
+	withdrawTxReturnsOnCall map[int]struct {
+		result1 db.CashTxResult
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
 }
 
 func (fake *FakeStore) AddAccountBalance(arg1 context.Context, arg2 db.AddAccountBalanceParams) (db.Account, error) {
@@ -399,6 +928,325 @@ func (fake *FakeStore) AddAccountBalanceReturnsOnCall(i int, result1 db.Account,
 	}{result1, result2}
 }
 
+func (fake *FakeStore) BlockSessionFamily(arg1 context.Context, arg2 uuid.UUID) error {
+	fake.blockSessionFamilyMutex.Lock()
+	ret, specificReturn := fake.blockSessionFamilyReturnsOnCall[len(fake.blockSessionFamilyArgsForCall)]
+	fake.blockSessionFamilyArgsForCall = append(fake.blockSessionFamilyArgsForCall, struct {
+		arg1 context.Context
+		arg2 uuid.UUID
+	}{arg1, arg2})
+	stub := fake.BlockSessionFamilyStub
+	fakeReturns := fake.blockSessionFamilyReturns
+	fake.recordInvocation("BlockSessionFamily", []interface{}{arg1, arg2})
+	fake.blockSessionFamilyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) BlockSessionFamilyCallCount() int {
+	fake.blockSessionFamilyMutex.RLock()
+	defer fake.blockSessionFamilyMutex.RUnlock()
+	return len(fake.blockSessionFamilyArgsForCall)
+}
+
+func (fake *FakeStore) BlockSessionFamilyCalls(stub func(context.Context, uuid.UUID) error) {
+	fake.blockSessionFamilyMutex.Lock()
+	defer fake.blockSessionFamilyMutex.Unlock()
+	fake.BlockSessionFamilyStub = stub
+}
+
+func (fake *FakeStore) BlockSessionFamilyArgsForCall(i int) (context.Context, uuid.UUID) {
+	fake.blockSessionFamilyMutex.RLock()
+	defer fake.blockSessionFamilyMutex.RUnlock()
+	argsForCall := fake.blockSessionFamilyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) BlockSessionFamilyReturns(result1 error) {
+	fake.blockSessionFamilyMutex.Lock()
+	defer fake.blockSessionFamilyMutex.Unlock()
+	fake.BlockSessionFamilyStub = nil
+	fake.blockSessionFamilyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) BlockSessionFamilyReturnsOnCall(i int, result1 error) {
+	fake.blockSessionFamilyMutex.Lock()
+	defer fake.blockSessionFamilyMutex.Unlock()
+	fake.BlockSessionFamilyStub = nil
+	if fake.blockSessionFamilyReturnsOnCall == nil {
+		fake.blockSessionFamilyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.blockSessionFamilyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) BlockUserSessions(arg1 context.Context, arg2 db.BlockUserSessionsParams) error {
+	fake.blockUserSessionsMutex.Lock()
+	ret, specificReturn := fake.blockUserSessionsReturnsOnCall[len(fake.blockUserSessionsArgsForCall)]
+	fake.blockUserSessionsArgsForCall = append(fake.blockUserSessionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.BlockUserSessionsParams
+	}{arg1, arg2})
+	stub := fake.BlockUserSessionsStub
+	fakeReturns := fake.blockUserSessionsReturns
+	fake.recordInvocation("BlockUserSessions", []interface{}{arg1, arg2})
+	fake.blockUserSessionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) BlockUserSessionsCallCount() int {
+	fake.blockUserSessionsMutex.RLock()
+	defer fake.blockUserSessionsMutex.RUnlock()
+	return len(fake.blockUserSessionsArgsForCall)
+}
+
+func (fake *FakeStore) BlockUserSessionsCalls(stub func(context.Context, db.BlockUserSessionsParams) error) {
+	fake.blockUserSessionsMutex.Lock()
+	defer fake.blockUserSessionsMutex.Unlock()
+	fake.BlockUserSessionsStub = stub
+}
+
+func (fake *FakeStore) BlockUserSessionsArgsForCall(i int) (context.Context, db.BlockUserSessionsParams) {
+	fake.blockUserSessionsMutex.RLock()
+	defer fake.blockUserSessionsMutex.RUnlock()
+	argsForCall := fake.blockUserSessionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) BlockUserSessionsReturns(result1 error) {
+	fake.blockUserSessionsMutex.Lock()
+	defer fake.blockUserSessionsMutex.Unlock()
+	fake.BlockUserSessionsStub = nil
+	fake.blockUserSessionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) BlockUserSessionsReturnsOnCall(i int, result1 error) {
+	fake.blockUserSessionsMutex.Lock()
+	defer fake.blockUserSessionsMutex.Unlock()
+	fake.BlockUserSessionsStub = nil
+	if fake.blockUserSessionsReturnsOnCall == nil {
+		fake.blockUserSessionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.blockUserSessionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) ClaimOutboxMessages(arg1 context.Context, arg2 db.ClaimOutboxMessagesParams) ([]db.Outbox, error) {
+	fake.claimOutboxMessagesMutex.Lock()
+	ret, specificReturn := fake.claimOutboxMessagesReturnsOnCall[len(fake.claimOutboxMessagesArgsForCall)]
+	fake.claimOutboxMessagesArgsForCall = append(fake.claimOutboxMessagesArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ClaimOutboxMessagesParams
+	}{arg1, arg2})
+	stub := fake.ClaimOutboxMessagesStub
+	fakeReturns := fake.claimOutboxMessagesReturns
+	fake.recordInvocation("ClaimOutboxMessages", []interface{}{arg1, arg2})
+	fake.claimOutboxMessagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) ClaimOutboxMessagesCallCount() int {
+	fake.claimOutboxMessagesMutex.RLock()
+	defer fake.claimOutboxMessagesMutex.RUnlock()
+	return len(fake.claimOutboxMessagesArgsForCall)
+}
+
+func (fake *FakeStore) ClaimOutboxMessagesCalls(stub func(context.Context, db.ClaimOutboxMessagesParams) ([]db.Outbox, error)) {
+	fake.claimOutboxMessagesMutex.Lock()
+	defer fake.claimOutboxMessagesMutex.Unlock()
+	fake.ClaimOutboxMessagesStub = stub
+}
+
+func (fake *FakeStore) ClaimOutboxMessagesArgsForCall(i int) (context.Context, db.ClaimOutboxMessagesParams) {
+	fake.claimOutboxMessagesMutex.RLock()
+	defer fake.claimOutboxMessagesMutex.RUnlock()
+	argsForCall := fake.claimOutboxMessagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) ClaimOutboxMessagesReturns(result1 []db.Outbox, result2 error) {
+	fake.claimOutboxMessagesMutex.Lock()
+	defer fake.claimOutboxMessagesMutex.Unlock()
+	fake.ClaimOutboxMessagesStub = nil
+	fake.claimOutboxMessagesReturns = struct {
+		result1 []db.Outbox
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ClaimOutboxMessagesReturnsOnCall(i int, result1 []db.Outbox, result2 error) {
+	fake.claimOutboxMessagesMutex.Lock()
+	defer fake.claimOutboxMessagesMutex.Unlock()
+	fake.ClaimOutboxMessagesStub = nil
+	if fake.claimOutboxMessagesReturnsOnCall == nil {
+		fake.claimOutboxMessagesReturnsOnCall = make(map[int]struct {
+			result1 []db.Outbox
+			result2 error
+		})
+	}
+	fake.claimOutboxMessagesReturnsOnCall[i] = struct {
+		result1 []db.Outbox
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CountResetPasswordsSince(arg1 context.Context, arg2 db.CountResetPasswordsSinceParams) (int64, error) {
+	fake.countResetPasswordsSinceMutex.Lock()
+	ret, specificReturn := fake.countResetPasswordsSinceReturnsOnCall[len(fake.countResetPasswordsSinceArgsForCall)]
+	fake.countResetPasswordsSinceArgsForCall = append(fake.countResetPasswordsSinceArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.CountResetPasswordsSinceParams
+	}{arg1, arg2})
+	stub := fake.CountResetPasswordsSinceStub
+	fakeReturns := fake.countResetPasswordsSinceReturns
+	fake.recordInvocation("CountResetPasswordsSince", []interface{}{arg1, arg2})
+	fake.countResetPasswordsSinceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) CountResetPasswordsSinceCallCount() int {
+	fake.countResetPasswordsSinceMutex.RLock()
+	defer fake.countResetPasswordsSinceMutex.RUnlock()
+	return len(fake.countResetPasswordsSinceArgsForCall)
+}
+
+func (fake *FakeStore) CountResetPasswordsSinceCalls(stub func(context.Context, db.CountResetPasswordsSinceParams) (int64, error)) {
+	fake.countResetPasswordsSinceMutex.Lock()
+	defer fake.countResetPasswordsSinceMutex.Unlock()
+	fake.CountResetPasswordsSinceStub = stub
+}
+
+func (fake *FakeStore) CountResetPasswordsSinceArgsForCall(i int) (context.Context, db.CountResetPasswordsSinceParams) {
+	fake.countResetPasswordsSinceMutex.RLock()
+	defer fake.countResetPasswordsSinceMutex.RUnlock()
+	argsForCall := fake.countResetPasswordsSinceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) CountResetPasswordsSinceReturns(result1 int64, result2 error) {
+	fake.countResetPasswordsSinceMutex.Lock()
+	defer fake.countResetPasswordsSinceMutex.Unlock()
+	fake.CountResetPasswordsSinceStub = nil
+	fake.countResetPasswordsSinceReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CountResetPasswordsSinceReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.countResetPasswordsSinceMutex.Lock()
+	defer fake.countResetPasswordsSinceMutex.Unlock()
+	fake.CountResetPasswordsSinceStub = nil
+	if fake.countResetPasswordsSinceReturnsOnCall == nil {
+		fake.countResetPasswordsSinceReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.countResetPasswordsSinceReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CountVerifyEmailsSince(arg1 context.Context, arg2 db.CountVerifyEmailsSinceParams) (int64, error) {
+	fake.countVerifyEmailsSinceMutex.Lock()
+	ret, specificReturn := fake.countVerifyEmailsSinceReturnsOnCall[len(fake.countVerifyEmailsSinceArgsForCall)]
+	fake.countVerifyEmailsSinceArgsForCall = append(fake.countVerifyEmailsSinceArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.CountVerifyEmailsSinceParams
+	}{arg1, arg2})
+	stub := fake.CountVerifyEmailsSinceStub
+	fakeReturns := fake.countVerifyEmailsSinceReturns
+	fake.recordInvocation("CountVerifyEmailsSince", []interface{}{arg1, arg2})
+	fake.countVerifyEmailsSinceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) CountVerifyEmailsSinceCallCount() int {
+	fake.countVerifyEmailsSinceMutex.RLock()
+	defer fake.countVerifyEmailsSinceMutex.RUnlock()
+	return len(fake.countVerifyEmailsSinceArgsForCall)
+}
+
+func (fake *FakeStore) CountVerifyEmailsSinceCalls(stub func(context.Context, db.CountVerifyEmailsSinceParams) (int64, error)) {
+	fake.countVerifyEmailsSinceMutex.Lock()
+	defer fake.countVerifyEmailsSinceMutex.Unlock()
+	fake.CountVerifyEmailsSinceStub = stub
+}
+
+func (fake *FakeStore) CountVerifyEmailsSinceArgsForCall(i int) (context.Context, db.CountVerifyEmailsSinceParams) {
+	fake.countVerifyEmailsSinceMutex.RLock()
+	defer fake.countVerifyEmailsSinceMutex.RUnlock()
+	argsForCall := fake.countVerifyEmailsSinceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) CountVerifyEmailsSinceReturns(result1 int64, result2 error) {
+	fake.countVerifyEmailsSinceMutex.Lock()
+	defer fake.countVerifyEmailsSinceMutex.Unlock()
+	fake.CountVerifyEmailsSinceStub = nil
+	fake.countVerifyEmailsSinceReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CountVerifyEmailsSinceReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.countVerifyEmailsSinceMutex.Lock()
+	defer fake.countVerifyEmailsSinceMutex.Unlock()
+	fake.CountVerifyEmailsSinceStub = nil
+	if fake.countVerifyEmailsSinceReturnsOnCall == nil {
+		fake.countVerifyEmailsSinceReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.countVerifyEmailsSinceReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStore) CreateAccount(arg1 context.Context, arg2 db.CreateAccountParams) (db.Account, error) {
 	fake.createAccountMutex.Lock()
 	ret, specificReturn := fake.createAccountReturnsOnCall[len(fake.createAccountArgsForCall)]
@@ -529,17 +1377,17 @@ func (fake *FakeStore) CreateEntryReturnsOnCall(i int, result1 db.Entry, result2
 	}{result1, result2}
 }
 
-func (fake *FakeStore) CreateSession(arg1 context.Context, arg2 db.CreateSessionParams) (db.Session, error) {
-	fake.createSessionMutex.Lock()
-	ret, specificReturn := fake.createSessionReturnsOnCall[len(fake.createSessionArgsForCall)]
-	fake.createSessionArgsForCall = append(fake.createSessionArgsForCall, struct {
+func (fake *FakeStore) CreateIdempotencyKey(arg1 context.Context, arg2 db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	fake.createIdempotencyKeyMutex.Lock()
+	ret, specificReturn := fake.createIdempotencyKeyReturnsOnCall[len(fake.createIdempotencyKeyArgsForCall)]
+	fake.createIdempotencyKeyArgsForCall = append(fake.createIdempotencyKeyArgsForCall, struct {
 		arg1 context.Context
-		arg2 db.CreateSessionParams
+		arg2 db.CreateIdempotencyKeyParams
 	}{arg1, arg2})
-	stub := fake.CreateSessionStub
-	fakeReturns := fake.createSessionReturns
-	fake.recordInvocation("CreateSession", []interface{}{arg1, arg2})
-	fake.createSessionMutex.Unlock()
+	stub := fake.CreateIdempotencyKeyStub
+	fakeReturns := fake.createIdempotencyKeyReturns
+	fake.recordInvocation("CreateIdempotencyKey", []interface{}{arg1, arg2})
+	fake.createIdempotencyKeyMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -549,62 +1397,62 @@ func (fake *FakeStore) CreateSession(arg1 context.Context, arg2 db.CreateSession
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeStore) CreateSessionCallCount() int {
-	fake.createSessionMutex.RLock()
-	defer fake.createSessionMutex.RUnlock()
-	return len(fake.createSessionArgsForCall)
+func (fake *FakeStore) CreateIdempotencyKeyCallCount() int {
+	fake.createIdempotencyKeyMutex.RLock()
+	defer fake.createIdempotencyKeyMutex.RUnlock()
+	return len(fake.createIdempotencyKeyArgsForCall)
 }
 
-func (fake *FakeStore) CreateSessionCalls(stub func(context.Context, db.CreateSessionParams) (db.Session, error)) {
-	fake.createSessionMutex.Lock()
-	defer fake.createSessionMutex.Unlock()
-	fake.CreateSessionStub = stub
+func (fake *FakeStore) CreateIdempotencyKeyCalls(stub func(context.Context, db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error)) {
+	fake.createIdempotencyKeyMutex.Lock()
+	defer fake.createIdempotencyKeyMutex.Unlock()
+	fake.CreateIdempotencyKeyStub = stub
 }
 
-func (fake *FakeStore) CreateSessionArgsForCall(i int) (context.Context, db.CreateSessionParams) {
-	fake.createSessionMutex.RLock()
-	defer fake.createSessionMutex.RUnlock()
-	argsForCall := fake.createSessionArgsForCall[i]
+func (fake *FakeStore) CreateIdempotencyKeyArgsForCall(i int) (context.Context, db.CreateIdempotencyKeyParams) {
+	fake.createIdempotencyKeyMutex.RLock()
+	defer fake.createIdempotencyKeyMutex.RUnlock()
+	argsForCall := fake.createIdempotencyKeyArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeStore) CreateSessionReturns(result1 db.Session, result2 error) {
-	fake.createSessionMutex.Lock()
-	defer fake.createSessionMutex.Unlock()
-	fake.CreateSessionStub = nil
-	fake.createSessionReturns = struct {
-		result1 db.Session
+func (fake *FakeStore) CreateIdempotencyKeyReturns(result1 db.IdempotencyKey, result2 error) {
+	fake.createIdempotencyKeyMutex.Lock()
+	defer fake.createIdempotencyKeyMutex.Unlock()
+	fake.CreateIdempotencyKeyStub = nil
+	fake.createIdempotencyKeyReturns = struct {
+		result1 db.IdempotencyKey
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) CreateSessionReturnsOnCall(i int, result1 db.Session, result2 error) {
-	fake.createSessionMutex.Lock()
-	defer fake.createSessionMutex.Unlock()
-	fake.CreateSessionStub = nil
-	if fake.createSessionReturnsOnCall == nil {
-		fake.createSessionReturnsOnCall = make(map[int]struct {
-			result1 db.Session
+func (fake *FakeStore) CreateIdempotencyKeyReturnsOnCall(i int, result1 db.IdempotencyKey, result2 error) {
+	fake.createIdempotencyKeyMutex.Lock()
+	defer fake.createIdempotencyKeyMutex.Unlock()
+	fake.CreateIdempotencyKeyStub = nil
+	if fake.createIdempotencyKeyReturnsOnCall == nil {
+		fake.createIdempotencyKeyReturnsOnCall = make(map[int]struct {
+			result1 db.IdempotencyKey
 			result2 error
 		})
 	}
-	fake.createSessionReturnsOnCall[i] = struct {
-		result1 db.Session
+	fake.createIdempotencyKeyReturnsOnCall[i] = struct {
+		result1 db.IdempotencyKey
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) CreateTransfer(arg1 context.Context, arg2 db.CreateTransferParams) (db.Transfer, error) {
-	fake.createTransferMutex.Lock()
-	ret, specificReturn := fake.createTransferReturnsOnCall[len(fake.createTransferArgsForCall)]
-	fake.createTransferArgsForCall = append(fake.createTransferArgsForCall, struct {
+func (fake *FakeStore) CreateLoginLink(arg1 context.Context, arg2 db.CreateLoginLinkParams) (db.LoginLink, error) {
+	fake.createLoginLinkMutex.Lock()
+	ret, specificReturn := fake.createLoginLinkReturnsOnCall[len(fake.createLoginLinkArgsForCall)]
+	fake.createLoginLinkArgsForCall = append(fake.createLoginLinkArgsForCall, struct {
 		arg1 context.Context
-		arg2 db.CreateTransferParams
+		arg2 db.CreateLoginLinkParams
 	}{arg1, arg2})
-	stub := fake.CreateTransferStub
-	fakeReturns := fake.createTransferReturns
-	fake.recordInvocation("CreateTransfer", []interface{}{arg1, arg2})
-	fake.createTransferMutex.Unlock()
+	stub := fake.CreateLoginLinkStub
+	fakeReturns := fake.createLoginLinkReturns
+	fake.recordInvocation("CreateLoginLink", []interface{}{arg1, arg2})
+	fake.createLoginLinkMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -614,62 +1462,62 @@ func (fake *FakeStore) CreateTransfer(arg1 context.Context, arg2 db.CreateTransf
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeStore) CreateTransferCallCount() int {
-	fake.createTransferMutex.RLock()
-	defer fake.createTransferMutex.RUnlock()
-	return len(fake.createTransferArgsForCall)
+func (fake *FakeStore) CreateLoginLinkCallCount() int {
+	fake.createLoginLinkMutex.RLock()
+	defer fake.createLoginLinkMutex.RUnlock()
+	return len(fake.createLoginLinkArgsForCall)
 }
 
-func (fake *FakeStore) CreateTransferCalls(stub func(context.Context, db.CreateTransferParams) (db.Transfer, error)) {
-	fake.createTransferMutex.Lock()
-	defer fake.createTransferMutex.Unlock()
-	fake.CreateTransferStub = stub
+func (fake *FakeStore) CreateLoginLinkCalls(stub func(context.Context, db.CreateLoginLinkParams) (db.LoginLink, error)) {
+	fake.createLoginLinkMutex.Lock()
+	defer fake.createLoginLinkMutex.Unlock()
+	fake.CreateLoginLinkStub = stub
 }
 
-func (fake *FakeStore) CreateTransferArgsForCall(i int) (context.Context, db.CreateTransferParams) {
-	fake.createTransferMutex.RLock()
-	defer fake.createTransferMutex.RUnlock()
-	argsForCall := fake.createTransferArgsForCall[i]
+func (fake *FakeStore) CreateLoginLinkArgsForCall(i int) (context.Context, db.CreateLoginLinkParams) {
+	fake.createLoginLinkMutex.RLock()
+	defer fake.createLoginLinkMutex.RUnlock()
+	argsForCall := fake.createLoginLinkArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeStore) CreateTransferReturns(result1 db.Transfer, result2 error) {
-	fake.createTransferMutex.Lock()
-	defer fake.createTransferMutex.Unlock()
-	fake.CreateTransferStub = nil
-	fake.createTransferReturns = struct {
-		result1 db.Transfer
+func (fake *FakeStore) CreateLoginLinkReturns(result1 db.LoginLink, result2 error) {
+	fake.createLoginLinkMutex.Lock()
+	defer fake.createLoginLinkMutex.Unlock()
+	fake.CreateLoginLinkStub = nil
+	fake.createLoginLinkReturns = struct {
+		result1 db.LoginLink
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) CreateTransferReturnsOnCall(i int, result1 db.Transfer, result2 error) {
-	fake.createTransferMutex.Lock()
-	defer fake.createTransferMutex.Unlock()
-	fake.CreateTransferStub = nil
-	if fake.createTransferReturnsOnCall == nil {
-		fake.createTransferReturnsOnCall = make(map[int]struct {
-			result1 db.Transfer
+func (fake *FakeStore) CreateLoginLinkReturnsOnCall(i int, result1 db.LoginLink, result2 error) {
+	fake.createLoginLinkMutex.Lock()
+	defer fake.createLoginLinkMutex.Unlock()
+	fake.CreateLoginLinkStub = nil
+	if fake.createLoginLinkReturnsOnCall == nil {
+		fake.createLoginLinkReturnsOnCall = make(map[int]struct {
+			result1 db.LoginLink
 			result2 error
 		})
 	}
-	fake.createTransferReturnsOnCall[i] = struct {
-		result1 db.Transfer
+	fake.createLoginLinkReturnsOnCall[i] = struct {
+		result1 db.LoginLink
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) CreateUser(arg1 context.Context, arg2 db.CreateUserParams) (db.User, error) {
-	fake.createUserMutex.Lock()
-	ret, specificReturn := fake.createUserReturnsOnCall[len(fake.createUserArgsForCall)]
-	fake.createUserArgsForCall = append(fake.createUserArgsForCall, struct {
+func (fake *FakeStore) CreateOutboxMessage(arg1 context.Context, arg2 db.CreateOutboxMessageParams) (db.Outbox, error) {
+	fake.createOutboxMessageMutex.Lock()
+	ret, specificReturn := fake.createOutboxMessageReturnsOnCall[len(fake.createOutboxMessageArgsForCall)]
+	fake.createOutboxMessageArgsForCall = append(fake.createOutboxMessageArgsForCall, struct {
 		arg1 context.Context
-		arg2 db.CreateUserParams
+		arg2 db.CreateOutboxMessageParams
 	}{arg1, arg2})
-	stub := fake.CreateUserStub
-	fakeReturns := fake.createUserReturns
-	fake.recordInvocation("CreateUser", []interface{}{arg1, arg2})
-	fake.createUserMutex.Unlock()
+	stub := fake.CreateOutboxMessageStub
+	fakeReturns := fake.createOutboxMessageReturns
+	fake.recordInvocation("CreateOutboxMessage", []interface{}{arg1, arg2})
+	fake.createOutboxMessageMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -679,38 +1527,363 @@ func (fake *FakeStore) CreateUser(arg1 context.Context, arg2 db.CreateUserParams
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeStore) CreateUserCallCount() int {
-	fake.createUserMutex.RLock()
-	defer fake.createUserMutex.RUnlock()
-	return len(fake.createUserArgsForCall)
+func (fake *FakeStore) CreateOutboxMessageCallCount() int {
+	fake.createOutboxMessageMutex.RLock()
+	defer fake.createOutboxMessageMutex.RUnlock()
+	return len(fake.createOutboxMessageArgsForCall)
 }
 
-func (fake *FakeStore) CreateUserCalls(stub func(context.Context, db.CreateUserParams) (db.User, error)) {
-	fake.createUserMutex.Lock()
-	defer fake.createUserMutex.Unlock()
-	fake.CreateUserStub = stub
+func (fake *FakeStore) CreateOutboxMessageCalls(stub func(context.Context, db.CreateOutboxMessageParams) (db.Outbox, error)) {
+	fake.createOutboxMessageMutex.Lock()
+	defer fake.createOutboxMessageMutex.Unlock()
+	fake.CreateOutboxMessageStub = stub
 }
 
-func (fake *FakeStore) CreateUserArgsForCall(i int) (context.Context, db.CreateUserParams) {
-	fake.createUserMutex.RLock()
-	defer fake.createUserMutex.RUnlock()
-	argsForCall := fake.createUserArgsForCall[i]
+func (fake *FakeStore) CreateOutboxMessageArgsForCall(i int) (context.Context, db.CreateOutboxMessageParams) {
+	fake.createOutboxMessageMutex.RLock()
+	defer fake.createOutboxMessageMutex.RUnlock()
+	argsForCall := fake.createOutboxMessageArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeStore) CreateUserReturns(result1 db.User, result2 error) {
-	fake.createUserMutex.Lock()
-	defer fake.createUserMutex.Unlock()
-	fake.CreateUserStub = nil
-	fake.createUserReturns = struct {
-		result1 db.User
+func (fake *FakeStore) CreateOutboxMessageReturns(result1 db.Outbox, result2 error) {
+	fake.createOutboxMessageMutex.Lock()
+	defer fake.createOutboxMessageMutex.Unlock()
+	fake.CreateOutboxMessageStub = nil
+	fake.createOutboxMessageReturns = struct {
+		result1 db.Outbox
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) CreateUserReturnsOnCall(i int, result1 db.User, result2 error) {
-	fake.createUserMutex.Lock()
-	defer fake.createUserMutex.Unlock()
+func (fake *FakeStore) CreateOutboxMessageReturnsOnCall(i int, result1 db.Outbox, result2 error) {
+	fake.createOutboxMessageMutex.Lock()
+	defer fake.createOutboxMessageMutex.Unlock()
+	fake.CreateOutboxMessageStub = nil
+	if fake.createOutboxMessageReturnsOnCall == nil {
+		fake.createOutboxMessageReturnsOnCall = make(map[int]struct {
+			result1 db.Outbox
+			result2 error
+		})
+	}
+	fake.createOutboxMessageReturnsOnCall[i] = struct {
+		result1 db.Outbox
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CreateRecoveryCode(arg1 context.Context, arg2 db.CreateRecoveryCodeParams) (db.RecoveryCode, error) {
+	fake.createRecoveryCodeMutex.Lock()
+	ret, specificReturn := fake.createRecoveryCodeReturnsOnCall[len(fake.createRecoveryCodeArgsForCall)]
+	fake.createRecoveryCodeArgsForCall = append(fake.createRecoveryCodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.CreateRecoveryCodeParams
+	}{arg1, arg2})
+	stub := fake.CreateRecoveryCodeStub
+	fakeReturns := fake.createRecoveryCodeReturns
+	fake.recordInvocation("CreateRecoveryCode", []interface{}{arg1, arg2})
+	fake.createRecoveryCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) CreateRecoveryCodeCallCount() int {
+	fake.createRecoveryCodeMutex.RLock()
+	defer fake.createRecoveryCodeMutex.RUnlock()
+	return len(fake.createRecoveryCodeArgsForCall)
+}
+
+func (fake *FakeStore) CreateRecoveryCodeCalls(stub func(context.Context, db.CreateRecoveryCodeParams) (db.RecoveryCode, error)) {
+	fake.createRecoveryCodeMutex.Lock()
+	defer fake.createRecoveryCodeMutex.Unlock()
+	fake.CreateRecoveryCodeStub = stub
+}
+
+func (fake *FakeStore) CreateRecoveryCodeArgsForCall(i int) (context.Context, db.CreateRecoveryCodeParams) {
+	fake.createRecoveryCodeMutex.RLock()
+	defer fake.createRecoveryCodeMutex.RUnlock()
+	argsForCall := fake.createRecoveryCodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) CreateRecoveryCodeReturns(result1 db.RecoveryCode, result2 error) {
+	fake.createRecoveryCodeMutex.Lock()
+	defer fake.createRecoveryCodeMutex.Unlock()
+	fake.CreateRecoveryCodeStub = nil
+	fake.createRecoveryCodeReturns = struct {
+		result1 db.RecoveryCode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CreateRecoveryCodeReturnsOnCall(i int, result1 db.RecoveryCode, result2 error) {
+	fake.createRecoveryCodeMutex.Lock()
+	defer fake.createRecoveryCodeMutex.Unlock()
+	fake.CreateRecoveryCodeStub = nil
+	if fake.createRecoveryCodeReturnsOnCall == nil {
+		fake.createRecoveryCodeReturnsOnCall = make(map[int]struct {
+			result1 db.RecoveryCode
+			result2 error
+		})
+	}
+	fake.createRecoveryCodeReturnsOnCall[i] = struct {
+		result1 db.RecoveryCode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CreateResetPassword(arg1 context.Context, arg2 db.CreateResetPasswordParams) (db.ResetPassword, error) {
+	fake.createResetPasswordMutex.Lock()
+	ret, specificReturn := fake.createResetPasswordReturnsOnCall[len(fake.createResetPasswordArgsForCall)]
+	fake.createResetPasswordArgsForCall = append(fake.createResetPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.CreateResetPasswordParams
+	}{arg1, arg2})
+	stub := fake.CreateResetPasswordStub
+	fakeReturns := fake.createResetPasswordReturns
+	fake.recordInvocation("CreateResetPassword", []interface{}{arg1, arg2})
+	fake.createResetPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) CreateResetPasswordCallCount() int {
+	fake.createResetPasswordMutex.RLock()
+	defer fake.createResetPasswordMutex.RUnlock()
+	return len(fake.createResetPasswordArgsForCall)
+}
+
+func (fake *FakeStore) CreateResetPasswordCalls(stub func(context.Context, db.CreateResetPasswordParams) (db.ResetPassword, error)) {
+	fake.createResetPasswordMutex.Lock()
+	defer fake.createResetPasswordMutex.Unlock()
+	fake.CreateResetPasswordStub = stub
+}
+
+func (fake *FakeStore) CreateResetPasswordArgsForCall(i int) (context.Context, db.CreateResetPasswordParams) {
+	fake.createResetPasswordMutex.RLock()
+	defer fake.createResetPasswordMutex.RUnlock()
+	argsForCall := fake.createResetPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) CreateResetPasswordReturns(result1 db.ResetPassword, result2 error) {
+	fake.createResetPasswordMutex.Lock()
+	defer fake.createResetPasswordMutex.Unlock()
+	fake.CreateResetPasswordStub = nil
+	fake.createResetPasswordReturns = struct {
+		result1 db.ResetPassword
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CreateResetPasswordReturnsOnCall(i int, result1 db.ResetPassword, result2 error) {
+	fake.createResetPasswordMutex.Lock()
+	defer fake.createResetPasswordMutex.Unlock()
+	fake.CreateResetPasswordStub = nil
+	if fake.createResetPasswordReturnsOnCall == nil {
+		fake.createResetPasswordReturnsOnCall = make(map[int]struct {
+			result1 db.ResetPassword
+			result2 error
+		})
+	}
+	fake.createResetPasswordReturnsOnCall[i] = struct {
+		result1 db.ResetPassword
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CreateSession(arg1 context.Context, arg2 db.CreateSessionParams) (db.Session, error) {
+	fake.createSessionMutex.Lock()
+	ret, specificReturn := fake.createSessionReturnsOnCall[len(fake.createSessionArgsForCall)]
+	fake.createSessionArgsForCall = append(fake.createSessionArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.CreateSessionParams
+	}{arg1, arg2})
+	stub := fake.CreateSessionStub
+	fakeReturns := fake.createSessionReturns
+	fake.recordInvocation("CreateSession", []interface{}{arg1, arg2})
+	fake.createSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) CreateSessionCallCount() int {
+	fake.createSessionMutex.RLock()
+	defer fake.createSessionMutex.RUnlock()
+	return len(fake.createSessionArgsForCall)
+}
+
+func (fake *FakeStore) CreateSessionCalls(stub func(context.Context, db.CreateSessionParams) (db.Session, error)) {
+	fake.createSessionMutex.Lock()
+	defer fake.createSessionMutex.Unlock()
+	fake.CreateSessionStub = stub
+}
+
+func (fake *FakeStore) CreateSessionArgsForCall(i int) (context.Context, db.CreateSessionParams) {
+	fake.createSessionMutex.RLock()
+	defer fake.createSessionMutex.RUnlock()
+	argsForCall := fake.createSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) CreateSessionReturns(result1 db.Session, result2 error) {
+	fake.createSessionMutex.Lock()
+	defer fake.createSessionMutex.Unlock()
+	fake.CreateSessionStub = nil
+	fake.createSessionReturns = struct {
+		result1 db.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CreateSessionReturnsOnCall(i int, result1 db.Session, result2 error) {
+	fake.createSessionMutex.Lock()
+	defer fake.createSessionMutex.Unlock()
+	fake.CreateSessionStub = nil
+	if fake.createSessionReturnsOnCall == nil {
+		fake.createSessionReturnsOnCall = make(map[int]struct {
+			result1 db.Session
+			result2 error
+		})
+	}
+	fake.createSessionReturnsOnCall[i] = struct {
+		result1 db.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CreateTransfer(arg1 context.Context, arg2 db.CreateTransferParams) (db.Transfer, error) {
+	fake.createTransferMutex.Lock()
+	ret, specificReturn := fake.createTransferReturnsOnCall[len(fake.createTransferArgsForCall)]
+	fake.createTransferArgsForCall = append(fake.createTransferArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.CreateTransferParams
+	}{arg1, arg2})
+	stub := fake.CreateTransferStub
+	fakeReturns := fake.createTransferReturns
+	fake.recordInvocation("CreateTransfer", []interface{}{arg1, arg2})
+	fake.createTransferMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) CreateTransferCallCount() int {
+	fake.createTransferMutex.RLock()
+	defer fake.createTransferMutex.RUnlock()
+	return len(fake.createTransferArgsForCall)
+}
+
+func (fake *FakeStore) CreateTransferCalls(stub func(context.Context, db.CreateTransferParams) (db.Transfer, error)) {
+	fake.createTransferMutex.Lock()
+	defer fake.createTransferMutex.Unlock()
+	fake.CreateTransferStub = stub
+}
+
+func (fake *FakeStore) CreateTransferArgsForCall(i int) (context.Context, db.CreateTransferParams) {
+	fake.createTransferMutex.RLock()
+	defer fake.createTransferMutex.RUnlock()
+	argsForCall := fake.createTransferArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) CreateTransferReturns(result1 db.Transfer, result2 error) {
+	fake.createTransferMutex.Lock()
+	defer fake.createTransferMutex.Unlock()
+	fake.CreateTransferStub = nil
+	fake.createTransferReturns = struct {
+		result1 db.Transfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CreateTransferReturnsOnCall(i int, result1 db.Transfer, result2 error) {
+	fake.createTransferMutex.Lock()
+	defer fake.createTransferMutex.Unlock()
+	fake.CreateTransferStub = nil
+	if fake.createTransferReturnsOnCall == nil {
+		fake.createTransferReturnsOnCall = make(map[int]struct {
+			result1 db.Transfer
+			result2 error
+		})
+	}
+	fake.createTransferReturnsOnCall[i] = struct {
+		result1 db.Transfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CreateUser(arg1 context.Context, arg2 db.CreateUserParams) (db.User, error) {
+	fake.createUserMutex.Lock()
+	ret, specificReturn := fake.createUserReturnsOnCall[len(fake.createUserArgsForCall)]
+	fake.createUserArgsForCall = append(fake.createUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.CreateUserParams
+	}{arg1, arg2})
+	stub := fake.CreateUserStub
+	fakeReturns := fake.createUserReturns
+	fake.recordInvocation("CreateUser", []interface{}{arg1, arg2})
+	fake.createUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) CreateUserCallCount() int {
+	fake.createUserMutex.RLock()
+	defer fake.createUserMutex.RUnlock()
+	return len(fake.createUserArgsForCall)
+}
+
+func (fake *FakeStore) CreateUserCalls(stub func(context.Context, db.CreateUserParams) (db.User, error)) {
+	fake.createUserMutex.Lock()
+	defer fake.createUserMutex.Unlock()
+	fake.CreateUserStub = stub
+}
+
+func (fake *FakeStore) CreateUserArgsForCall(i int) (context.Context, db.CreateUserParams) {
+	fake.createUserMutex.RLock()
+	defer fake.createUserMutex.RUnlock()
+	argsForCall := fake.createUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) CreateUserReturns(result1 db.User, result2 error) {
+	fake.createUserMutex.Lock()
+	defer fake.createUserMutex.Unlock()
+	fake.CreateUserStub = nil
+	fake.createUserReturns = struct {
+		result1 db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) CreateUserReturnsOnCall(i int, result1 db.User, result2 error) {
+	fake.createUserMutex.Lock()
+	defer fake.createUserMutex.Unlock()
 	fake.CreateUserStub = nil
 	if fake.createUserReturnsOnCall == nil {
 		fake.createUserReturnsOnCall = make(map[int]struct {
@@ -916,19 +2089,18 @@ func (fake *FakeStore) DeleteAccountReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeStore) GetAccount(arg1 context.Context, arg2 int64) (db.Account, error) {
-	fake.getAccountMutex.Lock()
-	ret, specificReturn := fake.getAccountReturnsOnCall[len(fake.getAccountArgsForCall)]
-	fake.getAccountArgsForCall = append(fake.getAccountArgsForCall, struct {
+func (fake *FakeStore) DeleteExpiredSessions(arg1 context.Context) (int64, error) {
+	fake.deleteExpiredSessionsMutex.Lock()
+	ret, specificReturn := fake.deleteExpiredSessionsReturnsOnCall[len(fake.deleteExpiredSessionsArgsForCall)]
+	fake.deleteExpiredSessionsArgsForCall = append(fake.deleteExpiredSessionsArgsForCall, struct {
 		arg1 context.Context
-		arg2 int64
-	}{arg1, arg2})
-	stub := fake.GetAccountStub
-	fakeReturns := fake.getAccountReturns
-	fake.recordInvocation("GetAccount", []interface{}{arg1, arg2})
-	fake.getAccountMutex.Unlock()
+	}{arg1})
+	stub := fake.DeleteExpiredSessionsStub
+	fakeReturns := fake.deleteExpiredSessionsReturns
+	fake.recordInvocation("DeleteExpiredSessions", []interface{}{arg1})
+	fake.deleteExpiredSessionsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -936,127 +2108,124 @@ func (fake *FakeStore) GetAccount(arg1 context.Context, arg2 int64) (db.Account,
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeStore) GetAccountCallCount() int {
-	fake.getAccountMutex.RLock()
-	defer fake.getAccountMutex.RUnlock()
-	return len(fake.getAccountArgsForCall)
+func (fake *FakeStore) DeleteExpiredSessionsCallCount() int {
+	fake.deleteExpiredSessionsMutex.RLock()
+	defer fake.deleteExpiredSessionsMutex.RUnlock()
+	return len(fake.deleteExpiredSessionsArgsForCall)
 }
 
-func (fake *FakeStore) GetAccountCalls(stub func(context.Context, int64) (db.Account, error)) {
-	fake.getAccountMutex.Lock()
-	defer fake.getAccountMutex.Unlock()
-	fake.GetAccountStub = stub
+func (fake *FakeStore) DeleteExpiredSessionsCalls(stub func(context.Context) (int64, error)) {
+	fake.deleteExpiredSessionsMutex.Lock()
+	defer fake.deleteExpiredSessionsMutex.Unlock()
+	fake.DeleteExpiredSessionsStub = stub
 }
 
-func (fake *FakeStore) GetAccountArgsForCall(i int) (context.Context, int64) {
-	fake.getAccountMutex.RLock()
-	defer fake.getAccountMutex.RUnlock()
-	argsForCall := fake.getAccountArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *FakeStore) DeleteExpiredSessionsArgsForCall(i int) context.Context {
+	fake.deleteExpiredSessionsMutex.RLock()
+	defer fake.deleteExpiredSessionsMutex.RUnlock()
+	argsForCall := fake.deleteExpiredSessionsArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakeStore) GetAccountReturns(result1 db.Account, result2 error) {
-	fake.getAccountMutex.Lock()
-	defer fake.getAccountMutex.Unlock()
-	fake.GetAccountStub = nil
-	fake.getAccountReturns = struct {
-		result1 db.Account
+func (fake *FakeStore) DeleteExpiredSessionsReturns(result1 int64, result2 error) {
+	fake.deleteExpiredSessionsMutex.Lock()
+	defer fake.deleteExpiredSessionsMutex.Unlock()
+	fake.DeleteExpiredSessionsStub = nil
+	fake.deleteExpiredSessionsReturns = struct {
+		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) GetAccountReturnsOnCall(i int, result1 db.Account, result2 error) {
-	fake.getAccountMutex.Lock()
-	defer fake.getAccountMutex.Unlock()
-	fake.GetAccountStub = nil
-	if fake.getAccountReturnsOnCall == nil {
-		fake.getAccountReturnsOnCall = make(map[int]struct {
-			result1 db.Account
+func (fake *FakeStore) DeleteExpiredSessionsReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.deleteExpiredSessionsMutex.Lock()
+	defer fake.deleteExpiredSessionsMutex.Unlock()
+	fake.DeleteExpiredSessionsStub = nil
+	if fake.deleteExpiredSessionsReturnsOnCall == nil {
+		fake.deleteExpiredSessionsReturnsOnCall = make(map[int]struct {
+			result1 int64
 			result2 error
 		})
 	}
-	fake.getAccountReturnsOnCall[i] = struct {
-		result1 db.Account
+	fake.deleteExpiredSessionsReturnsOnCall[i] = struct {
+		result1 int64
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) GetAccountForUpdate(arg1 context.Context, arg2 int64) (db.Account, error) {
-	fake.getAccountForUpdateMutex.Lock()
-	ret, specificReturn := fake.getAccountForUpdateReturnsOnCall[len(fake.getAccountForUpdateArgsForCall)]
-	fake.getAccountForUpdateArgsForCall = append(fake.getAccountForUpdateArgsForCall, struct {
+func (fake *FakeStore) DeleteRecoveryCodes(arg1 context.Context, arg2 string) error {
+	fake.deleteRecoveryCodesMutex.Lock()
+	ret, specificReturn := fake.deleteRecoveryCodesReturnsOnCall[len(fake.deleteRecoveryCodesArgsForCall)]
+	fake.deleteRecoveryCodesArgsForCall = append(fake.deleteRecoveryCodesArgsForCall, struct {
 		arg1 context.Context
-		arg2 int64
+		arg2 string
 	}{arg1, arg2})
-	stub := fake.GetAccountForUpdateStub
-	fakeReturns := fake.getAccountForUpdateReturns
-	fake.recordInvocation("GetAccountForUpdate", []interface{}{arg1, arg2})
-	fake.getAccountForUpdateMutex.Unlock()
+	stub := fake.DeleteRecoveryCodesStub
+	fakeReturns := fake.deleteRecoveryCodesReturns
+	fake.recordInvocation("DeleteRecoveryCodes", []interface{}{arg1, arg2})
+	fake.deleteRecoveryCodesMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *FakeStore) GetAccountForUpdateCallCount() int {
-	fake.getAccountForUpdateMutex.RLock()
-	defer fake.getAccountForUpdateMutex.RUnlock()
-	return len(fake.getAccountForUpdateArgsForCall)
+func (fake *FakeStore) DeleteRecoveryCodesCallCount() int {
+	fake.deleteRecoveryCodesMutex.RLock()
+	defer fake.deleteRecoveryCodesMutex.RUnlock()
+	return len(fake.deleteRecoveryCodesArgsForCall)
 }
 
-func (fake *FakeStore) GetAccountForUpdateCalls(stub func(context.Context, int64) (db.Account, error)) {
-	fake.getAccountForUpdateMutex.Lock()
-	defer fake.getAccountForUpdateMutex.Unlock()
-	fake.GetAccountForUpdateStub = stub
+func (fake *FakeStore) DeleteRecoveryCodesCalls(stub func(context.Context, string) error) {
+	fake.deleteRecoveryCodesMutex.Lock()
+	defer fake.deleteRecoveryCodesMutex.Unlock()
+	fake.DeleteRecoveryCodesStub = stub
 }
 
-func (fake *FakeStore) GetAccountForUpdateArgsForCall(i int) (context.Context, int64) {
-	fake.getAccountForUpdateMutex.RLock()
-	defer fake.getAccountForUpdateMutex.RUnlock()
-	argsForCall := fake.getAccountForUpdateArgsForCall[i]
+func (fake *FakeStore) DeleteRecoveryCodesArgsForCall(i int) (context.Context, string) {
+	fake.deleteRecoveryCodesMutex.RLock()
+	defer fake.deleteRecoveryCodesMutex.RUnlock()
+	argsForCall := fake.deleteRecoveryCodesArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeStore) GetAccountForUpdateReturns(result1 db.Account, result2 error) {
-	fake.getAccountForUpdateMutex.Lock()
-	defer fake.getAccountForUpdateMutex.Unlock()
-	fake.GetAccountForUpdateStub = nil
-	fake.getAccountForUpdateReturns = struct {
-		result1 db.Account
-		result2 error
-	}{result1, result2}
+func (fake *FakeStore) DeleteRecoveryCodesReturns(result1 error) {
+	fake.deleteRecoveryCodesMutex.Lock()
+	defer fake.deleteRecoveryCodesMutex.Unlock()
+	fake.DeleteRecoveryCodesStub = nil
+	fake.deleteRecoveryCodesReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeStore) GetAccountForUpdateReturnsOnCall(i int, result1 db.Account, result2 error) {
-	fake.getAccountForUpdateMutex.Lock()
-	defer fake.getAccountForUpdateMutex.Unlock()
-	fake.GetAccountForUpdateStub = nil
-	if fake.getAccountForUpdateReturnsOnCall == nil {
-		fake.getAccountForUpdateReturnsOnCall = make(map[int]struct {
-			result1 db.Account
-			result2 error
+func (fake *FakeStore) DeleteRecoveryCodesReturnsOnCall(i int, result1 error) {
+	fake.deleteRecoveryCodesMutex.Lock()
+	defer fake.deleteRecoveryCodesMutex.Unlock()
+	fake.DeleteRecoveryCodesStub = nil
+	if fake.deleteRecoveryCodesReturnsOnCall == nil {
+		fake.deleteRecoveryCodesReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getAccountForUpdateReturnsOnCall[i] = struct {
-		result1 db.Account
-		result2 error
-	}{result1, result2}
+	fake.deleteRecoveryCodesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeStore) GetEntry(arg1 context.Context, arg2 int64) (db.Entry, error) {
-	fake.getEntryMutex.Lock()
-	ret, specificReturn := fake.getEntryReturnsOnCall[len(fake.getEntryArgsForCall)]
-	fake.getEntryArgsForCall = append(fake.getEntryArgsForCall, struct {
+func (fake *FakeStore) DepositTx(arg1 context.Context, arg2 db.CashTxParams) (db.CashTxResult, error) {
+	fake.depositTxMutex.Lock()
+	ret, specificReturn := fake.depositTxReturnsOnCall[len(fake.depositTxArgsForCall)]
+	fake.depositTxArgsForCall = append(fake.depositTxArgsForCall, struct {
 		arg1 context.Context
-		arg2 int64
+		arg2 db.CashTxParams
 	}{arg1, arg2})
-	stub := fake.GetEntryStub
-	fakeReturns := fake.getEntryReturns
-	fake.recordInvocation("GetEntry", []interface{}{arg1, arg2})
-	fake.getEntryMutex.Unlock()
+	stub := fake.DepositTxStub
+	fakeReturns := fake.depositTxReturns
+	fake.recordInvocation("DepositTx", []interface{}{arg1, arg2})
+	fake.depositTxMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -1066,62 +2235,62 @@ func (fake *FakeStore) GetEntry(arg1 context.Context, arg2 int64) (db.Entry, err
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeStore) GetEntryCallCount() int {
-	fake.getEntryMutex.RLock()
-	defer fake.getEntryMutex.RUnlock()
-	return len(fake.getEntryArgsForCall)
+func (fake *FakeStore) DepositTxCallCount() int {
+	fake.depositTxMutex.RLock()
+	defer fake.depositTxMutex.RUnlock()
+	return len(fake.depositTxArgsForCall)
 }
 
-func (fake *FakeStore) GetEntryCalls(stub func(context.Context, int64) (db.Entry, error)) {
-	fake.getEntryMutex.Lock()
-	defer fake.getEntryMutex.Unlock()
-	fake.GetEntryStub = stub
+func (fake *FakeStore) DepositTxCalls(stub func(context.Context, db.CashTxParams) (db.CashTxResult, error)) {
+	fake.depositTxMutex.Lock()
+	defer fake.depositTxMutex.Unlock()
+	fake.DepositTxStub = stub
 }
 
-func (fake *FakeStore) GetEntryArgsForCall(i int) (context.Context, int64) {
-	fake.getEntryMutex.RLock()
-	defer fake.getEntryMutex.RUnlock()
-	argsForCall := fake.getEntryArgsForCall[i]
+func (fake *FakeStore) DepositTxArgsForCall(i int) (context.Context, db.CashTxParams) {
+	fake.depositTxMutex.RLock()
+	defer fake.depositTxMutex.RUnlock()
+	argsForCall := fake.depositTxArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeStore) GetEntryReturns(result1 db.Entry, result2 error) {
-	fake.getEntryMutex.Lock()
-	defer fake.getEntryMutex.Unlock()
-	fake.GetEntryStub = nil
-	fake.getEntryReturns = struct {
-		result1 db.Entry
+func (fake *FakeStore) DepositTxReturns(result1 db.CashTxResult, result2 error) {
+	fake.depositTxMutex.Lock()
+	defer fake.depositTxMutex.Unlock()
+	fake.DepositTxStub = nil
+	fake.depositTxReturns = struct {
+		result1 db.CashTxResult
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) GetEntryReturnsOnCall(i int, result1 db.Entry, result2 error) {
-	fake.getEntryMutex.Lock()
-	defer fake.getEntryMutex.Unlock()
-	fake.GetEntryStub = nil
-	if fake.getEntryReturnsOnCall == nil {
-		fake.getEntryReturnsOnCall = make(map[int]struct {
-			result1 db.Entry
+func (fake *FakeStore) DepositTxReturnsOnCall(i int, result1 db.CashTxResult, result2 error) {
+	fake.depositTxMutex.Lock()
+	defer fake.depositTxMutex.Unlock()
+	fake.DepositTxStub = nil
+	if fake.depositTxReturnsOnCall == nil {
+		fake.depositTxReturnsOnCall = make(map[int]struct {
+			result1 db.CashTxResult
 			result2 error
 		})
 	}
-	fake.getEntryReturnsOnCall[i] = struct {
-		result1 db.Entry
+	fake.depositTxReturnsOnCall[i] = struct {
+		result1 db.CashTxResult
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) GetSession(arg1 context.Context, arg2 uuid.UUID) (db.Session, error) {
-	fake.getSessionMutex.Lock()
-	ret, specificReturn := fake.getSessionReturnsOnCall[len(fake.getSessionArgsForCall)]
-	fake.getSessionArgsForCall = append(fake.getSessionArgsForCall, struct {
+func (fake *FakeStore) DisableTOTPTx(arg1 context.Context, arg2 string) (db.User, error) {
+	fake.disableTOTPTxMutex.Lock()
+	ret, specificReturn := fake.disableTOTPTxReturnsOnCall[len(fake.disableTOTPTxArgsForCall)]
+	fake.disableTOTPTxArgsForCall = append(fake.disableTOTPTxArgsForCall, struct {
 		arg1 context.Context
-		arg2 uuid.UUID
+		arg2 string
 	}{arg1, arg2})
-	stub := fake.GetSessionStub
-	fakeReturns := fake.getSessionReturns
-	fake.recordInvocation("GetSession", []interface{}{arg1, arg2})
-	fake.getSessionMutex.Unlock()
+	stub := fake.DisableTOTPTxStub
+	fakeReturns := fake.disableTOTPTxReturns
+	fake.recordInvocation("DisableTOTPTx", []interface{}{arg1, arg2})
+	fake.disableTOTPTxMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -1131,62 +2300,62 @@ func (fake *FakeStore) GetSession(arg1 context.Context, arg2 uuid.UUID) (db.Sess
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeStore) GetSessionCallCount() int {
-	fake.getSessionMutex.RLock()
-	defer fake.getSessionMutex.RUnlock()
-	return len(fake.getSessionArgsForCall)
+func (fake *FakeStore) DisableTOTPTxCallCount() int {
+	fake.disableTOTPTxMutex.RLock()
+	defer fake.disableTOTPTxMutex.RUnlock()
+	return len(fake.disableTOTPTxArgsForCall)
 }
 
-func (fake *FakeStore) GetSessionCalls(stub func(context.Context, uuid.UUID) (db.Session, error)) {
-	fake.getSessionMutex.Lock()
-	defer fake.getSessionMutex.Unlock()
-	fake.GetSessionStub = stub
+func (fake *FakeStore) DisableTOTPTxCalls(stub func(context.Context, string) (db.User, error)) {
+	fake.disableTOTPTxMutex.Lock()
+	defer fake.disableTOTPTxMutex.Unlock()
+	fake.DisableTOTPTxStub = stub
 }
 
-func (fake *FakeStore) GetSessionArgsForCall(i int) (context.Context, uuid.UUID) {
-	fake.getSessionMutex.RLock()
-	defer fake.getSessionMutex.RUnlock()
-	argsForCall := fake.getSessionArgsForCall[i]
+func (fake *FakeStore) DisableTOTPTxArgsForCall(i int) (context.Context, string) {
+	fake.disableTOTPTxMutex.RLock()
+	defer fake.disableTOTPTxMutex.RUnlock()
+	argsForCall := fake.disableTOTPTxArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeStore) GetSessionReturns(result1 db.Session, result2 error) {
-	fake.getSessionMutex.Lock()
-	defer fake.getSessionMutex.Unlock()
-	fake.GetSessionStub = nil
-	fake.getSessionReturns = struct {
-		result1 db.Session
+func (fake *FakeStore) DisableTOTPTxReturns(result1 db.User, result2 error) {
+	fake.disableTOTPTxMutex.Lock()
+	defer fake.disableTOTPTxMutex.Unlock()
+	fake.DisableTOTPTxStub = nil
+	fake.disableTOTPTxReturns = struct {
+		result1 db.User
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) GetSessionReturnsOnCall(i int, result1 db.Session, result2 error) {
-	fake.getSessionMutex.Lock()
-	defer fake.getSessionMutex.Unlock()
-	fake.GetSessionStub = nil
-	if fake.getSessionReturnsOnCall == nil {
-		fake.getSessionReturnsOnCall = make(map[int]struct {
-			result1 db.Session
+func (fake *FakeStore) DisableTOTPTxReturnsOnCall(i int, result1 db.User, result2 error) {
+	fake.disableTOTPTxMutex.Lock()
+	defer fake.disableTOTPTxMutex.Unlock()
+	fake.DisableTOTPTxStub = nil
+	if fake.disableTOTPTxReturnsOnCall == nil {
+		fake.disableTOTPTxReturnsOnCall = make(map[int]struct {
+			result1 db.User
 			result2 error
 		})
 	}
-	fake.getSessionReturnsOnCall[i] = struct {
-		result1 db.Session
+	fake.disableTOTPTxReturnsOnCall[i] = struct {
+		result1 db.User
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) GetTransfer(arg1 context.Context, arg2 int64) (db.Transfer, error) {
-	fake.getTransferMutex.Lock()
-	ret, specificReturn := fake.getTransferReturnsOnCall[len(fake.getTransferArgsForCall)]
-	fake.getTransferArgsForCall = append(fake.getTransferArgsForCall, struct {
+func (fake *FakeStore) EnableTOTPTx(arg1 context.Context, arg2 db.EnableTOTPTxParams) (db.User, error) {
+	fake.enableTOTPTxMutex.Lock()
+	ret, specificReturn := fake.enableTOTPTxReturnsOnCall[len(fake.enableTOTPTxArgsForCall)]
+	fake.enableTOTPTxArgsForCall = append(fake.enableTOTPTxArgsForCall, struct {
 		arg1 context.Context
-		arg2 int64
+		arg2 db.EnableTOTPTxParams
 	}{arg1, arg2})
-	stub := fake.GetTransferStub
-	fakeReturns := fake.getTransferReturns
-	fake.recordInvocation("GetTransfer", []interface{}{arg1, arg2})
-	fake.getTransferMutex.Unlock()
+	stub := fake.EnableTOTPTxStub
+	fakeReturns := fake.enableTOTPTxReturns
+	fake.recordInvocation("EnableTOTPTx", []interface{}{arg1, arg2})
+	fake.enableTOTPTxMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -1196,132 +2365,1036 @@ func (fake *FakeStore) GetTransfer(arg1 context.Context, arg2 int64) (db.Transfe
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeStore) GetTransferCallCount() int {
-	fake.getTransferMutex.RLock()
-	defer fake.getTransferMutex.RUnlock()
-	return len(fake.getTransferArgsForCall)
+func (fake *FakeStore) EnableTOTPTxCallCount() int {
+	fake.enableTOTPTxMutex.RLock()
+	defer fake.enableTOTPTxMutex.RUnlock()
+	return len(fake.enableTOTPTxArgsForCall)
 }
 
-func (fake *FakeStore) GetTransferCalls(stub func(context.Context, int64) (db.Transfer, error)) {
-	fake.getTransferMutex.Lock()
-	defer fake.getTransferMutex.Unlock()
-	fake.GetTransferStub = stub
+func (fake *FakeStore) EnableTOTPTxCalls(stub func(context.Context, db.EnableTOTPTxParams) (db.User, error)) {
+	fake.enableTOTPTxMutex.Lock()
+	defer fake.enableTOTPTxMutex.Unlock()
+	fake.EnableTOTPTxStub = stub
 }
 
-func (fake *FakeStore) GetTransferArgsForCall(i int) (context.Context, int64) {
-	fake.getTransferMutex.RLock()
-	defer fake.getTransferMutex.RUnlock()
-	argsForCall := fake.getTransferArgsForCall[i]
+func (fake *FakeStore) EnableTOTPTxArgsForCall(i int) (context.Context, db.EnableTOTPTxParams) {
+	fake.enableTOTPTxMutex.RLock()
+	defer fake.enableTOTPTxMutex.RUnlock()
+	argsForCall := fake.enableTOTPTxArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeStore) GetTransferReturns(result1 db.Transfer, result2 error) {
-	fake.getTransferMutex.Lock()
-	defer fake.getTransferMutex.Unlock()
-	fake.GetTransferStub = nil
-	fake.getTransferReturns = struct {
-		result1 db.Transfer
+func (fake *FakeStore) EnableTOTPTxReturns(result1 db.User, result2 error) {
+	fake.enableTOTPTxMutex.Lock()
+	defer fake.enableTOTPTxMutex.Unlock()
+	fake.EnableTOTPTxStub = nil
+	fake.enableTOTPTxReturns = struct {
+		result1 db.User
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) GetTransferReturnsOnCall(i int, result1 db.Transfer, result2 error) {
-	fake.getTransferMutex.Lock()
-	defer fake.getTransferMutex.Unlock()
-	fake.GetTransferStub = nil
-	if fake.getTransferReturnsOnCall == nil {
-		fake.getTransferReturnsOnCall = make(map[int]struct {
-			result1 db.Transfer
+func (fake *FakeStore) EnableTOTPTxReturnsOnCall(i int, result1 db.User, result2 error) {
+	fake.enableTOTPTxMutex.Lock()
+	defer fake.enableTOTPTxMutex.Unlock()
+	fake.EnableTOTPTxStub = nil
+	if fake.enableTOTPTxReturnsOnCall == nil {
+		fake.enableTOTPTxReturnsOnCall = make(map[int]struct {
+			result1 db.User
 			result2 error
 		})
 	}
-	fake.getTransferReturnsOnCall[i] = struct {
-		result1 db.Transfer
+	fake.enableTOTPTxReturnsOnCall[i] = struct {
+		result1 db.User
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) GetUser(arg1 context.Context, arg2 string) (db.User, error) {
-	fake.getUserMutex.Lock()
-	ret, specificReturn := fake.getUserReturnsOnCall[len(fake.getUserArgsForCall)]
-	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+func (fake *FakeStore) ExpireResetPasswords(arg1 context.Context, arg2 string) error {
+	fake.expireResetPasswordsMutex.Lock()
+	ret, specificReturn := fake.expireResetPasswordsReturnsOnCall[len(fake.expireResetPasswordsArgsForCall)]
+	fake.expireResetPasswordsArgsForCall = append(fake.expireResetPasswordsArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 	}{arg1, arg2})
-	stub := fake.GetUserStub
-	fakeReturns := fake.getUserReturns
-	fake.recordInvocation("GetUser", []interface{}{arg1, arg2})
-	fake.getUserMutex.Unlock()
+	stub := fake.ExpireResetPasswordsStub
+	fakeReturns := fake.expireResetPasswordsReturns
+	fake.recordInvocation("ExpireResetPasswords", []interface{}{arg1, arg2})
+	fake.expireResetPasswordsMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *FakeStore) GetUserCallCount() int {
-	fake.getUserMutex.RLock()
-	defer fake.getUserMutex.RUnlock()
-	return len(fake.getUserArgsForCall)
+func (fake *FakeStore) ExpireResetPasswordsCallCount() int {
+	fake.expireResetPasswordsMutex.RLock()
+	defer fake.expireResetPasswordsMutex.RUnlock()
+	return len(fake.expireResetPasswordsArgsForCall)
 }
 
-func (fake *FakeStore) GetUserCalls(stub func(context.Context, string) (db.User, error)) {
-	fake.getUserMutex.Lock()
-	defer fake.getUserMutex.Unlock()
-	fake.GetUserStub = stub
+func (fake *FakeStore) ExpireResetPasswordsCalls(stub func(context.Context, string) error) {
+	fake.expireResetPasswordsMutex.Lock()
+	defer fake.expireResetPasswordsMutex.Unlock()
+	fake.ExpireResetPasswordsStub = stub
 }
 
-func (fake *FakeStore) GetUserArgsForCall(i int) (context.Context, string) {
-	fake.getUserMutex.RLock()
-	defer fake.getUserMutex.RUnlock()
-	argsForCall := fake.getUserArgsForCall[i]
+func (fake *FakeStore) ExpireResetPasswordsArgsForCall(i int) (context.Context, string) {
+	fake.expireResetPasswordsMutex.RLock()
+	defer fake.expireResetPasswordsMutex.RUnlock()
+	argsForCall := fake.expireResetPasswordsArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeStore) GetUserReturns(result1 db.User, result2 error) {
-	fake.getUserMutex.Lock()
-	defer fake.getUserMutex.Unlock()
-	fake.GetUserStub = nil
-	fake.getUserReturns = struct {
-		result1 db.User
-		result2 error
-	}{result1, result2}
+func (fake *FakeStore) ExpireResetPasswordsReturns(result1 error) {
+	fake.expireResetPasswordsMutex.Lock()
+	defer fake.expireResetPasswordsMutex.Unlock()
+	fake.ExpireResetPasswordsStub = nil
+	fake.expireResetPasswordsReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeStore) GetUserReturnsOnCall(i int, result1 db.User, result2 error) {
-	fake.getUserMutex.Lock()
-	defer fake.getUserMutex.Unlock()
-	fake.GetUserStub = nil
-	if fake.getUserReturnsOnCall == nil {
-		fake.getUserReturnsOnCall = make(map[int]struct {
-			result1 db.User
-			result2 error
+func (fake *FakeStore) ExpireResetPasswordsReturnsOnCall(i int, result1 error) {
+	fake.expireResetPasswordsMutex.Lock()
+	defer fake.expireResetPasswordsMutex.Unlock()
+	fake.ExpireResetPasswordsStub = nil
+	if fake.expireResetPasswordsReturnsOnCall == nil {
+		fake.expireResetPasswordsReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.getUserReturnsOnCall[i] = struct {
-		result1 db.User
-		result2 error
-	}{result1, result2}
+	fake.expireResetPasswordsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeStore) ListAccounts(arg1 context.Context, arg2 db.ListAccountsParams) ([]db.Account, error) {
-	fake.listAccountsMutex.Lock()
-	ret, specificReturn := fake.listAccountsReturnsOnCall[len(fake.listAccountsArgsForCall)]
-	fake.listAccountsArgsForCall = append(fake.listAccountsArgsForCall, struct {
+func (fake *FakeStore) ExpireVerifyEmails(arg1 context.Context, arg2 string) error {
+	fake.expireVerifyEmailsMutex.Lock()
+	ret, specificReturn := fake.expireVerifyEmailsReturnsOnCall[len(fake.expireVerifyEmailsArgsForCall)]
+	fake.expireVerifyEmailsArgsForCall = append(fake.expireVerifyEmailsArgsForCall, struct {
 		arg1 context.Context
-		arg2 db.ListAccountsParams
+		arg2 string
 	}{arg1, arg2})
-	stub := fake.ListAccountsStub
-	fakeReturns := fake.listAccountsReturns
-	fake.recordInvocation("ListAccounts", []interface{}{arg1, arg2})
-	fake.listAccountsMutex.Unlock()
+	stub := fake.ExpireVerifyEmailsStub
+	fakeReturns := fake.expireVerifyEmailsReturns
+	fake.recordInvocation("ExpireVerifyEmails", []interface{}{arg1, arg2})
+	fake.expireVerifyEmailsMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) ExpireVerifyEmailsCallCount() int {
+	fake.expireVerifyEmailsMutex.RLock()
+	defer fake.expireVerifyEmailsMutex.RUnlock()
+	return len(fake.expireVerifyEmailsArgsForCall)
+}
+
+func (fake *FakeStore) ExpireVerifyEmailsCalls(stub func(context.Context, string) error) {
+	fake.expireVerifyEmailsMutex.Lock()
+	defer fake.expireVerifyEmailsMutex.Unlock()
+	fake.ExpireVerifyEmailsStub = stub
+}
+
+func (fake *FakeStore) ExpireVerifyEmailsArgsForCall(i int) (context.Context, string) {
+	fake.expireVerifyEmailsMutex.RLock()
+	defer fake.expireVerifyEmailsMutex.RUnlock()
+	argsForCall := fake.expireVerifyEmailsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) ExpireVerifyEmailsReturns(result1 error) {
+	fake.expireVerifyEmailsMutex.Lock()
+	defer fake.expireVerifyEmailsMutex.Unlock()
+	fake.ExpireVerifyEmailsStub = nil
+	fake.expireVerifyEmailsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) ExpireVerifyEmailsReturnsOnCall(i int, result1 error) {
+	fake.expireVerifyEmailsMutex.Lock()
+	defer fake.expireVerifyEmailsMutex.Unlock()
+	fake.ExpireVerifyEmailsStub = nil
+	if fake.expireVerifyEmailsReturnsOnCall == nil {
+		fake.expireVerifyEmailsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.expireVerifyEmailsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) GetAccount(arg1 context.Context, arg2 int64) (db.Account, error) {
+	fake.getAccountMutex.Lock()
+	ret, specificReturn := fake.getAccountReturnsOnCall[len(fake.getAccountArgsForCall)]
+	fake.getAccountArgsForCall = append(fake.getAccountArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetAccountStub
+	fakeReturns := fake.getAccountReturns
+	fake.recordInvocation("GetAccount", []interface{}{arg1, arg2})
+	fake.getAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetAccountCallCount() int {
+	fake.getAccountMutex.RLock()
+	defer fake.getAccountMutex.RUnlock()
+	return len(fake.getAccountArgsForCall)
+}
+
+func (fake *FakeStore) GetAccountCalls(stub func(context.Context, int64) (db.Account, error)) {
+	fake.getAccountMutex.Lock()
+	defer fake.getAccountMutex.Unlock()
+	fake.GetAccountStub = stub
+}
+
+func (fake *FakeStore) GetAccountArgsForCall(i int) (context.Context, int64) {
+	fake.getAccountMutex.RLock()
+	defer fake.getAccountMutex.RUnlock()
+	argsForCall := fake.getAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetAccountReturns(result1 db.Account, result2 error) {
+	fake.getAccountMutex.Lock()
+	defer fake.getAccountMutex.Unlock()
+	fake.GetAccountStub = nil
+	fake.getAccountReturns = struct {
+		result1 db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetAccountReturnsOnCall(i int, result1 db.Account, result2 error) {
+	fake.getAccountMutex.Lock()
+	defer fake.getAccountMutex.Unlock()
+	fake.GetAccountStub = nil
+	if fake.getAccountReturnsOnCall == nil {
+		fake.getAccountReturnsOnCall = make(map[int]struct {
+			result1 db.Account
+			result2 error
+		})
+	}
+	fake.getAccountReturnsOnCall[i] = struct {
+		result1 db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetAccountByOwnerAndCurrency(arg1 context.Context, arg2 db.GetAccountByOwnerAndCurrencyParams) (db.Account, error) {
+	fake.getAccountByOwnerAndCurrencyMutex.Lock()
+	ret, specificReturn := fake.getAccountByOwnerAndCurrencyReturnsOnCall[len(fake.getAccountByOwnerAndCurrencyArgsForCall)]
+	fake.getAccountByOwnerAndCurrencyArgsForCall = append(fake.getAccountByOwnerAndCurrencyArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.GetAccountByOwnerAndCurrencyParams
+	}{arg1, arg2})
+	stub := fake.GetAccountByOwnerAndCurrencyStub
+	fakeReturns := fake.getAccountByOwnerAndCurrencyReturns
+	fake.recordInvocation("GetAccountByOwnerAndCurrency", []interface{}{arg1, arg2})
+	fake.getAccountByOwnerAndCurrencyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetAccountByOwnerAndCurrencyCallCount() int {
+	fake.getAccountByOwnerAndCurrencyMutex.RLock()
+	defer fake.getAccountByOwnerAndCurrencyMutex.RUnlock()
+	return len(fake.getAccountByOwnerAndCurrencyArgsForCall)
+}
+
+func (fake *FakeStore) GetAccountByOwnerAndCurrencyCalls(stub func(context.Context, db.GetAccountByOwnerAndCurrencyParams) (db.Account, error)) {
+	fake.getAccountByOwnerAndCurrencyMutex.Lock()
+	defer fake.getAccountByOwnerAndCurrencyMutex.Unlock()
+	fake.GetAccountByOwnerAndCurrencyStub = stub
+}
+
+func (fake *FakeStore) GetAccountByOwnerAndCurrencyArgsForCall(i int) (context.Context, db.GetAccountByOwnerAndCurrencyParams) {
+	fake.getAccountByOwnerAndCurrencyMutex.RLock()
+	defer fake.getAccountByOwnerAndCurrencyMutex.RUnlock()
+	argsForCall := fake.getAccountByOwnerAndCurrencyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetAccountByOwnerAndCurrencyReturns(result1 db.Account, result2 error) {
+	fake.getAccountByOwnerAndCurrencyMutex.Lock()
+	defer fake.getAccountByOwnerAndCurrencyMutex.Unlock()
+	fake.GetAccountByOwnerAndCurrencyStub = nil
+	fake.getAccountByOwnerAndCurrencyReturns = struct {
+		result1 db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetAccountByOwnerAndCurrencyReturnsOnCall(i int, result1 db.Account, result2 error) {
+	fake.getAccountByOwnerAndCurrencyMutex.Lock()
+	defer fake.getAccountByOwnerAndCurrencyMutex.Unlock()
+	fake.GetAccountByOwnerAndCurrencyStub = nil
+	if fake.getAccountByOwnerAndCurrencyReturnsOnCall == nil {
+		fake.getAccountByOwnerAndCurrencyReturnsOnCall = make(map[int]struct {
+			result1 db.Account
+			result2 error
+		})
+	}
+	fake.getAccountByOwnerAndCurrencyReturnsOnCall[i] = struct {
+		result1 db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetAccountForUpdate(arg1 context.Context, arg2 int64) (db.Account, error) {
+	fake.getAccountForUpdateMutex.Lock()
+	ret, specificReturn := fake.getAccountForUpdateReturnsOnCall[len(fake.getAccountForUpdateArgsForCall)]
+	fake.getAccountForUpdateArgsForCall = append(fake.getAccountForUpdateArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetAccountForUpdateStub
+	fakeReturns := fake.getAccountForUpdateReturns
+	fake.recordInvocation("GetAccountForUpdate", []interface{}{arg1, arg2})
+	fake.getAccountForUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetAccountForUpdateCallCount() int {
+	fake.getAccountForUpdateMutex.RLock()
+	defer fake.getAccountForUpdateMutex.RUnlock()
+	return len(fake.getAccountForUpdateArgsForCall)
+}
+
+func (fake *FakeStore) GetAccountForUpdateCalls(stub func(context.Context, int64) (db.Account, error)) {
+	fake.getAccountForUpdateMutex.Lock()
+	defer fake.getAccountForUpdateMutex.Unlock()
+	fake.GetAccountForUpdateStub = stub
+}
+
+func (fake *FakeStore) GetAccountForUpdateArgsForCall(i int) (context.Context, int64) {
+	fake.getAccountForUpdateMutex.RLock()
+	defer fake.getAccountForUpdateMutex.RUnlock()
+	argsForCall := fake.getAccountForUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetAccountForUpdateReturns(result1 db.Account, result2 error) {
+	fake.getAccountForUpdateMutex.Lock()
+	defer fake.getAccountForUpdateMutex.Unlock()
+	fake.GetAccountForUpdateStub = nil
+	fake.getAccountForUpdateReturns = struct {
+		result1 db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetAccountForUpdateReturnsOnCall(i int, result1 db.Account, result2 error) {
+	fake.getAccountForUpdateMutex.Lock()
+	defer fake.getAccountForUpdateMutex.Unlock()
+	fake.GetAccountForUpdateStub = nil
+	if fake.getAccountForUpdateReturnsOnCall == nil {
+		fake.getAccountForUpdateReturnsOnCall = make(map[int]struct {
+			result1 db.Account
+			result2 error
+		})
+	}
+	fake.getAccountForUpdateReturnsOnCall[i] = struct {
+		result1 db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetActiveResetPassword(arg1 context.Context, arg2 string) (db.ResetPassword, error) {
+	fake.getActiveResetPasswordMutex.Lock()
+	ret, specificReturn := fake.getActiveResetPasswordReturnsOnCall[len(fake.getActiveResetPasswordArgsForCall)]
+	fake.getActiveResetPasswordArgsForCall = append(fake.getActiveResetPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetActiveResetPasswordStub
+	fakeReturns := fake.getActiveResetPasswordReturns
+	fake.recordInvocation("GetActiveResetPassword", []interface{}{arg1, arg2})
+	fake.getActiveResetPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetActiveResetPasswordCallCount() int {
+	fake.getActiveResetPasswordMutex.RLock()
+	defer fake.getActiveResetPasswordMutex.RUnlock()
+	return len(fake.getActiveResetPasswordArgsForCall)
+}
+
+func (fake *FakeStore) GetActiveResetPasswordCalls(stub func(context.Context, string) (db.ResetPassword, error)) {
+	fake.getActiveResetPasswordMutex.Lock()
+	defer fake.getActiveResetPasswordMutex.Unlock()
+	fake.GetActiveResetPasswordStub = stub
+}
+
+func (fake *FakeStore) GetActiveResetPasswordArgsForCall(i int) (context.Context, string) {
+	fake.getActiveResetPasswordMutex.RLock()
+	defer fake.getActiveResetPasswordMutex.RUnlock()
+	argsForCall := fake.getActiveResetPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetActiveResetPasswordReturns(result1 db.ResetPassword, result2 error) {
+	fake.getActiveResetPasswordMutex.Lock()
+	defer fake.getActiveResetPasswordMutex.Unlock()
+	fake.GetActiveResetPasswordStub = nil
+	fake.getActiveResetPasswordReturns = struct {
+		result1 db.ResetPassword
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetActiveResetPasswordReturnsOnCall(i int, result1 db.ResetPassword, result2 error) {
+	fake.getActiveResetPasswordMutex.Lock()
+	defer fake.getActiveResetPasswordMutex.Unlock()
+	fake.GetActiveResetPasswordStub = nil
+	if fake.getActiveResetPasswordReturnsOnCall == nil {
+		fake.getActiveResetPasswordReturnsOnCall = make(map[int]struct {
+			result1 db.ResetPassword
+			result2 error
+		})
+	}
+	fake.getActiveResetPasswordReturnsOnCall[i] = struct {
+		result1 db.ResetPassword
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetActiveVerifyEmail(arg1 context.Context, arg2 db.GetActiveVerifyEmailParams) (db.VerifyEmail, error) {
+	fake.getActiveVerifyEmailMutex.Lock()
+	ret, specificReturn := fake.getActiveVerifyEmailReturnsOnCall[len(fake.getActiveVerifyEmailArgsForCall)]
+	fake.getActiveVerifyEmailArgsForCall = append(fake.getActiveVerifyEmailArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.GetActiveVerifyEmailParams
+	}{arg1, arg2})
+	stub := fake.GetActiveVerifyEmailStub
+	fakeReturns := fake.getActiveVerifyEmailReturns
+	fake.recordInvocation("GetActiveVerifyEmail", []interface{}{arg1, arg2})
+	fake.getActiveVerifyEmailMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetActiveVerifyEmailCallCount() int {
+	fake.getActiveVerifyEmailMutex.RLock()
+	defer fake.getActiveVerifyEmailMutex.RUnlock()
+	return len(fake.getActiveVerifyEmailArgsForCall)
+}
+
+func (fake *FakeStore) GetActiveVerifyEmailCalls(stub func(context.Context, db.GetActiveVerifyEmailParams) (db.VerifyEmail, error)) {
+	fake.getActiveVerifyEmailMutex.Lock()
+	defer fake.getActiveVerifyEmailMutex.Unlock()
+	fake.GetActiveVerifyEmailStub = stub
+}
+
+func (fake *FakeStore) GetActiveVerifyEmailArgsForCall(i int) (context.Context, db.GetActiveVerifyEmailParams) {
+	fake.getActiveVerifyEmailMutex.RLock()
+	defer fake.getActiveVerifyEmailMutex.RUnlock()
+	argsForCall := fake.getActiveVerifyEmailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetActiveVerifyEmailReturns(result1 db.VerifyEmail, result2 error) {
+	fake.getActiveVerifyEmailMutex.Lock()
+	defer fake.getActiveVerifyEmailMutex.Unlock()
+	fake.GetActiveVerifyEmailStub = nil
+	fake.getActiveVerifyEmailReturns = struct {
+		result1 db.VerifyEmail
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetActiveVerifyEmailReturnsOnCall(i int, result1 db.VerifyEmail, result2 error) {
+	fake.getActiveVerifyEmailMutex.Lock()
+	defer fake.getActiveVerifyEmailMutex.Unlock()
+	fake.GetActiveVerifyEmailStub = nil
+	if fake.getActiveVerifyEmailReturnsOnCall == nil {
+		fake.getActiveVerifyEmailReturnsOnCall = make(map[int]struct {
+			result1 db.VerifyEmail
+			result2 error
+		})
+	}
+	fake.getActiveVerifyEmailReturnsOnCall[i] = struct {
+		result1 db.VerifyEmail
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetEntry(arg1 context.Context, arg2 int64) (db.Entry, error) {
+	fake.getEntryMutex.Lock()
+	ret, specificReturn := fake.getEntryReturnsOnCall[len(fake.getEntryArgsForCall)]
+	fake.getEntryArgsForCall = append(fake.getEntryArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetEntryStub
+	fakeReturns := fake.getEntryReturns
+	fake.recordInvocation("GetEntry", []interface{}{arg1, arg2})
+	fake.getEntryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetEntryCallCount() int {
+	fake.getEntryMutex.RLock()
+	defer fake.getEntryMutex.RUnlock()
+	return len(fake.getEntryArgsForCall)
+}
+
+func (fake *FakeStore) GetEntryCalls(stub func(context.Context, int64) (db.Entry, error)) {
+	fake.getEntryMutex.Lock()
+	defer fake.getEntryMutex.Unlock()
+	fake.GetEntryStub = stub
+}
+
+func (fake *FakeStore) GetEntryArgsForCall(i int) (context.Context, int64) {
+	fake.getEntryMutex.RLock()
+	defer fake.getEntryMutex.RUnlock()
+	argsForCall := fake.getEntryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetEntryReturns(result1 db.Entry, result2 error) {
+	fake.getEntryMutex.Lock()
+	defer fake.getEntryMutex.Unlock()
+	fake.GetEntryStub = nil
+	fake.getEntryReturns = struct {
+		result1 db.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetEntryReturnsOnCall(i int, result1 db.Entry, result2 error) {
+	fake.getEntryMutex.Lock()
+	defer fake.getEntryMutex.Unlock()
+	fake.GetEntryStub = nil
+	if fake.getEntryReturnsOnCall == nil {
+		fake.getEntryReturnsOnCall = make(map[int]struct {
+			result1 db.Entry
+			result2 error
+		})
+	}
+	fake.getEntryReturnsOnCall[i] = struct {
+		result1 db.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetIdempotencyKey(arg1 context.Context, arg2 db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	fake.getIdempotencyKeyMutex.Lock()
+	ret, specificReturn := fake.getIdempotencyKeyReturnsOnCall[len(fake.getIdempotencyKeyArgsForCall)]
+	fake.getIdempotencyKeyArgsForCall = append(fake.getIdempotencyKeyArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.GetIdempotencyKeyParams
+	}{arg1, arg2})
+	stub := fake.GetIdempotencyKeyStub
+	fakeReturns := fake.getIdempotencyKeyReturns
+	fake.recordInvocation("GetIdempotencyKey", []interface{}{arg1, arg2})
+	fake.getIdempotencyKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetIdempotencyKeyCallCount() int {
+	fake.getIdempotencyKeyMutex.RLock()
+	defer fake.getIdempotencyKeyMutex.RUnlock()
+	return len(fake.getIdempotencyKeyArgsForCall)
+}
+
+func (fake *FakeStore) GetIdempotencyKeyCalls(stub func(context.Context, db.GetIdempotencyKeyParams) (db.IdempotencyKey, error)) {
+	fake.getIdempotencyKeyMutex.Lock()
+	defer fake.getIdempotencyKeyMutex.Unlock()
+	fake.GetIdempotencyKeyStub = stub
+}
+
+func (fake *FakeStore) GetIdempotencyKeyArgsForCall(i int) (context.Context, db.GetIdempotencyKeyParams) {
+	fake.getIdempotencyKeyMutex.RLock()
+	defer fake.getIdempotencyKeyMutex.RUnlock()
+	argsForCall := fake.getIdempotencyKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetIdempotencyKeyReturns(result1 db.IdempotencyKey, result2 error) {
+	fake.getIdempotencyKeyMutex.Lock()
+	defer fake.getIdempotencyKeyMutex.Unlock()
+	fake.GetIdempotencyKeyStub = nil
+	fake.getIdempotencyKeyReturns = struct {
+		result1 db.IdempotencyKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetIdempotencyKeyReturnsOnCall(i int, result1 db.IdempotencyKey, result2 error) {
+	fake.getIdempotencyKeyMutex.Lock()
+	defer fake.getIdempotencyKeyMutex.Unlock()
+	fake.GetIdempotencyKeyStub = nil
+	if fake.getIdempotencyKeyReturnsOnCall == nil {
+		fake.getIdempotencyKeyReturnsOnCall = make(map[int]struct {
+			result1 db.IdempotencyKey
+			result2 error
+		})
+	}
+	fake.getIdempotencyKeyReturnsOnCall[i] = struct {
+		result1 db.IdempotencyKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetResetPasswordByCode(arg1 context.Context, arg2 db.GetResetPasswordByCodeParams) (db.ResetPassword, error) {
+	fake.getResetPasswordByCodeMutex.Lock()
+	ret, specificReturn := fake.getResetPasswordByCodeReturnsOnCall[len(fake.getResetPasswordByCodeArgsForCall)]
+	fake.getResetPasswordByCodeArgsForCall = append(fake.getResetPasswordByCodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.GetResetPasswordByCodeParams
+	}{arg1, arg2})
+	stub := fake.GetResetPasswordByCodeStub
+	fakeReturns := fake.getResetPasswordByCodeReturns
+	fake.recordInvocation("GetResetPasswordByCode", []interface{}{arg1, arg2})
+	fake.getResetPasswordByCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetResetPasswordByCodeCallCount() int {
+	fake.getResetPasswordByCodeMutex.RLock()
+	defer fake.getResetPasswordByCodeMutex.RUnlock()
+	return len(fake.getResetPasswordByCodeArgsForCall)
+}
+
+func (fake *FakeStore) GetResetPasswordByCodeCalls(stub func(context.Context, db.GetResetPasswordByCodeParams) (db.ResetPassword, error)) {
+	fake.getResetPasswordByCodeMutex.Lock()
+	defer fake.getResetPasswordByCodeMutex.Unlock()
+	fake.GetResetPasswordByCodeStub = stub
+}
+
+func (fake *FakeStore) GetResetPasswordByCodeArgsForCall(i int) (context.Context, db.GetResetPasswordByCodeParams) {
+	fake.getResetPasswordByCodeMutex.RLock()
+	defer fake.getResetPasswordByCodeMutex.RUnlock()
+	argsForCall := fake.getResetPasswordByCodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetResetPasswordByCodeReturns(result1 db.ResetPassword, result2 error) {
+	fake.getResetPasswordByCodeMutex.Lock()
+	defer fake.getResetPasswordByCodeMutex.Unlock()
+	fake.GetResetPasswordByCodeStub = nil
+	fake.getResetPasswordByCodeReturns = struct {
+		result1 db.ResetPassword
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetResetPasswordByCodeReturnsOnCall(i int, result1 db.ResetPassword, result2 error) {
+	fake.getResetPasswordByCodeMutex.Lock()
+	defer fake.getResetPasswordByCodeMutex.Unlock()
+	fake.GetResetPasswordByCodeStub = nil
+	if fake.getResetPasswordByCodeReturnsOnCall == nil {
+		fake.getResetPasswordByCodeReturnsOnCall = make(map[int]struct {
+			result1 db.ResetPassword
+			result2 error
+		})
+	}
+	fake.getResetPasswordByCodeReturnsOnCall[i] = struct {
+		result1 db.ResetPassword
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetSession(arg1 context.Context, arg2 uuid.UUID) (db.Session, error) {
+	fake.getSessionMutex.Lock()
+	ret, specificReturn := fake.getSessionReturnsOnCall[len(fake.getSessionArgsForCall)]
+	fake.getSessionArgsForCall = append(fake.getSessionArgsForCall, struct {
+		arg1 context.Context
+		arg2 uuid.UUID
+	}{arg1, arg2})
+	stub := fake.GetSessionStub
+	fakeReturns := fake.getSessionReturns
+	fake.recordInvocation("GetSession", []interface{}{arg1, arg2})
+	fake.getSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetSessionCallCount() int {
+	fake.getSessionMutex.RLock()
+	defer fake.getSessionMutex.RUnlock()
+	return len(fake.getSessionArgsForCall)
+}
+
+func (fake *FakeStore) GetSessionCalls(stub func(context.Context, uuid.UUID) (db.Session, error)) {
+	fake.getSessionMutex.Lock()
+	defer fake.getSessionMutex.Unlock()
+	fake.GetSessionStub = stub
+}
+
+func (fake *FakeStore) GetSessionArgsForCall(i int) (context.Context, uuid.UUID) {
+	fake.getSessionMutex.RLock()
+	defer fake.getSessionMutex.RUnlock()
+	argsForCall := fake.getSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetSessionReturns(result1 db.Session, result2 error) {
+	fake.getSessionMutex.Lock()
+	defer fake.getSessionMutex.Unlock()
+	fake.GetSessionStub = nil
+	fake.getSessionReturns = struct {
+		result1 db.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetSessionReturnsOnCall(i int, result1 db.Session, result2 error) {
+	fake.getSessionMutex.Lock()
+	defer fake.getSessionMutex.Unlock()
+	fake.GetSessionStub = nil
+	if fake.getSessionReturnsOnCall == nil {
+		fake.getSessionReturnsOnCall = make(map[int]struct {
+			result1 db.Session
+			result2 error
+		})
+	}
+	fake.getSessionReturnsOnCall[i] = struct {
+		result1 db.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetTransfer(arg1 context.Context, arg2 int64) (db.Transfer, error) {
+	fake.getTransferMutex.Lock()
+	ret, specificReturn := fake.getTransferReturnsOnCall[len(fake.getTransferArgsForCall)]
+	fake.getTransferArgsForCall = append(fake.getTransferArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetTransferStub
+	fakeReturns := fake.getTransferReturns
+	fake.recordInvocation("GetTransfer", []interface{}{arg1, arg2})
+	fake.getTransferMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetTransferCallCount() int {
+	fake.getTransferMutex.RLock()
+	defer fake.getTransferMutex.RUnlock()
+	return len(fake.getTransferArgsForCall)
+}
+
+func (fake *FakeStore) GetTransferCalls(stub func(context.Context, int64) (db.Transfer, error)) {
+	fake.getTransferMutex.Lock()
+	defer fake.getTransferMutex.Unlock()
+	fake.GetTransferStub = stub
+}
+
+func (fake *FakeStore) GetTransferArgsForCall(i int) (context.Context, int64) {
+	fake.getTransferMutex.RLock()
+	defer fake.getTransferMutex.RUnlock()
+	argsForCall := fake.getTransferArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetTransferReturns(result1 db.Transfer, result2 error) {
+	fake.getTransferMutex.Lock()
+	defer fake.getTransferMutex.Unlock()
+	fake.GetTransferStub = nil
+	fake.getTransferReturns = struct {
+		result1 db.Transfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetTransferReturnsOnCall(i int, result1 db.Transfer, result2 error) {
+	fake.getTransferMutex.Lock()
+	defer fake.getTransferMutex.Unlock()
+	fake.GetTransferStub = nil
+	if fake.getTransferReturnsOnCall == nil {
+		fake.getTransferReturnsOnCall = make(map[int]struct {
+			result1 db.Transfer
+			result2 error
+		})
+	}
+	fake.getTransferReturnsOnCall[i] = struct {
+		result1 db.Transfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetUser(arg1 context.Context, arg2 string) (db.User, error) {
+	fake.getUserMutex.Lock()
+	ret, specificReturn := fake.getUserReturnsOnCall[len(fake.getUserArgsForCall)]
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetUserStub
+	fakeReturns := fake.getUserReturns
+	fake.recordInvocation("GetUser", []interface{}{arg1, arg2})
+	fake.getUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeStore) GetUserCalls(stub func(context.Context, string) (db.User, error)) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = stub
+}
+
+func (fake *FakeStore) GetUserArgsForCall(i int) (context.Context, string) {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	argsForCall := fake.getUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetUserReturns(result1 db.User, result2 error) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = nil
+	fake.getUserReturns = struct {
+		result1 db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetUserReturnsOnCall(i int, result1 db.User, result2 error) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = nil
+	if fake.getUserReturnsOnCall == nil {
+		fake.getUserReturnsOnCall = make(map[int]struct {
+			result1 db.User
+			result2 error
+		})
+	}
+	fake.getUserReturnsOnCall[i] = struct {
+		result1 db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetUserByEmail(arg1 context.Context, arg2 string) (db.User, error) {
+	fake.getUserByEmailMutex.Lock()
+	ret, specificReturn := fake.getUserByEmailReturnsOnCall[len(fake.getUserByEmailArgsForCall)]
+	fake.getUserByEmailArgsForCall = append(fake.getUserByEmailArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetUserByEmailStub
+	fakeReturns := fake.getUserByEmailReturns
+	fake.recordInvocation("GetUserByEmail", []interface{}{arg1, arg2})
+	fake.getUserByEmailMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetUserByEmailCallCount() int {
+	fake.getUserByEmailMutex.RLock()
+	defer fake.getUserByEmailMutex.RUnlock()
+	return len(fake.getUserByEmailArgsForCall)
+}
+
+func (fake *FakeStore) GetUserByEmailCalls(stub func(context.Context, string) (db.User, error)) {
+	fake.getUserByEmailMutex.Lock()
+	defer fake.getUserByEmailMutex.Unlock()
+	fake.GetUserByEmailStub = stub
+}
+
+func (fake *FakeStore) GetUserByEmailArgsForCall(i int) (context.Context, string) {
+	fake.getUserByEmailMutex.RLock()
+	defer fake.getUserByEmailMutex.RUnlock()
+	argsForCall := fake.getUserByEmailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetUserByEmailReturns(result1 db.User, result2 error) {
+	fake.getUserByEmailMutex.Lock()
+	defer fake.getUserByEmailMutex.Unlock()
+	fake.GetUserByEmailStub = nil
+	fake.getUserByEmailReturns = struct {
+		result1 db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetUserByEmailReturnsOnCall(i int, result1 db.User, result2 error) {
+	fake.getUserByEmailMutex.Lock()
+	defer fake.getUserByEmailMutex.Unlock()
+	fake.GetUserByEmailStub = nil
+	if fake.getUserByEmailReturnsOnCall == nil {
+		fake.getUserByEmailReturnsOnCall = make(map[int]struct {
+			result1 db.User
+			result2 error
+		})
+	}
+	fake.getUserByEmailReturnsOnCall[i] = struct {
+		result1 db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetUserForUpdate(arg1 context.Context, arg2 string) (db.User, error) {
+	fake.getUserForUpdateMutex.Lock()
+	ret, specificReturn := fake.getUserForUpdateReturnsOnCall[len(fake.getUserForUpdateArgsForCall)]
+	fake.getUserForUpdateArgsForCall = append(fake.getUserForUpdateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetUserForUpdateStub
+	fakeReturns := fake.getUserForUpdateReturns
+	fake.recordInvocation("GetUserForUpdate", []interface{}{arg1, arg2})
+	fake.getUserForUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) GetUserForUpdateCallCount() int {
+	fake.getUserForUpdateMutex.RLock()
+	defer fake.getUserForUpdateMutex.RUnlock()
+	return len(fake.getUserForUpdateArgsForCall)
+}
+
+func (fake *FakeStore) GetUserForUpdateCalls(stub func(context.Context, string) (db.User, error)) {
+	fake.getUserForUpdateMutex.Lock()
+	defer fake.getUserForUpdateMutex.Unlock()
+	fake.GetUserForUpdateStub = stub
+}
+
+func (fake *FakeStore) GetUserForUpdateArgsForCall(i int) (context.Context, string) {
+	fake.getUserForUpdateMutex.RLock()
+	defer fake.getUserForUpdateMutex.RUnlock()
+	argsForCall := fake.getUserForUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) GetUserForUpdateReturns(result1 db.User, result2 error) {
+	fake.getUserForUpdateMutex.Lock()
+	defer fake.getUserForUpdateMutex.Unlock()
+	fake.GetUserForUpdateStub = nil
+	fake.getUserForUpdateReturns = struct {
+		result1 db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) GetUserForUpdateReturnsOnCall(i int, result1 db.User, result2 error) {
+	fake.getUserForUpdateMutex.Lock()
+	defer fake.getUserForUpdateMutex.Unlock()
+	fake.GetUserForUpdateStub = nil
+	if fake.getUserForUpdateReturnsOnCall == nil {
+		fake.getUserForUpdateReturnsOnCall = make(map[int]struct {
+			result1 db.User
+			result2 error
+		})
+	}
+	fake.getUserForUpdateReturnsOnCall[i] = struct {
+		result1 db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListAccounts(arg1 context.Context, arg2 db.ListAccountsParams) ([]db.Account, error) {
+	fake.listAccountsMutex.Lock()
+	ret, specificReturn := fake.listAccountsReturnsOnCall[len(fake.listAccountsArgsForCall)]
+	fake.listAccountsArgsForCall = append(fake.listAccountsArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ListAccountsParams
+	}{arg1, arg2})
+	stub := fake.ListAccountsStub
+	fakeReturns := fake.listAccountsReturns
+	fake.recordInvocation("ListAccounts", []interface{}{arg1, arg2})
+	fake.listAccountsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
 	}
 	return fakeReturns.result1, fakeReturns.result2
 }
@@ -1355,18 +3428,83 @@ func (fake *FakeStore) ListAccountsReturns(result1 []db.Account, result2 error)
 	}{result1, result2}
 }
 
-func (fake *FakeStore) ListAccountsReturnsOnCall(i int, result1 []db.Account, result2 error) {
-	fake.listAccountsMutex.Lock()
-	defer fake.listAccountsMutex.Unlock()
-	fake.ListAccountsStub = nil
-	if fake.listAccountsReturnsOnCall == nil {
-		fake.listAccountsReturnsOnCall = make(map[int]struct {
-			result1 []db.Account
+func (fake *FakeStore) ListAccountsReturnsOnCall(i int, result1 []db.Account, result2 error) {
+	fake.listAccountsMutex.Lock()
+	defer fake.listAccountsMutex.Unlock()
+	fake.ListAccountsStub = nil
+	if fake.listAccountsReturnsOnCall == nil {
+		fake.listAccountsReturnsOnCall = make(map[int]struct {
+			result1 []db.Account
+			result2 error
+		})
+	}
+	fake.listAccountsReturnsOnCall[i] = struct {
+		result1 []db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListActiveSessions(arg1 context.Context, arg2 string) ([]db.Session, error) {
+	fake.listActiveSessionsMutex.Lock()
+	ret, specificReturn := fake.listActiveSessionsReturnsOnCall[len(fake.listActiveSessionsArgsForCall)]
+	fake.listActiveSessionsArgsForCall = append(fake.listActiveSessionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ListActiveSessionsStub
+	fakeReturns := fake.listActiveSessionsReturns
+	fake.recordInvocation("ListActiveSessions", []interface{}{arg1, arg2})
+	fake.listActiveSessionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) ListActiveSessionsCallCount() int {
+	fake.listActiveSessionsMutex.RLock()
+	defer fake.listActiveSessionsMutex.RUnlock()
+	return len(fake.listActiveSessionsArgsForCall)
+}
+
+func (fake *FakeStore) ListActiveSessionsCalls(stub func(context.Context, string) ([]db.Session, error)) {
+	fake.listActiveSessionsMutex.Lock()
+	defer fake.listActiveSessionsMutex.Unlock()
+	fake.ListActiveSessionsStub = stub
+}
+
+func (fake *FakeStore) ListActiveSessionsArgsForCall(i int) (context.Context, string) {
+	fake.listActiveSessionsMutex.RLock()
+	defer fake.listActiveSessionsMutex.RUnlock()
+	argsForCall := fake.listActiveSessionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) ListActiveSessionsReturns(result1 []db.Session, result2 error) {
+	fake.listActiveSessionsMutex.Lock()
+	defer fake.listActiveSessionsMutex.Unlock()
+	fake.ListActiveSessionsStub = nil
+	fake.listActiveSessionsReturns = struct {
+		result1 []db.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListActiveSessionsReturnsOnCall(i int, result1 []db.Session, result2 error) {
+	fake.listActiveSessionsMutex.Lock()
+	defer fake.listActiveSessionsMutex.Unlock()
+	fake.ListActiveSessionsStub = nil
+	if fake.listActiveSessionsReturnsOnCall == nil {
+		fake.listActiveSessionsReturnsOnCall = make(map[int]struct {
+			result1 []db.Session
 			result2 error
 		})
 	}
-	fake.listAccountsReturnsOnCall[i] = struct {
-		result1 []db.Account
+	fake.listActiveSessionsReturnsOnCall[i] = struct {
+		result1 []db.Session
 		result2 error
 	}{result1, result2}
 }
@@ -1420,33 +3558,482 @@ func (fake *FakeStore) ListEntriesReturns(result1 []db.Entry, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeStore) ListEntriesReturnsOnCall(i int, result1 []db.Entry, result2 error) {
-	fake.listEntriesMutex.Lock()
-	defer fake.listEntriesMutex.Unlock()
-	fake.ListEntriesStub = nil
-	if fake.listEntriesReturnsOnCall == nil {
-		fake.listEntriesReturnsOnCall = make(map[int]struct {
-			result1 []db.Entry
+func (fake *FakeStore) ListEntriesReturnsOnCall(i int, result1 []db.Entry, result2 error) {
+	fake.listEntriesMutex.Lock()
+	defer fake.listEntriesMutex.Unlock()
+	fake.ListEntriesStub = nil
+	if fake.listEntriesReturnsOnCall == nil {
+		fake.listEntriesReturnsOnCall = make(map[int]struct {
+			result1 []db.Entry
+			result2 error
+		})
+	}
+	fake.listEntriesReturnsOnCall[i] = struct {
+		result1 []db.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListTransfers(arg1 context.Context, arg2 db.ListTransfersParams) ([]db.Transfer, error) {
+	fake.listTransfersMutex.Lock()
+	ret, specificReturn := fake.listTransfersReturnsOnCall[len(fake.listTransfersArgsForCall)]
+	fake.listTransfersArgsForCall = append(fake.listTransfersArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ListTransfersParams
+	}{arg1, arg2})
+	stub := fake.ListTransfersStub
+	fakeReturns := fake.listTransfersReturns
+	fake.recordInvocation("ListTransfers", []interface{}{arg1, arg2})
+	fake.listTransfersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) ListTransfersCallCount() int {
+	fake.listTransfersMutex.RLock()
+	defer fake.listTransfersMutex.RUnlock()
+	return len(fake.listTransfersArgsForCall)
+}
+
+func (fake *FakeStore) ListTransfersCalls(stub func(context.Context, db.ListTransfersParams) ([]db.Transfer, error)) {
+	fake.listTransfersMutex.Lock()
+	defer fake.listTransfersMutex.Unlock()
+	fake.ListTransfersStub = stub
+}
+
+func (fake *FakeStore) ListTransfersArgsForCall(i int) (context.Context, db.ListTransfersParams) {
+	fake.listTransfersMutex.RLock()
+	defer fake.listTransfersMutex.RUnlock()
+	argsForCall := fake.listTransfersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) ListTransfersReturns(result1 []db.Transfer, result2 error) {
+	fake.listTransfersMutex.Lock()
+	defer fake.listTransfersMutex.Unlock()
+	fake.ListTransfersStub = nil
+	fake.listTransfersReturns = struct {
+		result1 []db.Transfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ListTransfersReturnsOnCall(i int, result1 []db.Transfer, result2 error) {
+	fake.listTransfersMutex.Lock()
+	defer fake.listTransfersMutex.Unlock()
+	fake.ListTransfersStub = nil
+	if fake.listTransfersReturnsOnCall == nil {
+		fake.listTransfersReturnsOnCall = make(map[int]struct {
+			result1 []db.Transfer
+			result2 error
+		})
+	}
+	fake.listTransfersReturnsOnCall[i] = struct {
+		result1 []db.Transfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) MarkOutboxMessageFailed(arg1 context.Context, arg2 db.MarkOutboxMessageFailedParams) error {
+	fake.markOutboxMessageFailedMutex.Lock()
+	ret, specificReturn := fake.markOutboxMessageFailedReturnsOnCall[len(fake.markOutboxMessageFailedArgsForCall)]
+	fake.markOutboxMessageFailedArgsForCall = append(fake.markOutboxMessageFailedArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.MarkOutboxMessageFailedParams
+	}{arg1, arg2})
+	stub := fake.MarkOutboxMessageFailedStub
+	fakeReturns := fake.markOutboxMessageFailedReturns
+	fake.recordInvocation("MarkOutboxMessageFailed", []interface{}{arg1, arg2})
+	fake.markOutboxMessageFailedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) MarkOutboxMessageFailedCallCount() int {
+	fake.markOutboxMessageFailedMutex.RLock()
+	defer fake.markOutboxMessageFailedMutex.RUnlock()
+	return len(fake.markOutboxMessageFailedArgsForCall)
+}
+
+func (fake *FakeStore) MarkOutboxMessageFailedCalls(stub func(context.Context, db.MarkOutboxMessageFailedParams) error) {
+	fake.markOutboxMessageFailedMutex.Lock()
+	defer fake.markOutboxMessageFailedMutex.Unlock()
+	fake.MarkOutboxMessageFailedStub = stub
+}
+
+func (fake *FakeStore) MarkOutboxMessageFailedArgsForCall(i int) (context.Context, db.MarkOutboxMessageFailedParams) {
+	fake.markOutboxMessageFailedMutex.RLock()
+	defer fake.markOutboxMessageFailedMutex.RUnlock()
+	argsForCall := fake.markOutboxMessageFailedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) MarkOutboxMessageFailedReturns(result1 error) {
+	fake.markOutboxMessageFailedMutex.Lock()
+	defer fake.markOutboxMessageFailedMutex.Unlock()
+	fake.MarkOutboxMessageFailedStub = nil
+	fake.markOutboxMessageFailedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) MarkOutboxMessageFailedReturnsOnCall(i int, result1 error) {
+	fake.markOutboxMessageFailedMutex.Lock()
+	defer fake.markOutboxMessageFailedMutex.Unlock()
+	fake.MarkOutboxMessageFailedStub = nil
+	if fake.markOutboxMessageFailedReturnsOnCall == nil {
+		fake.markOutboxMessageFailedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markOutboxMessageFailedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) MarkOutboxMessageSent(arg1 context.Context, arg2 int64) error {
+	fake.markOutboxMessageSentMutex.Lock()
+	ret, specificReturn := fake.markOutboxMessageSentReturnsOnCall[len(fake.markOutboxMessageSentArgsForCall)]
+	fake.markOutboxMessageSentArgsForCall = append(fake.markOutboxMessageSentArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.MarkOutboxMessageSentStub
+	fakeReturns := fake.markOutboxMessageSentReturns
+	fake.recordInvocation("MarkOutboxMessageSent", []interface{}{arg1, arg2})
+	fake.markOutboxMessageSentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) MarkOutboxMessageSentCallCount() int {
+	fake.markOutboxMessageSentMutex.RLock()
+	defer fake.markOutboxMessageSentMutex.RUnlock()
+	return len(fake.markOutboxMessageSentArgsForCall)
+}
+
+func (fake *FakeStore) MarkOutboxMessageSentCalls(stub func(context.Context, int64) error) {
+	fake.markOutboxMessageSentMutex.Lock()
+	defer fake.markOutboxMessageSentMutex.Unlock()
+	fake.MarkOutboxMessageSentStub = stub
+}
+
+func (fake *FakeStore) MarkOutboxMessageSentArgsForCall(i int) (context.Context, int64) {
+	fake.markOutboxMessageSentMutex.RLock()
+	defer fake.markOutboxMessageSentMutex.RUnlock()
+	argsForCall := fake.markOutboxMessageSentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) MarkOutboxMessageSentReturns(result1 error) {
+	fake.markOutboxMessageSentMutex.Lock()
+	defer fake.markOutboxMessageSentMutex.Unlock()
+	fake.MarkOutboxMessageSentStub = nil
+	fake.markOutboxMessageSentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) MarkOutboxMessageSentReturnsOnCall(i int, result1 error) {
+	fake.markOutboxMessageSentMutex.Lock()
+	defer fake.markOutboxMessageSentMutex.Unlock()
+	fake.MarkOutboxMessageSentStub = nil
+	if fake.markOutboxMessageSentReturnsOnCall == nil {
+		fake.markOutboxMessageSentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markOutboxMessageSentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) RequestPasswordResetTx(arg1 context.Context, arg2 db.RequestPasswordResetTxParams) (db.RequestPasswordResetTxResult, error) {
+	fake.requestPasswordResetTxMutex.Lock()
+	ret, specificReturn := fake.requestPasswordResetTxReturnsOnCall[len(fake.requestPasswordResetTxArgsForCall)]
+	fake.requestPasswordResetTxArgsForCall = append(fake.requestPasswordResetTxArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.RequestPasswordResetTxParams
+	}{arg1, arg2})
+	stub := fake.RequestPasswordResetTxStub
+	fakeReturns := fake.requestPasswordResetTxReturns
+	fake.recordInvocation("RequestPasswordResetTx", []interface{}{arg1, arg2})
+	fake.requestPasswordResetTxMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) RequestPasswordResetTxCallCount() int {
+	fake.requestPasswordResetTxMutex.RLock()
+	defer fake.requestPasswordResetTxMutex.RUnlock()
+	return len(fake.requestPasswordResetTxArgsForCall)
+}
+
+func (fake *FakeStore) RequestPasswordResetTxCalls(stub func(context.Context, db.RequestPasswordResetTxParams) (db.RequestPasswordResetTxResult, error)) {
+	fake.requestPasswordResetTxMutex.Lock()
+	defer fake.requestPasswordResetTxMutex.Unlock()
+	fake.RequestPasswordResetTxStub = stub
+}
+
+func (fake *FakeStore) RequestPasswordResetTxArgsForCall(i int) (context.Context, db.RequestPasswordResetTxParams) {
+	fake.requestPasswordResetTxMutex.RLock()
+	defer fake.requestPasswordResetTxMutex.RUnlock()
+	argsForCall := fake.requestPasswordResetTxArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) RequestPasswordResetTxReturns(result1 db.RequestPasswordResetTxResult, result2 error) {
+	fake.requestPasswordResetTxMutex.Lock()
+	defer fake.requestPasswordResetTxMutex.Unlock()
+	fake.RequestPasswordResetTxStub = nil
+	fake.requestPasswordResetTxReturns = struct {
+		result1 db.RequestPasswordResetTxResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) RequestPasswordResetTxReturnsOnCall(i int, result1 db.RequestPasswordResetTxResult, result2 error) {
+	fake.requestPasswordResetTxMutex.Lock()
+	defer fake.requestPasswordResetTxMutex.Unlock()
+	fake.RequestPasswordResetTxStub = nil
+	if fake.requestPasswordResetTxReturnsOnCall == nil {
+		fake.requestPasswordResetTxReturnsOnCall = make(map[int]struct {
+			result1 db.RequestPasswordResetTxResult
+			result2 error
+		})
+	}
+	fake.requestPasswordResetTxReturnsOnCall[i] = struct {
+		result1 db.RequestPasswordResetTxResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ResendVerifyEmailTx(arg1 context.Context, arg2 db.ResendVerifyEmailTxParams) (db.ResendVerifyEmailTxResult, error) {
+	fake.resendVerifyEmailTxMutex.Lock()
+	ret, specificReturn := fake.resendVerifyEmailTxReturnsOnCall[len(fake.resendVerifyEmailTxArgsForCall)]
+	fake.resendVerifyEmailTxArgsForCall = append(fake.resendVerifyEmailTxArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ResendVerifyEmailTxParams
+	}{arg1, arg2})
+	stub := fake.ResendVerifyEmailTxStub
+	fakeReturns := fake.resendVerifyEmailTxReturns
+	fake.recordInvocation("ResendVerifyEmailTx", []interface{}{arg1, arg2})
+	fake.resendVerifyEmailTxMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) ResendVerifyEmailTxCallCount() int {
+	fake.resendVerifyEmailTxMutex.RLock()
+	defer fake.resendVerifyEmailTxMutex.RUnlock()
+	return len(fake.resendVerifyEmailTxArgsForCall)
+}
+
+func (fake *FakeStore) ResendVerifyEmailTxCalls(stub func(context.Context, db.ResendVerifyEmailTxParams) (db.ResendVerifyEmailTxResult, error)) {
+	fake.resendVerifyEmailTxMutex.Lock()
+	defer fake.resendVerifyEmailTxMutex.Unlock()
+	fake.ResendVerifyEmailTxStub = stub
+}
+
+func (fake *FakeStore) ResendVerifyEmailTxArgsForCall(i int) (context.Context, db.ResendVerifyEmailTxParams) {
+	fake.resendVerifyEmailTxMutex.RLock()
+	defer fake.resendVerifyEmailTxMutex.RUnlock()
+	argsForCall := fake.resendVerifyEmailTxArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) ResendVerifyEmailTxReturns(result1 db.ResendVerifyEmailTxResult, result2 error) {
+	fake.resendVerifyEmailTxMutex.Lock()
+	defer fake.resendVerifyEmailTxMutex.Unlock()
+	fake.ResendVerifyEmailTxStub = nil
+	fake.resendVerifyEmailTxReturns = struct {
+		result1 db.ResendVerifyEmailTxResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ResendVerifyEmailTxReturnsOnCall(i int, result1 db.ResendVerifyEmailTxResult, result2 error) {
+	fake.resendVerifyEmailTxMutex.Lock()
+	defer fake.resendVerifyEmailTxMutex.Unlock()
+	fake.ResendVerifyEmailTxStub = nil
+	if fake.resendVerifyEmailTxReturnsOnCall == nil {
+		fake.resendVerifyEmailTxReturnsOnCall = make(map[int]struct {
+			result1 db.ResendVerifyEmailTxResult
+			result2 error
+		})
+	}
+	fake.resendVerifyEmailTxReturnsOnCall[i] = struct {
+		result1 db.ResendVerifyEmailTxResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ResetPasswordTx(arg1 context.Context, arg2 db.ResetPasswordTxParams) (db.ResetPasswordTxResult, error) {
+	fake.resetPasswordTxMutex.Lock()
+	ret, specificReturn := fake.resetPasswordTxReturnsOnCall[len(fake.resetPasswordTxArgsForCall)]
+	fake.resetPasswordTxArgsForCall = append(fake.resetPasswordTxArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.ResetPasswordTxParams
+	}{arg1, arg2})
+	stub := fake.ResetPasswordTxStub
+	fakeReturns := fake.resetPasswordTxReturns
+	fake.recordInvocation("ResetPasswordTx", []interface{}{arg1, arg2})
+	fake.resetPasswordTxMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) ResetPasswordTxCallCount() int {
+	fake.resetPasswordTxMutex.RLock()
+	defer fake.resetPasswordTxMutex.RUnlock()
+	return len(fake.resetPasswordTxArgsForCall)
+}
+
+func (fake *FakeStore) ResetPasswordTxCalls(stub func(context.Context, db.ResetPasswordTxParams) (db.ResetPasswordTxResult, error)) {
+	fake.resetPasswordTxMutex.Lock()
+	defer fake.resetPasswordTxMutex.Unlock()
+	fake.ResetPasswordTxStub = stub
+}
+
+func (fake *FakeStore) ResetPasswordTxArgsForCall(i int) (context.Context, db.ResetPasswordTxParams) {
+	fake.resetPasswordTxMutex.RLock()
+	defer fake.resetPasswordTxMutex.RUnlock()
+	argsForCall := fake.resetPasswordTxArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) ResetPasswordTxReturns(result1 db.ResetPasswordTxResult, result2 error) {
+	fake.resetPasswordTxMutex.Lock()
+	defer fake.resetPasswordTxMutex.Unlock()
+	fake.ResetPasswordTxStub = nil
+	fake.resetPasswordTxReturns = struct {
+		result1 db.ResetPasswordTxResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) ResetPasswordTxReturnsOnCall(i int, result1 db.ResetPasswordTxResult, result2 error) {
+	fake.resetPasswordTxMutex.Lock()
+	defer fake.resetPasswordTxMutex.Unlock()
+	fake.ResetPasswordTxStub = nil
+	if fake.resetPasswordTxReturnsOnCall == nil {
+		fake.resetPasswordTxReturnsOnCall = make(map[int]struct {
+			result1 db.ResetPasswordTxResult
+			result2 error
+		})
+	}
+	fake.resetPasswordTxReturnsOnCall[i] = struct {
+		result1 db.ResetPasswordTxResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) RotateSession(arg1 context.Context, arg2 db.RotateSessionParams) (db.Session, error) {
+	fake.rotateSessionMutex.Lock()
+	ret, specificReturn := fake.rotateSessionReturnsOnCall[len(fake.rotateSessionArgsForCall)]
+	fake.rotateSessionArgsForCall = append(fake.rotateSessionArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.RotateSessionParams
+	}{arg1, arg2})
+	stub := fake.RotateSessionStub
+	fakeReturns := fake.rotateSessionReturns
+	fake.recordInvocation("RotateSession", []interface{}{arg1, arg2})
+	fake.rotateSessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) RotateSessionCallCount() int {
+	fake.rotateSessionMutex.RLock()
+	defer fake.rotateSessionMutex.RUnlock()
+	return len(fake.rotateSessionArgsForCall)
+}
+
+func (fake *FakeStore) RotateSessionCalls(stub func(context.Context, db.RotateSessionParams) (db.Session, error)) {
+	fake.rotateSessionMutex.Lock()
+	defer fake.rotateSessionMutex.Unlock()
+	fake.RotateSessionStub = stub
+}
+
+func (fake *FakeStore) RotateSessionArgsForCall(i int) (context.Context, db.RotateSessionParams) {
+	fake.rotateSessionMutex.RLock()
+	defer fake.rotateSessionMutex.RUnlock()
+	argsForCall := fake.rotateSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) RotateSessionReturns(result1 db.Session, result2 error) {
+	fake.rotateSessionMutex.Lock()
+	defer fake.rotateSessionMutex.Unlock()
+	fake.RotateSessionStub = nil
+	fake.rotateSessionReturns = struct {
+		result1 db.Session
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) RotateSessionReturnsOnCall(i int, result1 db.Session, result2 error) {
+	fake.rotateSessionMutex.Lock()
+	defer fake.rotateSessionMutex.Unlock()
+	fake.RotateSessionStub = nil
+	if fake.rotateSessionReturnsOnCall == nil {
+		fake.rotateSessionReturnsOnCall = make(map[int]struct {
+			result1 db.Session
 			result2 error
 		})
 	}
-	fake.listEntriesReturnsOnCall[i] = struct {
-		result1 []db.Entry
+	fake.rotateSessionReturnsOnCall[i] = struct {
+		result1 db.Session
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) ListTransfers(arg1 context.Context, arg2 db.ListTransfersParams) ([]db.Transfer, error) {
-	fake.listTransfersMutex.Lock()
-	ret, specificReturn := fake.listTransfersReturnsOnCall[len(fake.listTransfersArgsForCall)]
-	fake.listTransfersArgsForCall = append(fake.listTransfersArgsForCall, struct {
+func (fake *FakeStore) RotateSessionTx(arg1 context.Context, arg2 db.RotateSessionTxParams) (db.Session, error) {
+	fake.rotateSessionTxMutex.Lock()
+	ret, specificReturn := fake.rotateSessionTxReturnsOnCall[len(fake.rotateSessionTxArgsForCall)]
+	fake.rotateSessionTxArgsForCall = append(fake.rotateSessionTxArgsForCall, struct {
 		arg1 context.Context
-		arg2 db.ListTransfersParams
+		arg2 db.RotateSessionTxParams
 	}{arg1, arg2})
-	stub := fake.ListTransfersStub
-	fakeReturns := fake.listTransfersReturns
-	fake.recordInvocation("ListTransfers", []interface{}{arg1, arg2})
-	fake.listTransfersMutex.Unlock()
+	stub := fake.RotateSessionTxStub
+	fakeReturns := fake.rotateSessionTxReturns
+	fake.recordInvocation("RotateSessionTx", []interface{}{arg1, arg2})
+	fake.rotateSessionTxMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -1456,47 +4043,47 @@ func (fake *FakeStore) ListTransfers(arg1 context.Context, arg2 db.ListTransfers
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeStore) ListTransfersCallCount() int {
-	fake.listTransfersMutex.RLock()
-	defer fake.listTransfersMutex.RUnlock()
-	return len(fake.listTransfersArgsForCall)
+func (fake *FakeStore) RotateSessionTxCallCount() int {
+	fake.rotateSessionTxMutex.RLock()
+	defer fake.rotateSessionTxMutex.RUnlock()
+	return len(fake.rotateSessionTxArgsForCall)
 }
 
-func (fake *FakeStore) ListTransfersCalls(stub func(context.Context, db.ListTransfersParams) ([]db.Transfer, error)) {
-	fake.listTransfersMutex.Lock()
-	defer fake.listTransfersMutex.Unlock()
-	fake.ListTransfersStub = stub
+func (fake *FakeStore) RotateSessionTxCalls(stub func(context.Context, db.RotateSessionTxParams) (db.Session, error)) {
+	fake.rotateSessionTxMutex.Lock()
+	defer fake.rotateSessionTxMutex.Unlock()
+	fake.RotateSessionTxStub = stub
 }
 
-func (fake *FakeStore) ListTransfersArgsForCall(i int) (context.Context, db.ListTransfersParams) {
-	fake.listTransfersMutex.RLock()
-	defer fake.listTransfersMutex.RUnlock()
-	argsForCall := fake.listTransfersArgsForCall[i]
+func (fake *FakeStore) RotateSessionTxArgsForCall(i int) (context.Context, db.RotateSessionTxParams) {
+	fake.rotateSessionTxMutex.RLock()
+	defer fake.rotateSessionTxMutex.RUnlock()
+	argsForCall := fake.rotateSessionTxArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeStore) ListTransfersReturns(result1 []db.Transfer, result2 error) {
-	fake.listTransfersMutex.Lock()
-	defer fake.listTransfersMutex.Unlock()
-	fake.ListTransfersStub = nil
-	fake.listTransfersReturns = struct {
-		result1 []db.Transfer
+func (fake *FakeStore) RotateSessionTxReturns(result1 db.Session, result2 error) {
+	fake.rotateSessionTxMutex.Lock()
+	defer fake.rotateSessionTxMutex.Unlock()
+	fake.RotateSessionTxStub = nil
+	fake.rotateSessionTxReturns = struct {
+		result1 db.Session
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStore) ListTransfersReturnsOnCall(i int, result1 []db.Transfer, result2 error) {
-	fake.listTransfersMutex.Lock()
-	defer fake.listTransfersMutex.Unlock()
-	fake.ListTransfersStub = nil
-	if fake.listTransfersReturnsOnCall == nil {
-		fake.listTransfersReturnsOnCall = make(map[int]struct {
-			result1 []db.Transfer
+func (fake *FakeStore) RotateSessionTxReturnsOnCall(i int, result1 db.Session, result2 error) {
+	fake.rotateSessionTxMutex.Lock()
+	defer fake.rotateSessionTxMutex.Unlock()
+	fake.RotateSessionTxStub = nil
+	if fake.rotateSessionTxReturnsOnCall == nil {
+		fake.rotateSessionTxReturnsOnCall = make(map[int]struct {
+			result1 db.Session
 			result2 error
 		})
 	}
-	fake.listTransfersReturnsOnCall[i] = struct {
-		result1 []db.Transfer
+	fake.rotateSessionTxReturnsOnCall[i] = struct {
+		result1 db.Session
 		result2 error
 	}{result1, result2}
 }
@@ -1631,6 +4218,198 @@ func (fake *FakeStore) UpdateAccountReturnsOnCall(i int, result1 db.Account, res
 	}{result1, result2}
 }
 
+func (fake *FakeStore) UpdateIdempotencyKeyResponse(arg1 context.Context, arg2 db.UpdateIdempotencyKeyResponseParams) error {
+	fake.updateIdempotencyKeyResponseMutex.Lock()
+	ret, specificReturn := fake.updateIdempotencyKeyResponseReturnsOnCall[len(fake.updateIdempotencyKeyResponseArgsForCall)]
+	fake.updateIdempotencyKeyResponseArgsForCall = append(fake.updateIdempotencyKeyResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.UpdateIdempotencyKeyResponseParams
+	}{arg1, arg2})
+	stub := fake.UpdateIdempotencyKeyResponseStub
+	fakeReturns := fake.updateIdempotencyKeyResponseReturns
+	fake.recordInvocation("UpdateIdempotencyKeyResponse", []interface{}{arg1, arg2})
+	fake.updateIdempotencyKeyResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) UpdateIdempotencyKeyResponseCallCount() int {
+	fake.updateIdempotencyKeyResponseMutex.RLock()
+	defer fake.updateIdempotencyKeyResponseMutex.RUnlock()
+	return len(fake.updateIdempotencyKeyResponseArgsForCall)
+}
+
+func (fake *FakeStore) UpdateIdempotencyKeyResponseCalls(stub func(context.Context, db.UpdateIdempotencyKeyResponseParams) error) {
+	fake.updateIdempotencyKeyResponseMutex.Lock()
+	defer fake.updateIdempotencyKeyResponseMutex.Unlock()
+	fake.UpdateIdempotencyKeyResponseStub = stub
+}
+
+func (fake *FakeStore) UpdateIdempotencyKeyResponseArgsForCall(i int) (context.Context, db.UpdateIdempotencyKeyResponseParams) {
+	fake.updateIdempotencyKeyResponseMutex.RLock()
+	defer fake.updateIdempotencyKeyResponseMutex.RUnlock()
+	argsForCall := fake.updateIdempotencyKeyResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) UpdateIdempotencyKeyResponseReturns(result1 error) {
+	fake.updateIdempotencyKeyResponseMutex.Lock()
+	defer fake.updateIdempotencyKeyResponseMutex.Unlock()
+	fake.UpdateIdempotencyKeyResponseStub = nil
+	fake.updateIdempotencyKeyResponseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) UpdateIdempotencyKeyResponseReturnsOnCall(i int, result1 error) {
+	fake.updateIdempotencyKeyResponseMutex.Lock()
+	defer fake.updateIdempotencyKeyResponseMutex.Unlock()
+	fake.UpdateIdempotencyKeyResponseStub = nil
+	if fake.updateIdempotencyKeyResponseReturnsOnCall == nil {
+		fake.updateIdempotencyKeyResponseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateIdempotencyKeyResponseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) UpdateLoginLink(arg1 context.Context, arg2 db.UpdateLoginLinkParams) (db.LoginLink, error) {
+	fake.updateLoginLinkMutex.Lock()
+	ret, specificReturn := fake.updateLoginLinkReturnsOnCall[len(fake.updateLoginLinkArgsForCall)]
+	fake.updateLoginLinkArgsForCall = append(fake.updateLoginLinkArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.UpdateLoginLinkParams
+	}{arg1, arg2})
+	stub := fake.UpdateLoginLinkStub
+	fakeReturns := fake.updateLoginLinkReturns
+	fake.recordInvocation("UpdateLoginLink", []interface{}{arg1, arg2})
+	fake.updateLoginLinkMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) UpdateLoginLinkCallCount() int {
+	fake.updateLoginLinkMutex.RLock()
+	defer fake.updateLoginLinkMutex.RUnlock()
+	return len(fake.updateLoginLinkArgsForCall)
+}
+
+func (fake *FakeStore) UpdateLoginLinkCalls(stub func(context.Context, db.UpdateLoginLinkParams) (db.LoginLink, error)) {
+	fake.updateLoginLinkMutex.Lock()
+	defer fake.updateLoginLinkMutex.Unlock()
+	fake.UpdateLoginLinkStub = stub
+}
+
+func (fake *FakeStore) UpdateLoginLinkArgsForCall(i int) (context.Context, db.UpdateLoginLinkParams) {
+	fake.updateLoginLinkMutex.RLock()
+	defer fake.updateLoginLinkMutex.RUnlock()
+	argsForCall := fake.updateLoginLinkArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) UpdateLoginLinkReturns(result1 db.LoginLink, result2 error) {
+	fake.updateLoginLinkMutex.Lock()
+	defer fake.updateLoginLinkMutex.Unlock()
+	fake.UpdateLoginLinkStub = nil
+	fake.updateLoginLinkReturns = struct {
+		result1 db.LoginLink
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) UpdateLoginLinkReturnsOnCall(i int, result1 db.LoginLink, result2 error) {
+	fake.updateLoginLinkMutex.Lock()
+	defer fake.updateLoginLinkMutex.Unlock()
+	fake.UpdateLoginLinkStub = nil
+	if fake.updateLoginLinkReturnsOnCall == nil {
+		fake.updateLoginLinkReturnsOnCall = make(map[int]struct {
+			result1 db.LoginLink
+			result2 error
+		})
+	}
+	fake.updateLoginLinkReturnsOnCall[i] = struct {
+		result1 db.LoginLink
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) UpdateResetPassword(arg1 context.Context, arg2 db.UpdateResetPasswordParams) (db.ResetPassword, error) {
+	fake.updateResetPasswordMutex.Lock()
+	ret, specificReturn := fake.updateResetPasswordReturnsOnCall[len(fake.updateResetPasswordArgsForCall)]
+	fake.updateResetPasswordArgsForCall = append(fake.updateResetPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.UpdateResetPasswordParams
+	}{arg1, arg2})
+	stub := fake.UpdateResetPasswordStub
+	fakeReturns := fake.updateResetPasswordReturns
+	fake.recordInvocation("UpdateResetPassword", []interface{}{arg1, arg2})
+	fake.updateResetPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) UpdateResetPasswordCallCount() int {
+	fake.updateResetPasswordMutex.RLock()
+	defer fake.updateResetPasswordMutex.RUnlock()
+	return len(fake.updateResetPasswordArgsForCall)
+}
+
+func (fake *FakeStore) UpdateResetPasswordCalls(stub func(context.Context, db.UpdateResetPasswordParams) (db.ResetPassword, error)) {
+	fake.updateResetPasswordMutex.Lock()
+	defer fake.updateResetPasswordMutex.Unlock()
+	fake.UpdateResetPasswordStub = stub
+}
+
+func (fake *FakeStore) UpdateResetPasswordArgsForCall(i int) (context.Context, db.UpdateResetPasswordParams) {
+	fake.updateResetPasswordMutex.RLock()
+	defer fake.updateResetPasswordMutex.RUnlock()
+	argsForCall := fake.updateResetPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) UpdateResetPasswordReturns(result1 db.ResetPassword, result2 error) {
+	fake.updateResetPasswordMutex.Lock()
+	defer fake.updateResetPasswordMutex.Unlock()
+	fake.UpdateResetPasswordStub = nil
+	fake.updateResetPasswordReturns = struct {
+		result1 db.ResetPassword
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) UpdateResetPasswordReturnsOnCall(i int, result1 db.ResetPassword, result2 error) {
+	fake.updateResetPasswordMutex.Lock()
+	defer fake.updateResetPasswordMutex.Unlock()
+	fake.UpdateResetPasswordStub = nil
+	if fake.updateResetPasswordReturnsOnCall == nil {
+		fake.updateResetPasswordReturnsOnCall = make(map[int]struct {
+			result1 db.ResetPassword
+			result2 error
+		})
+	}
+	fake.updateResetPasswordReturnsOnCall[i] = struct {
+		result1 db.ResetPassword
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStore) UpdateUser(arg1 context.Context, arg2 db.UpdateUserParams) (db.User, error) {
 	fake.updateUserMutex.Lock()
 	ret, specificReturn := fake.updateUserReturnsOnCall[len(fake.updateUserArgsForCall)]
@@ -1696,6 +4475,71 @@ func (fake *FakeStore) UpdateUserReturnsOnCall(i int, result1 db.User, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeStore) UpdateUserTx(arg1 context.Context, arg2 db.UpdateUserTxParams) (db.UpdateUserTxResult, error) {
+	fake.updateUserTxMutex.Lock()
+	ret, specificReturn := fake.updateUserTxReturnsOnCall[len(fake.updateUserTxArgsForCall)]
+	fake.updateUserTxArgsForCall = append(fake.updateUserTxArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.UpdateUserTxParams
+	}{arg1, arg2})
+	stub := fake.UpdateUserTxStub
+	fakeReturns := fake.updateUserTxReturns
+	fake.recordInvocation("UpdateUserTx", []interface{}{arg1, arg2})
+	fake.updateUserTxMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) UpdateUserTxCallCount() int {
+	fake.updateUserTxMutex.RLock()
+	defer fake.updateUserTxMutex.RUnlock()
+	return len(fake.updateUserTxArgsForCall)
+}
+
+func (fake *FakeStore) UpdateUserTxCalls(stub func(context.Context, db.UpdateUserTxParams) (db.UpdateUserTxResult, error)) {
+	fake.updateUserTxMutex.Lock()
+	defer fake.updateUserTxMutex.Unlock()
+	fake.UpdateUserTxStub = stub
+}
+
+func (fake *FakeStore) UpdateUserTxArgsForCall(i int) (context.Context, db.UpdateUserTxParams) {
+	fake.updateUserTxMutex.RLock()
+	defer fake.updateUserTxMutex.RUnlock()
+	argsForCall := fake.updateUserTxArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) UpdateUserTxReturns(result1 db.UpdateUserTxResult, result2 error) {
+	fake.updateUserTxMutex.Lock()
+	defer fake.updateUserTxMutex.Unlock()
+	fake.UpdateUserTxStub = nil
+	fake.updateUserTxReturns = struct {
+		result1 db.UpdateUserTxResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) UpdateUserTxReturnsOnCall(i int, result1 db.UpdateUserTxResult, result2 error) {
+	fake.updateUserTxMutex.Lock()
+	defer fake.updateUserTxMutex.Unlock()
+	fake.UpdateUserTxStub = nil
+	if fake.updateUserTxReturnsOnCall == nil {
+		fake.updateUserTxReturnsOnCall = make(map[int]struct {
+			result1 db.UpdateUserTxResult
+			result2 error
+		})
+	}
+	fake.updateUserTxReturnsOnCall[i] = struct {
+		result1 db.UpdateUserTxResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStore) UpdateVerifyEmail(arg1 context.Context, arg2 db.UpdateVerifyEmailParams) (db.VerifyEmail, error) {
 	fake.updateVerifyEmailMutex.Lock()
 	ret, specificReturn := fake.updateVerifyEmailReturnsOnCall[len(fake.updateVerifyEmailArgsForCall)]
@@ -1761,6 +4605,136 @@ func (fake *FakeStore) UpdateVerifyEmailReturnsOnCall(i int, result1 db.VerifyEm
 	}{result1, result2}
 }
 
+func (fake *FakeStore) UseRecoveryCode(arg1 context.Context, arg2 db.UseRecoveryCodeParams) (db.RecoveryCode, error) {
+	fake.useRecoveryCodeMutex.Lock()
+	ret, specificReturn := fake.useRecoveryCodeReturnsOnCall[len(fake.useRecoveryCodeArgsForCall)]
+	fake.useRecoveryCodeArgsForCall = append(fake.useRecoveryCodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.UseRecoveryCodeParams
+	}{arg1, arg2})
+	stub := fake.UseRecoveryCodeStub
+	fakeReturns := fake.useRecoveryCodeReturns
+	fake.recordInvocation("UseRecoveryCode", []interface{}{arg1, arg2})
+	fake.useRecoveryCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) UseRecoveryCodeCallCount() int {
+	fake.useRecoveryCodeMutex.RLock()
+	defer fake.useRecoveryCodeMutex.RUnlock()
+	return len(fake.useRecoveryCodeArgsForCall)
+}
+
+func (fake *FakeStore) UseRecoveryCodeCalls(stub func(context.Context, db.UseRecoveryCodeParams) (db.RecoveryCode, error)) {
+	fake.useRecoveryCodeMutex.Lock()
+	defer fake.useRecoveryCodeMutex.Unlock()
+	fake.UseRecoveryCodeStub = stub
+}
+
+func (fake *FakeStore) UseRecoveryCodeArgsForCall(i int) (context.Context, db.UseRecoveryCodeParams) {
+	fake.useRecoveryCodeMutex.RLock()
+	defer fake.useRecoveryCodeMutex.RUnlock()
+	argsForCall := fake.useRecoveryCodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) UseRecoveryCodeReturns(result1 db.RecoveryCode, result2 error) {
+	fake.useRecoveryCodeMutex.Lock()
+	defer fake.useRecoveryCodeMutex.Unlock()
+	fake.UseRecoveryCodeStub = nil
+	fake.useRecoveryCodeReturns = struct {
+		result1 db.RecoveryCode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) UseRecoveryCodeReturnsOnCall(i int, result1 db.RecoveryCode, result2 error) {
+	fake.useRecoveryCodeMutex.Lock()
+	defer fake.useRecoveryCodeMutex.Unlock()
+	fake.UseRecoveryCodeStub = nil
+	if fake.useRecoveryCodeReturnsOnCall == nil {
+		fake.useRecoveryCodeReturnsOnCall = make(map[int]struct {
+			result1 db.RecoveryCode
+			result2 error
+		})
+	}
+	fake.useRecoveryCodeReturnsOnCall[i] = struct {
+		result1 db.RecoveryCode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) UseTOTPStep(arg1 context.Context, arg2 db.UseTOTPStepParams) (int64, error) {
+	fake.useTOTPStepMutex.Lock()
+	ret, specificReturn := fake.useTOTPStepReturnsOnCall[len(fake.useTOTPStepArgsForCall)]
+	fake.useTOTPStepArgsForCall = append(fake.useTOTPStepArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.UseTOTPStepParams
+	}{arg1, arg2})
+	stub := fake.UseTOTPStepStub
+	fakeReturns := fake.useTOTPStepReturns
+	fake.recordInvocation("UseTOTPStep", []interface{}{arg1, arg2})
+	fake.useTOTPStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) UseTOTPStepCallCount() int {
+	fake.useTOTPStepMutex.RLock()
+	defer fake.useTOTPStepMutex.RUnlock()
+	return len(fake.useTOTPStepArgsForCall)
+}
+
+func (fake *FakeStore) UseTOTPStepCalls(stub func(context.Context, db.UseTOTPStepParams) (int64, error)) {
+	fake.useTOTPStepMutex.Lock()
+	defer fake.useTOTPStepMutex.Unlock()
+	fake.UseTOTPStepStub = stub
+}
+
+func (fake *FakeStore) UseTOTPStepArgsForCall(i int) (context.Context, db.UseTOTPStepParams) {
+	fake.useTOTPStepMutex.RLock()
+	defer fake.useTOTPStepMutex.RUnlock()
+	argsForCall := fake.useTOTPStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) UseTOTPStepReturns(result1 int64, result2 error) {
+	fake.useTOTPStepMutex.Lock()
+	defer fake.useTOTPStepMutex.Unlock()
+	fake.UseTOTPStepStub = nil
+	fake.useTOTPStepReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) UseTOTPStepReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.useTOTPStepMutex.Lock()
+	defer fake.useTOTPStepMutex.Unlock()
+	fake.UseTOTPStepStub = nil
+	if fake.useTOTPStepReturnsOnCall == nil {
+		fake.useTOTPStepReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.useTOTPStepReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStore) VerifyEmailTx(arg1 context.Context, arg2 db.VerifyEmailTxParams) (db.VerifyEmailTxResult, error) {
 	fake.verifyEmailTxMutex.Lock()
 	ret, specificReturn := fake.verifyEmailTxReturnsOnCall[len(fake.verifyEmailTxArgsForCall)]
@@ -1826,15 +4800,100 @@ func (fake *FakeStore) VerifyEmailTxReturnsOnCall(i int, result1 db.VerifyEmailT
 	}{result1, result2}
 }
 
+func (fake *FakeStore) WithdrawTx(arg1 context.Context, arg2 db.CashTxParams) (db.CashTxResult, error) {
+	fake.withdrawTxMutex.Lock()
+	ret, specificReturn := fake.withdrawTxReturnsOnCall[len(fake.withdrawTxArgsForCall)]
+	fake.withdrawTxArgsForCall = append(fake.withdrawTxArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.CashTxParams
+	}{arg1, arg2})
+	stub := fake.WithdrawTxStub
+	fakeReturns := fake.withdrawTxReturns
+	fake.recordInvocation("WithdrawTx", []interface{}{arg1, arg2})
+	fake.withdrawTxMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) WithdrawTxCallCount() int {
+	fake.withdrawTxMutex.RLock()
+	defer fake.withdrawTxMutex.RUnlock()
+	return len(fake.withdrawTxArgsForCall)
+}
+
+func (fake *FakeStore) WithdrawTxCalls(stub func(context.Context, db.CashTxParams) (db.CashTxResult, error)) {
+	fake.withdrawTxMutex.Lock()
+	defer fake.withdrawTxMutex.Unlock()
+	fake.WithdrawTxStub = stub
+}
+
+func (fake *FakeStore) WithdrawTxArgsForCall(i int) (context.Context, db.CashTxParams) {
+	fake.withdrawTxMutex.RLock()
+	defer fake.withdrawTxMutex.RUnlock()
+	argsForCall := fake.withdrawTxArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) WithdrawTxReturns(result1 db.CashTxResult, result2 error) {
+	fake.withdrawTxMutex.Lock()
+	defer fake.withdrawTxMutex.Unlock()
+	fake.WithdrawTxStub = nil
+	fake.withdrawTxReturns = struct {
+		result1 db.CashTxResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) WithdrawTxReturnsOnCall(i int, result1 db.CashTxResult, result2 error) {
+	fake.withdrawTxMutex.Lock()
+	defer fake.withdrawTxMutex.Unlock()
+	fake.WithdrawTxStub = nil
+	if fake.withdrawTxReturnsOnCall == nil {
+		fake.withdrawTxReturnsOnCall = make(map[int]struct {
+			result1 db.CashTxResult
+			result2 error
+		})
+	}
+	fake.withdrawTxReturnsOnCall[i] = struct {
+		result1 db.CashTxResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.addAccountBalanceMutex.RLock()
 	defer fake.addAccountBalanceMutex.RUnlock()
+	fake.blockSessionFamilyMutex.RLock()
+	defer fake.blockSessionFamilyMutex.RUnlock()
+	fake.blockUserSessionsMutex.RLock()
+	defer fake.blockUserSessionsMutex.RUnlock()
+	fake.claimOutboxMessagesMutex.RLock()
+	defer fake.claimOutboxMessagesMutex.RUnlock()
+	fake.countResetPasswordsSinceMutex.RLock()
+	defer fake.countResetPasswordsSinceMutex.RUnlock()
+	fake.countVerifyEmailsSinceMutex.RLock()
+	defer fake.countVerifyEmailsSinceMutex.RUnlock()
 	fake.createAccountMutex.RLock()
 	defer fake.createAccountMutex.RUnlock()
 	fake.createEntryMutex.RLock()
 	defer fake.createEntryMutex.RUnlock()
+	fake.createIdempotencyKeyMutex.RLock()
+	defer fake.createIdempotencyKeyMutex.RUnlock()
+	fake.createLoginLinkMutex.RLock()
+	defer fake.createLoginLinkMutex.RUnlock()
+	fake.createOutboxMessageMutex.RLock()
+	defer fake.createOutboxMessageMutex.RUnlock()
+	fake.createRecoveryCodeMutex.RLock()
+	defer fake.createRecoveryCodeMutex.RUnlock()
+	fake.createResetPasswordMutex.RLock()
+	defer fake.createResetPasswordMutex.RUnlock()
 	fake.createSessionMutex.RLock()
 	defer fake.createSessionMutex.RUnlock()
 	fake.createTransferMutex.RLock()
@@ -1847,34 +4906,92 @@ func (fake *FakeStore) Invocations() map[string][][]interface{} {
 	defer fake.createVerifyEmailMutex.RUnlock()
 	fake.deleteAccountMutex.RLock()
 	defer fake.deleteAccountMutex.RUnlock()
+	fake.deleteExpiredSessionsMutex.RLock()
+	defer fake.deleteExpiredSessionsMutex.RUnlock()
+	fake.deleteRecoveryCodesMutex.RLock()
+	defer fake.deleteRecoveryCodesMutex.RUnlock()
+	fake.depositTxMutex.RLock()
+	defer fake.depositTxMutex.RUnlock()
+	fake.disableTOTPTxMutex.RLock()
+	defer fake.disableTOTPTxMutex.RUnlock()
+	fake.enableTOTPTxMutex.RLock()
+	defer fake.enableTOTPTxMutex.RUnlock()
+	fake.expireResetPasswordsMutex.RLock()
+	defer fake.expireResetPasswordsMutex.RUnlock()
+	fake.expireVerifyEmailsMutex.RLock()
+	defer fake.expireVerifyEmailsMutex.RUnlock()
 	fake.getAccountMutex.RLock()
 	defer fake.getAccountMutex.RUnlock()
+	fake.getAccountByOwnerAndCurrencyMutex.RLock()
+	defer fake.getAccountByOwnerAndCurrencyMutex.RUnlock()
 	fake.getAccountForUpdateMutex.RLock()
 	defer fake.getAccountForUpdateMutex.RUnlock()
+	fake.getActiveResetPasswordMutex.RLock()
+	defer fake.getActiveResetPasswordMutex.RUnlock()
+	fake.getActiveVerifyEmailMutex.RLock()
+	defer fake.getActiveVerifyEmailMutex.RUnlock()
 	fake.getEntryMutex.RLock()
 	defer fake.getEntryMutex.RUnlock()
+	fake.getIdempotencyKeyMutex.RLock()
+	defer fake.getIdempotencyKeyMutex.RUnlock()
+	fake.getResetPasswordByCodeMutex.RLock()
+	defer fake.getResetPasswordByCodeMutex.RUnlock()
 	fake.getSessionMutex.RLock()
 	defer fake.getSessionMutex.RUnlock()
 	fake.getTransferMutex.RLock()
 	defer fake.getTransferMutex.RUnlock()
 	fake.getUserMutex.RLock()
 	defer fake.getUserMutex.RUnlock()
+	fake.getUserByEmailMutex.RLock()
+	defer fake.getUserByEmailMutex.RUnlock()
+	fake.getUserForUpdateMutex.RLock()
+	defer fake.getUserForUpdateMutex.RUnlock()
 	fake.listAccountsMutex.RLock()
 	defer fake.listAccountsMutex.RUnlock()
+	fake.listActiveSessionsMutex.RLock()
+	defer fake.listActiveSessionsMutex.RUnlock()
 	fake.listEntriesMutex.RLock()
 	defer fake.listEntriesMutex.RUnlock()
 	fake.listTransfersMutex.RLock()
 	defer fake.listTransfersMutex.RUnlock()
+	fake.markOutboxMessageFailedMutex.RLock()
+	defer fake.markOutboxMessageFailedMutex.RUnlock()
+	fake.markOutboxMessageSentMutex.RLock()
+	defer fake.markOutboxMessageSentMutex.RUnlock()
+	fake.requestPasswordResetTxMutex.RLock()
+	defer fake.requestPasswordResetTxMutex.RUnlock()
+	fake.resendVerifyEmailTxMutex.RLock()
+	defer fake.resendVerifyEmailTxMutex.RUnlock()
+	fake.resetPasswordTxMutex.RLock()
+	defer fake.resetPasswordTxMutex.RUnlock()
+	fake.rotateSessionMutex.RLock()
+	defer fake.rotateSessionMutex.RUnlock()
+	fake.rotateSessionTxMutex.RLock()
+	defer fake.rotateSessionTxMutex.RUnlock()
 	fake.transferTrxMutex.RLock()
 	defer fake.transferTrxMutex.RUnlock()
 	fake.updateAccountMutex.RLock()
 	defer fake.updateAccountMutex.RUnlock()
+	fake.updateIdempotencyKeyResponseMutex.RLock()
+	defer fake.updateIdempotencyKeyResponseMutex.RUnlock()
+	fake.updateLoginLinkMutex.RLock()
+	defer fake.updateLoginLinkMutex.RUnlock()
+	fake.updateResetPasswordMutex.RLock()
+	defer fake.updateResetPasswordMutex.RUnlock()
 	fake.updateUserMutex.RLock()
 	defer fake.updateUserMutex.RUnlock()
+	fake.updateUserTxMutex.RLock()
+	defer fake.updateUserTxMutex.RUnlock()
 	fake.updateVerifyEmailMutex.RLock()
 	defer fake.updateVerifyEmailMutex.RUnlock()
+	fake.useRecoveryCodeMutex.RLock()
+	defer fake.useRecoveryCodeMutex.RUnlock()
+	fake.useTOTPStepMutex.RLock()
+	defer fake.useTOTPStepMutex.RUnlock()
 	fake.verifyEmailTxMutex.RLock()
 	defer fake.verifyEmailTxMutex.RUnlock()
+	fake.withdrawTxMutex.RLock()
+	defer fake.withdrawTxMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
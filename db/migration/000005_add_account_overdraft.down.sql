@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "balance_non_negative";
+
+ALTER TABLE "accounts" DROP COLUMN "allow_overdraft";
@@ -0,0 +1,5 @@
+ALTER TABLE "accounts" ADD COLUMN "allow_overdraft" bool NOT NULL DEFAULT false;
+
+-- NOT VALID skips the check for rows that are already negative,
+-- every new insert and update is still checked
+ALTER TABLE "accounts" ADD CONSTRAINT "balance_non_negative" CHECK ("allow_overdraft" OR "balance" >= 0) NOT VALID;
@@ -0,0 +1,7 @@
+DELETE FROM "entries" WHERE "account_id" IN (
+  SELECT "id" FROM "accounts" WHERE "owner" = 'simplebank_vault'
+);
+
+DELETE FROM "accounts" WHERE "owner" = 'simplebank_vault';
+
+DELETE FROM "users" WHERE "username" = 'simplebank_vault';
@@ -0,0 +1,11 @@
+-- the vault user owns one internal cash account per currency.
+-- It has no password, so nobody can log in as the vault user
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('simplebank_vault', '', 'Simple Bank Vault', 'vault@simplebank.internal');
+
+-- vault balances go negative as customers deposit cash
+INSERT INTO "accounts" ("owner", "balance", "currency", "allow_overdraft")
+VALUES
+  ('simplebank_vault', 0, 'USD', true),
+  ('simplebank_vault', 0, 'EUR', true),
+  ('simplebank_vault', 0, 'CAD', true);
@@ -0,0 +1,3 @@
+ALTER TABLE "sessions" DROP COLUMN "replaced_by";
+
+ALTER TABLE "sessions" DROP COLUMN "family_id";
@@ -0,0 +1,12 @@
+-- every login starts a new session family,
+-- renewing a refresh token adds a session to the same family
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid;
+
+UPDATE "sessions" SET "family_id" = "id";
+
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+-- replaced_by is set once the refresh token of the session was rotated
+ALTER TABLE "sessions" ADD COLUMN "replaced_by" uuid;
+
+CREATE INDEX ON "sessions" ("family_id");
@@ -0,0 +1,10 @@
+CREATE TABLE "login_links" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "secret_code" varchar NOT NULL,
+  "is_used" bool NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
+);
+
+ALTER TABLE "login_links" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
@@ -0,0 +1,14 @@
+CREATE TABLE "outbox" (
+  "id" bigserial PRIMARY KEY,
+  "task_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL DEFAULT '{}',
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+  "sent_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "outbox" ("sent_at", "next_attempt_at");
+
+COMMENT ON COLUMN "outbox"."sent_at" IS 'null until the task was published';
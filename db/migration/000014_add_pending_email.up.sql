@@ -0,0 +1,2 @@
+-- pending_email is the new address of an email change until it is verified, it is empty otherwise
+ALTER TABLE "users" ADD COLUMN "pending_email" varchar NOT NULL DEFAULT '';
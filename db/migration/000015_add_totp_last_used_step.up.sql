@@ -0,0 +1,3 @@
+-- totp_last_used_step is the time step of the last accepted TOTP code,
+-- a code of the same or an earlier step is rejected (RFC 6238 section 5.2)
+ALTER TABLE "users" ADD COLUMN "totp_last_used_step" bigint NOT NULL DEFAULT 0;
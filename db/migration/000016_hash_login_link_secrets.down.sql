@@ -0,0 +1,2 @@
+UPDATE "login_links" SET "is_used" = true WHERE "is_used" = false;
+ALTER TABLE "login_links" RENAME COLUMN "hashed_secret_code" TO "secret_code";
@@ -0,0 +1,4 @@
+-- login links store the sha256 of the secret code, a leaked table can't be used to log in.
+-- Links created before can't be consumed anymore, they expire after 15 minutes anyway
+UPDATE "login_links" SET "is_used" = true WHERE "is_used" = false;
+ALTER TABLE "login_links" RENAME COLUMN "secret_code" TO "hashed_secret_code";
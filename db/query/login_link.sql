@@ -0,0 +1,18 @@
+-- name: CreateLoginLink :one
+INSERT INTO login_links(
+  username,
+  hashed_secret_code
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: UpdateLoginLink :one
+UPDATE login_links
+SET
+    is_used = TRUE
+WHERE
+    id = @id
+    AND hashed_secret_code = @hashed_secret_code
+    AND is_used = FALSE
+    AND expires_at > NOW()
+RETURNING *;
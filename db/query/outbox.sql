@@ -0,0 +1,36 @@
+-- name: CreateOutboxMessage :one
+INSERT INTO outbox (
+  task_type,
+  payload
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: ClaimOutboxMessages :many
+-- pending messages are hidden until locked_until, so other relays skip them while they are published
+UPDATE outbox
+SET
+    next_attempt_at = sqlc.arg(locked_until)
+WHERE id IN (
+    SELECT id FROM outbox
+    WHERE sent_at IS NULL
+      AND next_attempt_at <= NOW()
+    ORDER BY id
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxMessageSent :exec
+UPDATE outbox
+SET
+    sent_at = NOW()
+WHERE id = $1;
+
+-- name: MarkOutboxMessageFailed :exec
+UPDATE outbox
+SET
+    attempts = attempts + 1,
+    last_error = sqlc.arg(last_error),
+    next_attempt_at = sqlc.arg(next_attempt_at)
+WHERE id = sqlc.arg(id);
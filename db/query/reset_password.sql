@@ -0,0 +1,51 @@
+-- name: CreateResetPassword :one
+INSERT INTO reset_passwords(
+  username,
+  secret_code
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: UpdateResetPassword :one
+UPDATE reset_passwords
+SET
+    is_used = TRUE
+WHERE
+    id = @id
+    AND secret_code = @secret_code
+    AND is_used = FALSE
+    AND expires_at > NOW()
+RETURNING *;
+
+-- name: GetActiveResetPassword :one
+SELECT * FROM reset_passwords
+WHERE
+    username = @username
+    AND is_used = FALSE
+    AND expires_at > NOW()
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CountResetPasswordsSince :one
+SELECT COUNT(*) FROM reset_passwords
+WHERE
+    username = @username
+    AND created_at > @since;
+
+-- name: ExpireResetPasswords :exec
+UPDATE reset_passwords
+SET
+    expires_at = NOW()
+WHERE
+    username = @username
+    AND is_used = FALSE
+    AND expires_at > NOW();
+
+-- name: GetResetPasswordByCode :one
+SELECT * FROM reset_passwords
+WHERE
+    id = @id
+    AND secret_code = @secret_code
+    AND is_used = FALSE
+    AND expires_at > NOW()
+LIMIT 1;
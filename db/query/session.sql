@@ -6,11 +6,43 @@ INSERT INTO sessions(
     user_agent,
     client_ip,
     is_blocked,
-    expires_at
+    expires_at,
+    family_id
 ) VALUES(
-    $1, $2, $3, $4, $5, $6, $7
+    $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING *;
 
 -- name: GetSession :one
 SELECT * FROM sessions
 WHERE id = $1 LIMIT 1;
+
+-- name: RotateSession :one
+UPDATE sessions
+SET replaced_by = sqlc.arg(replaced_by)
+WHERE id = sqlc.arg(id)
+  AND replaced_by IS NULL
+  AND is_blocked = false
+RETURNING *;
+
+-- name: BlockSessionFamily :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1;
+
+-- name: ListActiveSessions :many
+SELECT * FROM sessions
+WHERE username = $1
+  AND is_blocked = false
+  AND replaced_by IS NULL
+  AND expires_at > now()
+ORDER BY created_at DESC;
+
+-- name: BlockUserSessions :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE username = sqlc.arg(username)
+  AND family_id <> sqlc.arg(keep_family_id);
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expires_at < now();
@@ -12,6 +12,15 @@ INSERT INTO users (
 SELECT * FROM users
 WHERE username = $1 LIMIT 1;
 
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE email = $1 LIMIT 1;
+
 -- name: UpdateUser :one
 UPDATE users
 SET
@@ -19,7 +28,16 @@ SET
   password_changed_at = COALESCE(sqlc.narg(password_changed_at), password_changed_at),
   full_name = COALESCE(sqlc.narg(full_name), full_name),
   email = COALESCE(sqlc.narg(email), email),
-  is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified)
+  pending_email = COALESCE(sqlc.narg(pending_email), pending_email),
+  is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified),
+  role = COALESCE(sqlc.narg(role), role),
+  totp_secret = COALESCE(sqlc.narg(totp_secret), totp_secret),
+  totp_enabled = COALESCE(sqlc.narg(totp_enabled), totp_enabled)
 WHERE
   username = sqlc.arg(username)
 RETURNING *;
+
+-- name: UseTOTPStep :execrows
+UPDATE users
+SET totp_last_used_step = sqlc.arg(step)
+WHERE username = sqlc.arg(username) AND totp_last_used_step < sqlc.arg(step);
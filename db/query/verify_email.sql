@@ -7,6 +7,16 @@ INSERT INTO verify_emails(
   $1, $2, $3
 ) RETURNING *;
 
+-- name: GetActiveVerifyEmail :one
+SELECT * FROM verify_emails
+WHERE
+    username = @username
+    AND email = @email
+    AND is_used = FALSE
+    AND expires_at > NOW()
+ORDER BY id DESC
+LIMIT 1;
+
 -- name: UpdateVerifyEmail :one
 UPDATE verify_emails
 SET
@@ -15,5 +25,20 @@ WHERE
     id = @id
     AND secret_code = @secret_code
     AND is_used = FALSE
-    AND expired_at > NOW()
-RETURNING *;
\ No newline at end of file
+    AND expires_at > NOW()
+RETURNING *;
+
+-- name: CountVerifyEmailsSince :one
+SELECT COUNT(*) FROM verify_emails
+WHERE
+    username = @username
+    AND created_at > @since;
+
+-- name: ExpireVerifyEmails :exec
+UPDATE verify_emails
+SET
+    expires_at = NOW()
+WHERE
+    username = @username
+    AND is_used = FALSE
+    AND expires_at > NOW();
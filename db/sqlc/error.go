@@ -0,0 +1,17 @@
+package db
+
+import "errors"
+
+var (
+	ErrInsufficientFunds      = errors.New("insufficient funds")
+	ErrIdempotencyKeyConflict = errors.New("idempotency key was already used with a different request")
+	ErrVaultAccount           = errors.New("cash can't be deposited to or withdrawn from a vault account")
+	ErrVaultAccountNotFound   = errors.New("vault account not found for currency")
+	ErrRefreshTokenReused     = errors.New("refresh token was already used")
+	ErrEmailAlreadyVerified   = errors.New("email is already verified")
+	ErrVerifyEmailCooldown    = errors.New("a verification email was sent recently")
+	ErrVerifyEmailLimit       = errors.New("too many verification emails were sent today")
+	ErrVerifyEmailOutdated    = errors.New("verification code belongs to an email the user doesn't use anymore")
+	ErrResetPasswordCooldown  = errors.New("a reset password email was sent recently")
+	ErrResetPasswordLimit     = errors.New("too many reset password emails were sent today")
+)
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// claimIdempotencyKey reserves the idempotency key for the user within the transaction.
+// A concurrent transaction claiming the same key blocks until this one finishes.
+// If the key was already used with the same request hash, the stored response
+// is returned with replayed set to true.
+func claimIdempotencyKey(
+	ctx context.Context,
+	q *Queries,
+	username string,
+	idempotencyKey string,
+	requestHash string,
+) (response json.RawMessage, replayed bool, err error) {
+	_, err = q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		Username:       username,
+		IdempotencyKey: idempotencyKey,
+		RequestHash:    requestHash,
+	})
+	if err == nil {
+		return nil, false, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, err
+	}
+
+	stored, err := q.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username:       username,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if stored.RequestHash != requestHash {
+		return nil, false, ErrIdempotencyKeyConflict
+	}
+
+	return stored.Response, true, nil
+}
+
+// saveIdempotencyResponse stores the response of the request that claimed the idempotency key
+func saveIdempotencyResponse(
+	ctx context.Context,
+	q *Queries,
+	username string,
+	idempotencyKey string,
+	response interface{},
+) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("marshal idempotency response: %w", err)
+	}
+
+	return q.UpdateIdempotencyKeyResponse(ctx, UpdateIdempotencyKeyResponseParams{
+		Response:       data,
+		Username:       username,
+		IdempotencyKey: idempotencyKey,
+	})
+}
+
+func requestHash(request interface{}) (string, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("marshal idempotency request: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,83 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: idempotency_key.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const createIdempotencyKey = `-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+  username,
+  idempotency_key,
+  request_hash
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (username, idempotency_key) DO NOTHING
+RETURNING username, idempotency_key, request_hash, response, created_at
+`
+
+type CreateIdempotencyKeyParams struct {
+	Username       string `json:"username"`
+	IdempotencyKey string `json:"idempotency_key"`
+	RequestHash    string `json:"request_hash"`
+}
+
+func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, createIdempotencyKey, arg.Username, arg.IdempotencyKey, arg.RequestHash)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.IdempotencyKey,
+		&i.RequestHash,
+		&i.Response,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT username, idempotency_key, request_hash, response, created_at FROM idempotency_keys
+WHERE username = $1 AND idempotency_key = $2
+LIMIT 1
+`
+
+type GetIdempotencyKeyParams struct {
+	Username       string `json:"username"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, getIdempotencyKey, arg.Username, arg.IdempotencyKey)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.IdempotencyKey,
+		&i.RequestHash,
+		&i.Response,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateIdempotencyKeyResponse = `-- name: UpdateIdempotencyKeyResponse :exec
+UPDATE idempotency_keys
+SET response = $1
+WHERE username = $2 AND idempotency_key = $3
+`
+
+type UpdateIdempotencyKeyResponseParams struct {
+	Response       json.RawMessage `json:"response"`
+	Username       string          `json:"username"`
+	IdempotencyKey string          `json:"idempotency_key"`
+}
+
+func (q *Queries) UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) error {
+	_, err := q.db.ExecContext(ctx, updateIdempotencyKeyResponse, arg.Response, arg.Username, arg.IdempotencyKey)
+	return err
+}
@@ -0,0 +1,13 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashLoginLinkSecret returns the hash stored for the secret code of a login link.
+// The codes are random, so a fast hash is enough to protect them
+func HashLoginLinkSecret(secretCode string) string {
+	sum := sha256.Sum256([]byte(secretCode))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,69 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: login_link.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createLoginLink = `-- name: CreateLoginLink :one
+INSERT INTO login_links(
+  username,
+  hashed_secret_code
+) VALUES (
+  $1, $2
+) RETURNING id, username, hashed_secret_code, is_used, created_at, expires_at
+`
+
+type CreateLoginLinkParams struct {
+	Username         string `json:"username"`
+	HashedSecretCode string `json:"hashed_secret_code"`
+}
+
+func (q *Queries) CreateLoginLink(ctx context.Context, arg CreateLoginLinkParams) (LoginLink, error) {
+	row := q.db.QueryRowContext(ctx, createLoginLink, arg.Username, arg.HashedSecretCode)
+	var i LoginLink
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.HashedSecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const updateLoginLink = `-- name: UpdateLoginLink :one
+UPDATE login_links
+SET
+    is_used = TRUE
+WHERE
+    id = $1
+    AND hashed_secret_code = $2
+    AND is_used = FALSE
+    AND expires_at > NOW()
+RETURNING id, username, hashed_secret_code, is_used, created_at, expires_at
+`
+
+type UpdateLoginLinkParams struct {
+	ID               int64  `json:"id"`
+	HashedSecretCode string `json:"hashed_secret_code"`
+}
+
+func (q *Queries) UpdateLoginLink(ctx context.Context, arg UpdateLoginLinkParams) (LoginLink, error) {
+	row := q.db.QueryRowContext(ctx, updateLoginLink, arg.ID, arg.HashedSecretCode)
+	var i LoginLink
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.HashedSecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateLoginLink(t *testing.T) {
+	user := createRandomUser(t)
+
+	loginLink, err := testQueries.CreateLoginLink(context.Background(), CreateLoginLinkParams{
+		Username:         user.Username,
+		HashedSecretCode: HashLoginLinkSecret(random.RandomString(32)),
+	})
+	require.NoError(t, err)
+	require.False(t, loginLink.IsUsed)
+	require.True(t, loginLink.ExpiresAt.After(loginLink.CreatedAt))
+
+	_, err = testQueries.UpdateLoginLink(context.Background(), UpdateLoginLinkParams{
+		ID:               loginLink.ID,
+		HashedSecretCode: HashLoginLinkSecret(random.RandomString(32)),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg := UpdateLoginLinkParams{
+		ID:               loginLink.ID,
+		HashedSecretCode: loginLink.HashedSecretCode,
+	}
+
+	usedLink, err := testQueries.UpdateLoginLink(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, usedLink.IsUsed)
+	require.Equal(t, user.Username, usedLink.Username)
+
+	// the link can only be used once
+	_, err = testQueries.UpdateLoginLink(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
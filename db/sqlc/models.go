@@ -5,17 +5,20 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
-	Balance   int64     `json:"balance"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
+	ID             int64     `json:"id"`
+	Owner          string    `json:"owner"`
+	Balance        int64     `json:"balance"`
+	Currency       string    `json:"currency"`
+	CreatedAt      time.Time `json:"created_at"`
+	AllowOverdraft bool      `json:"allow_overdraft"`
 }
 
 type Entry struct {
@@ -26,15 +29,63 @@ type Entry struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type IdempotencyKey struct {
+	Username       string          `json:"username"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	RequestHash    string          `json:"request_hash"`
+	Response       json.RawMessage `json:"response"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type LoginLink struct {
+	ID               int64     `json:"id"`
+	Username         string    `json:"username"`
+	HashedSecretCode string    `json:"hashed_secret_code"`
+	IsUsed           bool      `json:"is_used"`
+	CreatedAt        time.Time `json:"created_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+type Outbox struct {
+	ID            int64           `json:"id"`
+	TaskType      string          `json:"task_type"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int32           `json:"attempts"`
+	LastError     string          `json:"last_error"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	// null until the task was published
+	SentAt    sql.NullTime `json:"sent_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type RecoveryCode struct {
+	ID         int64     `json:"id"`
+	Username   string    `json:"username"`
+	HashedCode string    `json:"hashed_code"`
+	IsUsed     bool      `json:"is_used"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ResetPassword struct {
+	ID         int64     `json:"id"`
+	Username   string    `json:"username"`
+	SecretCode string    `json:"secret_code"`
+	IsUsed     bool      `json:"is_used"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
 type Session struct {
-	ID           uuid.UUID `json:"id"`
-	Username     string    `json:"username"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIp     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           uuid.UUID     `json:"id"`
+	Username     string        `json:"username"`
+	RefreshToken string        `json:"refresh_token"`
+	UserAgent    string        `json:"user_agent"`
+	ClientIp     string        `json:"client_ip"`
+	IsBlocked    bool          `json:"is_blocked"`
+	ExpiresAt    time.Time     `json:"expires_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+	FamilyID     uuid.UUID     `json:"family_id"`
+	ReplacedBy   uuid.NullUUID `json:"replaced_by"`
 }
 
 type Transfer struct {
@@ -54,6 +105,11 @@ type User struct {
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
 	IsEmailVerified   bool      `json:"is_email_verified"`
+	Role              string    `json:"role"`
+	TotpSecret        string    `json:"totp_secret"`
+	TotpEnabled       bool      `json:"totp_enabled"`
+	PendingEmail      string    `json:"pending_email"`
+	TotpLastUsedStep  int64     `json:"totp_last_used_step"`
 }
 
 type VerifyEmail struct {
@@ -0,0 +1,127 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: outbox.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const claimOutboxMessages = `-- name: ClaimOutboxMessages :many
+UPDATE outbox
+SET
+    next_attempt_at = $1
+WHERE id IN (
+    SELECT id FROM outbox
+    WHERE sent_at IS NULL
+      AND next_attempt_at <= NOW()
+    ORDER BY id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, task_type, payload, attempts, last_error, next_attempt_at, sent_at, created_at
+`
+
+type ClaimOutboxMessagesParams struct {
+	LockedUntil time.Time `json:"locked_until"`
+	BatchSize   int32     `json:"batch_size"`
+}
+
+// pending messages are hidden until locked_until, so other relays skip them while they are published
+func (q *Queries) ClaimOutboxMessages(ctx context.Context, arg ClaimOutboxMessagesParams) ([]Outbox, error) {
+	rows, err := q.db.QueryContext(ctx, claimOutboxMessages, arg.LockedUntil, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskType,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.SentAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createOutboxMessage = `-- name: CreateOutboxMessage :one
+INSERT INTO outbox (
+  task_type,
+  payload
+) VALUES (
+  $1, $2
+) RETURNING id, task_type, payload, attempts, last_error, next_attempt_at, sent_at, created_at
+`
+
+type CreateOutboxMessageParams struct {
+	TaskType string          `json:"task_type"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+func (q *Queries) CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (Outbox, error) {
+	row := q.db.QueryRowContext(ctx, createOutboxMessage, arg.TaskType, arg.Payload)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.TaskType,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.SentAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const markOutboxMessageFailed = `-- name: MarkOutboxMessageFailed :exec
+UPDATE outbox
+SET
+    attempts = attempts + 1,
+    last_error = $1,
+    next_attempt_at = $2
+WHERE id = $3
+`
+
+type MarkOutboxMessageFailedParams struct {
+	LastError     string    `json:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	ID            int64     `json:"id"`
+}
+
+func (q *Queries) MarkOutboxMessageFailed(ctx context.Context, arg MarkOutboxMessageFailedParams) error {
+	_, err := q.db.ExecContext(ctx, markOutboxMessageFailed, arg.LastError, arg.NextAttemptAt, arg.ID)
+	return err
+}
+
+const markOutboxMessageSent = `-- name: MarkOutboxMessageSent :exec
+UPDATE outbox
+SET
+    sent_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxMessageSent(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, markOutboxMessageSent, id)
+	return err
+}
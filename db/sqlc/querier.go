@@ -12,25 +12,56 @@ import (
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error
+	BlockUserSessions(ctx context.Context, arg BlockUserSessionsParams) error
+	// pending messages are hidden until locked_until, so other relays skip them while they are published
+	ClaimOutboxMessages(ctx context.Context, arg ClaimOutboxMessagesParams) ([]Outbox, error)
+	CountResetPasswordsSince(ctx context.Context, arg CountResetPasswordsSinceParams) (int64, error)
+	CountVerifyEmailsSince(ctx context.Context, arg CountVerifyEmailsSinceParams) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateLoginLink(ctx context.Context, arg CreateLoginLinkParams) (LoginLink, error)
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (Outbox, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) (RecoveryCode, error)
+	CreateResetPassword(ctx context.Context, arg CreateResetPasswordParams) (ResetPassword, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, username string) error
+	ExpireResetPasswords(ctx context.Context, username string) error
+	ExpireVerifyEmails(ctx context.Context, username string) error
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByOwnerAndCurrency(ctx context.Context, arg GetAccountByOwnerAndCurrencyParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetActiveResetPassword(ctx context.Context, username string) (ResetPassword, error)
+	GetActiveVerifyEmail(ctx context.Context, arg GetActiveVerifyEmailParams) (VerifyEmail, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetResetPasswordByCode(ctx context.Context, arg GetResetPasswordByCodeParams) (ResetPassword, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListActiveSessions(ctx context.Context, username string) ([]Session, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	MarkOutboxMessageFailed(ctx context.Context, arg MarkOutboxMessageFailedParams) error
+	MarkOutboxMessageSent(ctx context.Context, id int64) error
+	RotateSession(ctx context.Context, arg RotateSessionParams) (Session, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) error
+	UpdateLoginLink(ctx context.Context, arg UpdateLoginLinkParams) (LoginLink, error)
+	UpdateResetPassword(ctx context.Context, arg UpdateResetPasswordParams) (ResetPassword, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (RecoveryCode, error)
+	UseTOTPStep(ctx context.Context, arg UseTOTPStepParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,76 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: recovery_code.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createRecoveryCode = `-- name: CreateRecoveryCode :one
+INSERT INTO recovery_codes(
+  username,
+  hashed_code
+) VALUES (
+  $1, $2
+) RETURNING id, username, hashed_code, is_used, created_at
+`
+
+type CreateRecoveryCodeParams struct {
+	Username   string `json:"username"`
+	HashedCode string `json:"hashed_code"`
+}
+
+func (q *Queries) CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) (RecoveryCode, error) {
+	row := q.db.QueryRowContext(ctx, createRecoveryCode, arg.Username, arg.HashedCode)
+	var i RecoveryCode
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.HashedCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteRecoveryCodes = `-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes
+WHERE username = $1
+`
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, username string) error {
+	_, err := q.db.ExecContext(ctx, deleteRecoveryCodes, username)
+	return err
+}
+
+const useRecoveryCode = `-- name: UseRecoveryCode :one
+UPDATE recovery_codes
+SET
+    is_used = TRUE
+WHERE
+    username = $1
+    AND hashed_code = $2
+    AND is_used = FALSE
+RETURNING id, username, hashed_code, is_used, created_at
+`
+
+type UseRecoveryCodeParams struct {
+	Username   string `json:"username"`
+	HashedCode string `json:"hashed_code"`
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (RecoveryCode, error) {
+	row := q.db.QueryRowContext(ctx, useRecoveryCode, arg.Username, arg.HashedCode)
+	var i RecoveryCode
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.HashedCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+	)
+	return i, err
+}
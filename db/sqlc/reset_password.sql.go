@@ -0,0 +1,157 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: reset_password.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const countResetPasswordsSince = `-- name: CountResetPasswordsSince :one
+SELECT COUNT(*) FROM reset_passwords
+WHERE
+    username = $1
+    AND created_at > $2
+`
+
+type CountResetPasswordsSinceParams struct {
+	Username string    `json:"username"`
+	Since    time.Time `json:"since"`
+}
+
+func (q *Queries) CountResetPasswordsSince(ctx context.Context, arg CountResetPasswordsSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countResetPasswordsSince, arg.Username, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createResetPassword = `-- name: CreateResetPassword :one
+INSERT INTO reset_passwords(
+  username,
+  secret_code
+) VALUES (
+  $1, $2
+) RETURNING id, username, secret_code, is_used, created_at, expires_at
+`
+
+type CreateResetPasswordParams struct {
+	Username   string `json:"username"`
+	SecretCode string `json:"secret_code"`
+}
+
+func (q *Queries) CreateResetPassword(ctx context.Context, arg CreateResetPasswordParams) (ResetPassword, error) {
+	row := q.db.QueryRowContext(ctx, createResetPassword, arg.Username, arg.SecretCode)
+	var i ResetPassword
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const expireResetPasswords = `-- name: ExpireResetPasswords :exec
+UPDATE reset_passwords
+SET
+    expires_at = NOW()
+WHERE
+    username = $1
+    AND is_used = FALSE
+    AND expires_at > NOW()
+`
+
+func (q *Queries) ExpireResetPasswords(ctx context.Context, username string) error {
+	_, err := q.db.ExecContext(ctx, expireResetPasswords, username)
+	return err
+}
+
+const getActiveResetPassword = `-- name: GetActiveResetPassword :one
+SELECT id, username, secret_code, is_used, created_at, expires_at FROM reset_passwords
+WHERE
+    username = $1
+    AND is_used = FALSE
+    AND expires_at > NOW()
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetActiveResetPassword(ctx context.Context, username string) (ResetPassword, error) {
+	row := q.db.QueryRowContext(ctx, getActiveResetPassword, username)
+	var i ResetPassword
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getResetPasswordByCode = `-- name: GetResetPasswordByCode :one
+SELECT id, username, secret_code, is_used, created_at, expires_at FROM reset_passwords
+WHERE
+    id = $1
+    AND secret_code = $2
+    AND is_used = FALSE
+    AND expires_at > NOW()
+LIMIT 1
+`
+
+type GetResetPasswordByCodeParams struct {
+	ID         int64  `json:"id"`
+	SecretCode string `json:"secret_code"`
+}
+
+func (q *Queries) GetResetPasswordByCode(ctx context.Context, arg GetResetPasswordByCodeParams) (ResetPassword, error) {
+	row := q.db.QueryRowContext(ctx, getResetPasswordByCode, arg.ID, arg.SecretCode)
+	var i ResetPassword
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const updateResetPassword = `-- name: UpdateResetPassword :one
+UPDATE reset_passwords
+SET
+    is_used = TRUE
+WHERE
+    id = $1
+    AND secret_code = $2
+    AND is_used = FALSE
+    AND expires_at > NOW()
+RETURNING id, username, secret_code, is_used, created_at, expires_at
+`
+
+type UpdateResetPasswordParams struct {
+	ID         int64  `json:"id"`
+	SecretCode string `json:"secret_code"`
+}
+
+func (q *Queries) UpdateResetPassword(ctx context.Context, arg UpdateResetPasswordParams) (ResetPassword, error) {
+	row := q.db.QueryRowContext(ctx, updateResetPassword, arg.ID, arg.SecretCode)
+	var i ResetPassword
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListActiveSessions(t *testing.T) {
+	session := createRandomSession(t)
+
+	blocked := randomSessionParams(session.Username, uuid.New())
+	blocked.IsBlocked = true
+	_, err := testQueries.CreateSession(context.Background(), blocked)
+	require.NoError(t, err)
+
+	expired := randomSessionParams(session.Username, uuid.New())
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	_, err = testQueries.CreateSession(context.Background(), expired)
+	require.NoError(t, err)
+
+	sessions, err := testQueries.ListActiveSessions(context.Background(), session.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, session.ID, sessions[0].ID)
+}
+
+func TestBlockUserSessions(t *testing.T) {
+	session1 := createRandomSession(t)
+
+	arg := randomSessionParams(session1.Username, uuid.New())
+	arg.ID = arg.FamilyID
+	session2, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+
+	err = testQueries.BlockUserSessions(context.Background(), BlockUserSessionsParams{
+		Username:     session1.Username,
+		KeepFamilyID: session2.FamilyID,
+	})
+	require.NoError(t, err)
+
+	sessions, err := testQueries.ListActiveSessions(context.Background(), session1.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, session2.ID, sessions[0].ID)
+}
+
+func TestDeleteExpiredSessions(t *testing.T) {
+	user := createRandomUser(t)
+
+	arg := randomSessionParams(user.Username, uuid.New())
+	arg.ExpiresAt = time.Now().Add(-time.Minute)
+	expired, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+
+	deleted, err := testQueries.DeleteExpiredSessions(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	_, err = testQueries.GetSession(context.Background(), expired.ID)
+	require.Error(t, err)
+}
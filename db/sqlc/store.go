@@ -10,7 +10,16 @@ type Store interface {
 	Querier
 	TransferTrx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
 	CreateUserTrx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	ResendVerifyEmailTx(ctx context.Context, arg ResendVerifyEmailTxParams) (ResendVerifyEmailTxResult, error)
+	DepositTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	WithdrawTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error)
+	RequestPasswordResetTx(ctx context.Context, arg RequestPasswordResetTxParams) (RequestPasswordResetTxResult, error)
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error)
+	EnableTOTPTx(ctx context.Context, arg EnableTOTPTxParams) (User, error)
+	DisableTOTPTx(ctx context.Context, username string) (User, error)
 }
 
 type SQLStore struct {
@@ -39,6 +48,8 @@ func (s *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
 		if rbErr != nil {
 			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
 		}
+
+		return err
 	}
 
 	return tx.Commit()
@@ -2,21 +2,23 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/milhamh95/simplebank/pkg/random"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTransferTx(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
-
 	n := 5
 	amount := int64(10)
 
+	account1 := createRandomAccountWithMinBalance(t, int64(n)*amount)
+	account2 := createRandomAccount(t)
+
 	errCh := make(chan error)
 	results := make(chan TransferTxResult)
 
@@ -111,12 +113,12 @@ func TestTransferTx(t *testing.T) {
 func TestTransferTxDeadlock(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
-
 	n := 10
 	amount := int64(10)
 
+	account1 := createRandomAccountWithMinBalance(t, int64(n)*amount)
+	account2 := createRandomAccountWithMinBalance(t, int64(n)*amount)
+
 	errCh := make(chan error)
 
 	for i := 0; i < n; i++ {
@@ -154,3 +156,86 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	_, err := store.TransferTrx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        account1.Balance + 1,
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+
+	updatedAccount1, err := testQueries.GetAccount(context.TODO(), account1.ID)
+	require.NoError(t, err)
+
+	updatedAccount2, err := testQueries.GetAccount(context.TODO(), account2.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+
+	transfers, err := testQueries.ListTransfers(context.TODO(), ListTransfersParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account1.ID,
+		Limit:         5,
+		Offset:        0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, transfers)
+}
+
+func TestTransferTxIdempotency(t *testing.T) {
+	store := NewStore(testDB)
+
+	amount := int64(10)
+	account1 := createRandomAccountWithMinBalance(t, 2*amount)
+	account2 := createRandomAccount(t)
+
+	arg := TransferTxParams{
+		FromAccountID:  account1.ID,
+		ToAccountID:    account2.ID,
+		Amount:         amount,
+		Username:       account1.Owner,
+		IdempotencyKey: random.RandomString(32),
+	}
+
+	result1, err := store.TransferTrx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// replaying the same request returns the original transfer
+	result2, err := store.TransferTrx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, result1.Transfer.ID, result2.Transfer.ID)
+	require.Equal(t, result1.FromEntry.ID, result2.FromEntry.ID)
+	require.Equal(t, result1.ToEntry.ID, result2.ToEntry.ID)
+
+	// reusing the key for a different request is a conflict
+	arg.Amount = 2 * amount
+	_, err = store.TransferTrx(context.Background(), arg)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrIdempotencyKeyConflict))
+
+	updatedAccount1, err := testQueries.GetAccount(context.TODO(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-amount, updatedAccount1.Balance)
+}
+
+// createRandomAccountWithMinBalance creates a random account
+// that holds at least minBalance
+func createRandomAccountWithMinBalance(t *testing.T, minBalance int64) Account {
+	account := createRandomAccount(t)
+
+	account, err := testQueries.AddAccountBalance(context.TODO(), AddAccountBalanceParams{
+		ID:     account.ID,
+		Amount: minBalance,
+	})
+	require.NoError(t, err)
+
+	return account
+}
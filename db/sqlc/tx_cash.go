@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+)
+
+// VaultOwner is the username of the system user that owns the bank's cash accounts.
+// The vault holds one account per currency, created by migration
+const VaultOwner = "simplebank_vault"
+
+type CashTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+	// Username and IdempotencyKey are optional.
+	// When IdempotencyKey is set, replaying the same cash movement returns the original result
+	Username       string `json:"username"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+type CashTxResult struct {
+	Account      Account `json:"account"`
+	Entry        Entry   `json:"entry"`
+	VaultAccount Account `json:"vault_account"`
+	VaultEntry   Entry   `json:"vault_entry"`
+}
+
+// cashRequest is the part of CashTxParams that identifies a cash movement for idempotency.
+// Amount is signed, so a deposit and a withdrawal never share a key
+type cashRequest struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+// DepositTx moves cash into an account.
+// The account is credited and the vault account of the same currency is debited.
+// It returns ErrIdempotencyKeyConflict when the idempotency key was used for another request
+func (s *SQLStore) DepositTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return s.cashTx(ctx, arg, arg.Amount)
+}
+
+// WithdrawTx moves cash out of an account.
+// The account is debited and the vault account of the same currency is credited.
+// It returns ErrInsufficientFunds when the account can't cover the amount,
+// and ErrIdempotencyKeyConflict when the idempotency key was used for another request
+func (s *SQLStore) WithdrawTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return s.cashTx(ctx, arg, -arg.Amount)
+}
+
+// cashTx adds amount to the account and subtracts it from the vault,
+// so every cash movement is a balanced pair of entries
+func (s *SQLStore) cashTx(ctx context.Context, arg CashTxParams, amount int64) (CashTxResult, error) {
+	var result CashTxResult
+
+	hash, err := requestHash(cashRequest{
+		AccountID: arg.AccountID,
+		Amount:    amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	err = s.execTx(ctx, func(q *Queries) error {
+		if arg.IdempotencyKey != "" {
+			response, replayed, err := claimIdempotencyKey(ctx, q, arg.Username, arg.IdempotencyKey, hash)
+			if err != nil {
+				return err
+			}
+
+			if replayed {
+				return json.Unmarshal(response, &result)
+			}
+		}
+
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if account.Owner == VaultOwner {
+			return ErrVaultAccount
+		}
+
+		vaultAccount, err := q.GetAccountByOwnerAndCurrency(ctx, GetAccountByOwnerAndCurrencyParams{
+			Owner:    VaultOwner,
+			Currency: account.Currency,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrVaultAccountNotFound
+			}
+			return err
+		}
+
+		// lock in ascending id order, the same as TransferTx
+		if account.ID < vaultAccount.ID {
+			account, _, err = lockAccounts(ctx, q, account.ID, vaultAccount.ID)
+		} else {
+			_, account, err = lockAccounts(ctx, q, vaultAccount.ID, account.ID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if amount < 0 && !account.AllowOverdraft && account.Balance < -amount {
+			return ErrInsufficientFunds
+		}
+
+		result.Entry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: account.ID,
+			Amount:    amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.VaultEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: vaultAccount.ID,
+			Amount:    -amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if account.ID < vaultAccount.ID {
+			result.Account, result.VaultAccount, err = addMoney(
+				ctx,
+				q,
+				account.ID,
+				amount,
+				vaultAccount.ID,
+				-amount)
+		} else {
+			result.VaultAccount, result.Account, err = addMoney(
+				ctx,
+				q,
+				vaultAccount.ID,
+				-amount,
+				account.ID,
+				amount)
+		}
+		if err != nil {
+			return err
+		}
+
+		if arg.IdempotencyKey != "" {
+			return saveIdempotencyResponse(ctx, q, arg.Username, arg.IdempotencyKey, result)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
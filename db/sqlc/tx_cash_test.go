@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createRandomAccount(t)
+	vaultAccount := getVaultAccount(t, account.Currency)
+	amount := int64(10)
+
+	result, err := store.DepositTx(context.Background(), CashTxParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account.ID, result.Entry.AccountID)
+	require.Equal(t, amount, result.Entry.Amount)
+	require.Equal(t, vaultAccount.ID, result.VaultEntry.AccountID)
+	require.Equal(t, -amount, result.VaultEntry.Amount)
+
+	require.Equal(t, account.Balance+amount, result.Account.Balance)
+	require.Equal(t, vaultAccount.ID, result.VaultAccount.ID)
+}
+
+func TestWithdrawTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	amount := int64(10)
+	account := createRandomAccountWithMinBalance(t, amount)
+	vaultAccount := getVaultAccount(t, account.Currency)
+
+	result, err := store.WithdrawTx(context.Background(), CashTxParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, -amount, result.Entry.Amount)
+	require.Equal(t, vaultAccount.ID, result.VaultEntry.AccountID)
+	require.Equal(t, amount, result.VaultEntry.Amount)
+	require.Equal(t, account.Balance-amount, result.Account.Balance)
+}
+
+func TestWithdrawTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createRandomAccount(t)
+
+	_, err := store.WithdrawTx(context.Background(), CashTxParams{
+		AccountID: account.ID,
+		Amount:    account.Balance + 1,
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrInsufficientFunds))
+
+	updatedAccount, err := testQueries.GetAccount(context.TODO(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, updatedAccount.Balance)
+}
+
+func TestDepositTxVaultAccount(t *testing.T) {
+	store := NewStore(testDB)
+
+	vaultAccount := getVaultAccount(t, "USD")
+
+	_, err := store.DepositTx(context.Background(), CashTxParams{
+		AccountID: vaultAccount.ID,
+		Amount:    10,
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrVaultAccount))
+}
+
+func TestCashTxIdempotency(t *testing.T) {
+	store := NewStore(testDB)
+
+	amount := int64(10)
+	account := createRandomAccountWithMinBalance(t, amount)
+
+	arg := CashTxParams{
+		AccountID:      account.ID,
+		Amount:         amount,
+		Username:       account.Owner,
+		IdempotencyKey: random.RandomString(32),
+	}
+
+	result1, err := store.DepositTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// replaying the same deposit returns the original entries
+	result2, err := store.DepositTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, result1.Entry.ID, result2.Entry.ID)
+	require.Equal(t, result1.VaultEntry.ID, result2.VaultEntry.ID)
+
+	// a withdrawal of the same amount is a different request
+	_, err = store.WithdrawTx(context.Background(), arg)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrIdempotencyKeyConflict))
+
+	updatedAccount, err := testQueries.GetAccount(context.TODO(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance+amount, updatedAccount.Balance)
+}
+
+func getVaultAccount(t *testing.T, currency string) Account {
+	account, err := testQueries.GetAccountByOwnerAndCurrency(context.TODO(), GetAccountByOwnerAndCurrencyParams{
+		Owner:    VaultOwner,
+		Currency: currency,
+	})
+	require.NoError(t, err)
+	require.True(t, account.AllowOverdraft)
+
+	return account
+}
@@ -4,7 +4,9 @@ import "context"
 
 type CreateUserTxParams struct {
 	CreateUserParams
-	AfterCreate func(user User) error
+	// OutboxMessages are written in the same transaction as the user,
+	// the outbox relay publishes them once the transaction is committed
+	OutboxMessages []CreateOutboxMessageParams
 }
 
 type CreateUserTxResult struct {
@@ -22,7 +24,14 @@ func (s *SQLStore) CreateUserTrx(ctx context.Context, arg CreateUserTxParams) (C
 			return err
 		}
 
-		return arg.AfterCreate(result.User)
+		for _, msg := range arg.OutboxMessages {
+			_, err = q.CreateOutboxMessage(ctx, msg)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 
 	// TODO: update accounts balance
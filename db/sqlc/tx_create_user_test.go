@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/pkg/password"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUserTxOutbox(t *testing.T) {
+	store := NewStore(testDB)
+
+	hashedPassword, err := password.HashPassword(random.RandomString(6))
+	require.NoError(t, err)
+
+	username := random.RandomOwner()
+	payload, err := json.Marshal(map[string]string{"username": username})
+	require.NoError(t, err)
+
+	arg := CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       username,
+			HashedPassword: hashedPassword,
+			FullName:       random.RandomOwner(),
+			Email:          random.RandomEmail(),
+		},
+		OutboxMessages: []CreateOutboxMessageParams{
+			{
+				TaskType: "task:test",
+				Payload:  payload,
+			},
+		},
+	}
+
+	result, err := store.CreateUserTrx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, username, result.User.Username)
+
+	msg := claimOutboxMessage(t, username)
+	require.Equal(t, "task:test", msg.TaskType)
+	require.False(t, msg.SentAt.Valid)
+	require.True(t, msg.NextAttemptAt.After(time.Now()))
+
+	err = testQueries.MarkOutboxMessageFailed(context.Background(), MarkOutboxMessageFailedParams{
+		ID:            msg.ID,
+		LastError:     "redis is down",
+		NextAttemptAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	msg = claimOutboxMessage(t, username)
+	require.Equal(t, int32(1), msg.Attempts)
+	require.Equal(t, "redis is down", msg.LastError)
+
+	err = testQueries.MarkOutboxMessageSent(context.Background(), msg.ID)
+	require.NoError(t, err)
+
+	// the user already exists, so neither the user nor the outbox message is written
+	_, err = store.CreateUserTrx(context.Background(), arg)
+	require.Error(t, err)
+
+	msgs, err := testQueries.ClaimOutboxMessages(context.Background(), ClaimOutboxMessagesParams{
+		LockedUntil: time.Now(),
+		BatchSize:   1000,
+	})
+	require.NoError(t, err)
+	for _, m := range msgs {
+		require.NotContains(t, string(m.Payload), username)
+	}
+}
+
+// claimOutboxMessage claims the pending outbox messages and returns the one of the user
+func claimOutboxMessage(t *testing.T, username string) Outbox {
+	msgs, err := testQueries.ClaimOutboxMessages(context.Background(), ClaimOutboxMessagesParams{
+		LockedUntil: time.Now().Add(time.Minute),
+		BatchSize:   1000,
+	})
+	require.NoError(t, err)
+
+	for _, msg := range msgs {
+		// jsonb doesn't keep the formatting of the payload
+		if strings.Contains(string(msg.Payload), username) {
+			return msg
+		}
+	}
+
+	require.FailNow(t, "outbox message not claimed")
+	return Outbox{}
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type RequestPasswordResetTxParams struct {
+	Username   string
+	SecretCode string
+	// Cooldown is the minimum time between two reset codes of the user
+	Cooldown time.Duration
+	// DailyLimit is the maximum number of reset codes of the user in 24 hours
+	DailyLimit int64
+}
+
+type RequestPasswordResetTxResult struct {
+	User          User
+	ResetPassword ResetPassword
+}
+
+// RequestPasswordResetTx expires the unused reset codes of the user and creates a new one.
+// The user row is locked, so concurrent requests are counted one after the other.
+// It returns sql.ErrNoRows for an unknown user and ErrResetPasswordCooldown or
+// ErrResetPasswordLimit when no email should be sent
+func (s *SQLStore) RequestPasswordResetTx(ctx context.Context, arg RequestPasswordResetTxParams) (RequestPasswordResetTxResult, error) {
+	var result RequestPasswordResetTxResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.GetUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		recent, err := q.CountResetPasswordsSince(ctx, CountResetPasswordsSinceParams{
+			Username: arg.Username,
+			Since:    now.Add(-arg.Cooldown),
+		})
+		if err != nil {
+			return err
+		}
+
+		if recent > 0 {
+			return ErrResetPasswordCooldown
+		}
+
+		today, err := q.CountResetPasswordsSince(ctx, CountResetPasswordsSinceParams{
+			Username: arg.Username,
+			Since:    now.Add(-24 * time.Hour),
+		})
+		if err != nil {
+			return err
+		}
+
+		if today >= arg.DailyLimit {
+			return ErrResetPasswordLimit
+		}
+
+		err = q.ExpireResetPasswords(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.ResetPassword, err = q.CreateResetPassword(ctx, CreateResetPasswordParams{
+			Username:   arg.Username,
+			SecretCode: arg.SecretCode,
+		})
+
+		return err
+	})
+
+	return result, err
+}
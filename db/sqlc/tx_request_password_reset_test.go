@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestPasswordResetTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	arg := RequestPasswordResetTxParams{
+		Username:   user.Username,
+		SecretCode: random.RandomString(32),
+		Cooldown:   time.Minute,
+		DailyLimit: 2,
+	}
+
+	firstResult, err := store.RequestPasswordResetTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, user.Username, firstResult.ResetPassword.Username)
+	require.Equal(t, arg.SecretCode, firstResult.ResetPassword.SecretCode)
+
+	// the first code was just created
+	_, err = store.RequestPasswordResetTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrResetPasswordCooldown)
+
+	arg.Cooldown = 0
+	arg.SecretCode = random.RandomString(32)
+	result, err := store.RequestPasswordResetTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// the first code stopped working, the new one is the active one
+	activeResetPassword, err := testQueries.GetActiveResetPassword(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, result.ResetPassword.ID, activeResetPassword.ID)
+
+	_, err = testQueries.UpdateResetPassword(context.Background(), UpdateResetPasswordParams{
+		ID:         firstResult.ResetPassword.ID,
+		SecretCode: firstResult.ResetPassword.SecretCode,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = store.RequestPasswordResetTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrResetPasswordLimit)
+
+	_, err = store.RequestPasswordResetTx(context.Background(), RequestPasswordResetTxParams{
+		Username:   random.RandomOwner(),
+		SecretCode: random.RandomString(32),
+		DailyLimit: 1,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
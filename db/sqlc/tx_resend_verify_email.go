@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type ResendVerifyEmailTxParams struct {
+	Username   string
+	SecretCode string
+	// Cooldown is the minimum time between two verification emails of the user
+	Cooldown time.Duration
+	// DailyLimit is the maximum number of verification emails of the user in 24 hours
+	DailyLimit int64
+	// OutboxMessages are the tasks to send the new code, published once the transaction is committed
+	OutboxMessages []CreateOutboxMessageParams
+}
+
+type ResendVerifyEmailTxResult struct {
+	User        User
+	VerifyEmail VerifyEmail
+}
+
+// ResendVerifyEmailTx expires the unused codes of the user and creates a new one
+// for the pending email, or for the email when no change is pending.
+// The user row is locked, so concurrent resends are counted one after the other.
+// It returns ErrEmailAlreadyVerified, ErrVerifyEmailCooldown or ErrVerifyEmailLimit
+// when no email should be sent
+func (s *SQLStore) ResendVerifyEmailTx(ctx context.Context, arg ResendVerifyEmailTxParams) (ResendVerifyEmailTxResult, error) {
+	var result ResendVerifyEmailTxResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.GetUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		// a pending email change is verified before the current email
+		email := result.User.PendingEmail
+		if email == "" {
+			if result.User.IsEmailVerified {
+				return ErrEmailAlreadyVerified
+			}
+
+			email = result.User.Email
+		}
+
+		now := time.Now()
+		recent, err := q.CountVerifyEmailsSince(ctx, CountVerifyEmailsSinceParams{
+			Username: arg.Username,
+			Since:    now.Add(-arg.Cooldown),
+		})
+		if err != nil {
+			return err
+		}
+
+		if recent > 0 {
+			return ErrVerifyEmailCooldown
+		}
+
+		today, err := q.CountVerifyEmailsSince(ctx, CountVerifyEmailsSinceParams{
+			Username: arg.Username,
+			Since:    now.Add(-24 * time.Hour),
+		})
+		if err != nil {
+			return err
+		}
+
+		if today >= arg.DailyLimit {
+			return ErrVerifyEmailLimit
+		}
+
+		err = q.ExpireVerifyEmails(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.VerifyEmail, err = q.CreateVerifyEmail(ctx, CreateVerifyEmailParams{
+			Username:   arg.Username,
+			Email:      email,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range arg.OutboxMessages {
+			_, err = q.CreateOutboxMessage(ctx, msg)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResendVerifyEmailTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	oldVerifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: random.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	arg := ResendVerifyEmailTxParams{
+		Username:   user.Username,
+		SecretCode: random.RandomString(32),
+		Cooldown:   time.Minute,
+		DailyLimit: 2,
+	}
+
+	// the first code was just created
+	_, err = store.ResendVerifyEmailTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrVerifyEmailCooldown)
+
+	arg.Cooldown = 0
+	result, err := store.ResendVerifyEmailTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, user.Email, result.VerifyEmail.Email)
+	require.Equal(t, arg.SecretCode, result.VerifyEmail.SecretCode)
+
+	// the old code stopped working, the new one is the active one
+	_, err = testQueries.UpdateVerifyEmail(context.Background(), UpdateVerifyEmailParams{
+		ID:         oldVerifyEmail.ID,
+		SecretCode: oldVerifyEmail.SecretCode,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	activeVerifyEmail, err := testQueries.GetActiveVerifyEmail(context.Background(), GetActiveVerifyEmailParams{
+		Username: user.Username,
+		Email:    user.Email,
+	})
+	require.NoError(t, err)
+	require.Equal(t, result.VerifyEmail.ID, activeVerifyEmail.ID)
+
+	_, err = store.ResendVerifyEmailTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrVerifyEmailLimit)
+
+	_, err = testQueries.UpdateVerifyEmail(context.Background(), UpdateVerifyEmailParams{
+		ID:         activeVerifyEmail.ID,
+		SecretCode: activeVerifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		Username: user.Username,
+		IsEmailVerified: sql.NullBool{
+			Bool:  true,
+			Valid: true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = store.ResendVerifyEmailTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrEmailAlreadyVerified)
+}
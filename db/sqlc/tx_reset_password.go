@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ResetPasswordTxParams struct {
+	ResetID        int64
+	SecretCode     string
+	HashedPassword string
+}
+
+type ResetPasswordTxResult struct {
+	User          User
+	ResetPassword ResetPassword
+}
+
+// ResetPasswordTx consumes the reset code, sets the new password
+// and blocks every session of the user, so a stolen refresh token stops working.
+// It returns sql.ErrNoRows when the code is wrong, used or expired
+func (s *SQLStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error) {
+	var result ResetPasswordTxResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.ResetPassword, err = q.UpdateResetPassword(ctx, UpdateResetPasswordParams{
+			ID:         arg.ResetID,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+			Username: result.ResetPassword.Username,
+			HashedPassword: sql.NullString{
+				String: arg.HashedPassword,
+				Valid:  true,
+			},
+			PasswordChangedAt: sql.NullTime{
+				Time:  time.Now(),
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.BlockUserSessions(ctx, BlockUserSessionsParams{
+			Username:     result.User.Username,
+			KeepFamilyID: uuid.Nil,
+		})
+	})
+
+	return result, err
+}
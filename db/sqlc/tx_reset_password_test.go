@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetPasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	session := createRandomSession(t)
+	resetPassword, err := testQueries.CreateResetPassword(context.Background(), CreateResetPasswordParams{
+		Username:   session.Username,
+		SecretCode: random.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	arg := ResetPasswordTxParams{
+		ResetID:        resetPassword.ID,
+		SecretCode:     resetPassword.SecretCode,
+		HashedPassword: random.RandomString(60),
+	}
+
+	result, err := store.ResetPasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.ResetPassword.IsUsed)
+	require.Equal(t, arg.HashedPassword, result.User.HashedPassword)
+
+	blockedSession, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+
+	// the code can only be used once
+	_, err = store.ResetPasswordTx(context.Background(), arg)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, sql.ErrNoRows))
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type RotateSessionTxParams struct {
+	OldSessionID uuid.UUID
+	// NewSession must belong to the same family as the old session
+	NewSession CreateSessionParams
+}
+
+// RotateSessionTx replaces the old session with a new one of the same family.
+// The old session can only be replaced once, so when two renewals race with the same
+// refresh token only one of them wins. The other gets ErrRefreshTokenReused
+func (s *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error) {
+	var session Session
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		_, err := q.RotateSession(ctx, RotateSessionParams{
+			ID: arg.OldSessionID,
+			ReplacedBy: uuid.NullUUID{
+				UUID:  arg.NewSession.ID,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrRefreshTokenReused
+			}
+			return err
+		}
+
+		session, err = q.CreateSession(ctx, arg.NewSession)
+		return err
+	})
+
+	return session, err
+}
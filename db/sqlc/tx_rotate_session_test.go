@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateSessionTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	oldSession := createRandomSession(t)
+	arg := RotateSessionTxParams{
+		OldSessionID: oldSession.ID,
+		NewSession:   randomSessionParams(oldSession.Username, oldSession.FamilyID),
+	}
+
+	newSession, err := store.RotateSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.NewSession.ID, newSession.ID)
+	require.Equal(t, oldSession.FamilyID, newSession.FamilyID)
+	require.False(t, newSession.ReplacedBy.Valid)
+
+	rotatedSession, err := testQueries.GetSession(context.Background(), oldSession.ID)
+	require.NoError(t, err)
+	require.True(t, rotatedSession.ReplacedBy.Valid)
+	require.Equal(t, newSession.ID, rotatedSession.ReplacedBy.UUID)
+
+	// the old session can't be rotated twice
+	arg.NewSession = randomSessionParams(oldSession.Username, oldSession.FamilyID)
+	_, err = store.RotateSessionTx(context.Background(), arg)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrRefreshTokenReused))
+
+	_, err = testQueries.GetSession(context.Background(), arg.NewSession.ID)
+	require.Error(t, err)
+}
+
+func TestBlockSessionFamily(t *testing.T) {
+	store := NewStore(testDB)
+
+	oldSession := createRandomSession(t)
+	newSession, err := store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		OldSessionID: oldSession.ID,
+		NewSession:   randomSessionParams(oldSession.Username, oldSession.FamilyID),
+	})
+	require.NoError(t, err)
+
+	otherSession := createRandomSession(t)
+
+	err = testQueries.BlockSessionFamily(context.Background(), oldSession.FamilyID)
+	require.NoError(t, err)
+
+	for _, id := range []uuid.UUID{oldSession.ID, newSession.ID} {
+		session, err := testQueries.GetSession(context.Background(), id)
+		require.NoError(t, err)
+		require.True(t, session.IsBlocked)
+	}
+
+	session, err := testQueries.GetSession(context.Background(), otherSession.ID)
+	require.NoError(t, err)
+	require.False(t, session.IsBlocked)
+}
+
+func createRandomSession(t *testing.T) Session {
+	user := createRandomUser(t)
+	familyID := uuid.New()
+
+	arg := randomSessionParams(user.Username, familyID)
+	arg.ID = familyID
+
+	session, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, session.ID)
+	require.Equal(t, arg.FamilyID, session.FamilyID)
+
+	return session
+}
+
+func randomSessionParams(username string, familyID uuid.UUID) CreateSessionParams {
+	return CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     username,
+		RefreshToken: random.RandomString(32),
+		UserAgent:    random.RandomString(10),
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		FamilyID:     familyID,
+	}
+}
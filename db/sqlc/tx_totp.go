@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+type EnableTOTPTxParams struct {
+	Username string
+	// HashedRecoveryCodes replace the recovery codes the user had before
+	HashedRecoveryCodes []string
+}
+
+// EnableTOTPTx turns on TOTP for the user and stores a fresh set of recovery codes
+func (s *SQLStore) EnableTOTPTx(ctx context.Context, arg EnableTOTPTxParams) (User, error) {
+	var user User
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		user, err = q.UpdateUser(ctx, UpdateUserParams{
+			Username: arg.Username,
+			TotpEnabled: sql.NullBool{
+				Bool:  true,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteRecoveryCodes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		for _, hashedCode := range arg.HashedRecoveryCodes {
+			_, err = q.CreateRecoveryCode(ctx, CreateRecoveryCodeParams{
+				Username:   arg.Username,
+				HashedCode: hashedCode,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return user, err
+}
+
+// DisableTOTPTx turns off TOTP for the user, removing the secret and the recovery codes
+func (s *SQLStore) DisableTOTPTx(ctx context.Context, username string) (User, error) {
+	var user User
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		user, err = q.UpdateUser(ctx, UpdateUserParams{
+			Username: username,
+			TotpSecret: sql.NullString{
+				String: "",
+				Valid:  true,
+			},
+			TotpEnabled: sql.NullBool{
+				Bool:  false,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteRecoveryCodes(ctx, username)
+	})
+
+	return user, err
+}
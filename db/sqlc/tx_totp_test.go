@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableAndDisableTOTPTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	_, err := testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		Username: user.Username,
+		TotpSecret: sql.NullString{
+			String: random.RandomString(32),
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+
+	hashedCodes := []string{random.RandomString(64), random.RandomString(64)}
+	enabledUser, err := store.EnableTOTPTx(context.Background(), EnableTOTPTxParams{
+		Username:            user.Username,
+		HashedRecoveryCodes: hashedCodes,
+	})
+	require.NoError(t, err)
+	require.True(t, enabledUser.TotpEnabled)
+	require.NotEmpty(t, enabledUser.TotpSecret)
+
+	// a recovery code can only be used once
+	recoveryCode, err := testQueries.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{
+		Username:   user.Username,
+		HashedCode: hashedCodes[0],
+	})
+	require.NoError(t, err)
+	require.True(t, recoveryCode.IsUsed)
+
+	_, err = testQueries.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{
+		Username:   user.Username,
+		HashedCode: hashedCodes[0],
+	})
+	require.True(t, errors.Is(err, sql.ErrNoRows))
+
+	disabledUser, err := store.DisableTOTPTx(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.False(t, disabledUser.TotpEnabled)
+	require.Empty(t, disabledUser.TotpSecret)
+
+	_, err = testQueries.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{
+		Username:   user.Username,
+		HashedCode: hashedCodes[1],
+	})
+	require.True(t, errors.Is(err, sql.ErrNoRows))
+}
@@ -1,11 +1,18 @@
 package db
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	Amount        int64 `json:"amount"`
+	// Username and IdempotencyKey are optional.
+	// When IdempotencyKey is set, replaying the same transfer returns the original result
+	Username       string `json:"username"`
+	IdempotencyKey string `json:"idempotency_key"`
 }
 
 type TransferTxResult struct {
@@ -16,17 +23,63 @@ type TransferTxResult struct {
 	ToEntry     Entry    `json:"to_entry"`
 }
 
+// transferRequest is the part of TransferTxParams
+// that identifies a transfer for idempotency
+type transferRequest struct {
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	Amount        int64 `json:"amount"`
+}
+
 var txKey = struct{}{}
 
 // TransferTx performs a money transfer from one account to the other.
 // It cretes a transfer record, and account entires, and update acccount balance within a single
-// database connection
+// database connection.
+// It returns ErrInsufficientFunds when the from account can't cover the amount,
+// and ErrIdempotencyKeyConflict when the idempotency key was used for another transfer
 func (s *SQLStore) TransferTrx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
-	err := s.execTx(ctx, func(q *Queries) error {
+	hash, err := requestHash(transferRequest{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	err = s.execTx(ctx, func(q *Queries) error {
 		var err error
 
+		if arg.IdempotencyKey != "" {
+			response, replayed, err := claimIdempotencyKey(ctx, q, arg.Username, arg.IdempotencyKey, hash)
+			if err != nil {
+				return err
+			}
+
+			if replayed {
+				return json.Unmarshal(response, &result)
+			}
+		}
+
+		// lock both accounts in the same order as addMoney does,
+		// so concurrent transfers in opposite directions can't deadlock
+		var fromAccount Account
+		if arg.FromAccountID < arg.ToAccountID {
+			fromAccount, _, err = lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+		} else {
+			_, fromAccount, err = lockAccounts(ctx, q, arg.ToAccountID, arg.FromAccountID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if !fromAccount.AllowOverdraft && fromAccount.Balance < arg.Amount {
+			return ErrInsufficientFunds
+		}
+
 		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
 			FromAccountID: arg.FromAccountID,
 			ToAccountID:   arg.ToAccountID,
@@ -48,6 +101,9 @@ func (s *SQLStore) TransferTrx(ctx context.Context, arg TransferTxParams) (Trans
 			AccountID: arg.ToAccountID,
 			Amount:    arg.Amount,
 		})
+		if err != nil {
+			return err
+		}
 
 		if arg.FromAccountID < arg.ToAccountID {
 			result.FromAccount, result.ToAccount, err = addMoney(
@@ -66,15 +122,37 @@ func (s *SQLStore) TransferTrx(ctx context.Context, arg TransferTxParams) (Trans
 				arg.FromAccountID,
 				-arg.Amount)
 		}
+		if err != nil {
+			return err
+		}
+
+		if arg.IdempotencyKey != "" {
+			return saveIdempotencyResponse(ctx, q, arg.Username, arg.IdempotencyKey, result)
+		}
 
 		return nil
 	})
 
-	// TODO: update accounts balance
-
 	return result, err
 }
 
+// lockAccounts locks accountID1 and then accountID2 for update.
+// Callers must pass the account ids in ascending order.
+func lockAccounts(
+	ctx context.Context,
+	q *Queries,
+	accountID1 int64,
+	accountID2 int64,
+) (account1 Account, account2 Account, err error) {
+	account1, err = q.GetAccountForUpdate(ctx, accountID1)
+	if err != nil {
+		return
+	}
+
+	account2, err = q.GetAccountForUpdate(ctx, accountID2)
+	return
+}
+
 func addMoney(
 	ctx context.Context,
 	q *Queries,
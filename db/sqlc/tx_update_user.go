@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+type UpdateUserTxParams struct {
+	// UpdateUserParams.Email is ignored, a new email is set with NewEmail
+	UpdateUserParams
+	// NewEmail becomes the pending email of the user, users.email is only replaced
+	// once the new address is verified with VerifyEmailTx
+	NewEmail sql.NullString
+	// EmailChangeMessages are written to the outbox when NewEmail starts a new email change,
+	// e.g. the tasks to verify the new address and to notify the old one
+	EmailChangeMessages []CreateOutboxMessageParams
+}
+
+type UpdateUserTxResult struct {
+	User User
+	// EmailChangeStarted is true when NewEmail became the pending email
+	EmailChangeStarted bool
+}
+
+// UpdateUserTx updates the user and starts an email change when NewEmail differs from the user's email.
+// Asking for the current email again cancels a pending change. A new password blocks every session of the user
+func (s *SQLStore) UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error) {
+	var result UpdateUserTxResult
+
+	err := s.execTx(ctx, func(q *Queries) error {
+		updateArg := arg.UpdateUserParams
+		updateArg.Email = sql.NullString{}
+
+		if arg.NewEmail.Valid {
+			user, err := q.GetUserForUpdate(ctx, arg.Username)
+			if err != nil {
+				return err
+			}
+
+			expireCodes := false
+			switch arg.NewEmail.String {
+			case user.Email:
+				// the codes sent to the cancelled pending email stop working
+				expireCodes = user.PendingEmail != ""
+				updateArg.PendingEmail = sql.NullString{
+					String: "",
+					Valid:  true,
+				}
+			case user.PendingEmail:
+				// the change was already started, the code sent to it is still valid
+			default:
+				expireCodes = true
+				updateArg.PendingEmail = arg.NewEmail
+				result.EmailChangeStarted = true
+			}
+
+			if expireCodes {
+				err = q.ExpireVerifyEmails(ctx, arg.Username)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		var err error
+		result.User, err = q.UpdateUser(ctx, updateArg)
+		if err != nil {
+			return err
+		}
+
+		// like ResetPasswordTx, a new password logs out every session
+		if arg.HashedPassword.Valid {
+			err = q.BlockUserSessions(ctx, BlockUserSessionsParams{
+				Username:     arg.Username,
+				KeepFamilyID: uuid.Nil,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if !result.EmailChangeStarted {
+			return nil
+		}
+
+		for _, msg := range arg.EmailChangeMessages {
+			_, err = q.CreateOutboxMessage(ctx, msg)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
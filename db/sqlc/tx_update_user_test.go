@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateUserTxEmailChange(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	newEmail := random.RandomEmail()
+
+	arg := UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+		},
+		NewEmail: sql.NullString{
+			String: newEmail,
+			Valid:  true,
+		},
+	}
+
+	result, err := store.UpdateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.EmailChangeStarted)
+	require.Equal(t, user.Email, result.User.Email)
+	require.Equal(t, newEmail, result.User.PendingEmail)
+
+	// asking for the pending email again doesn't start another change
+	result, err = store.UpdateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, result.EmailChangeStarted)
+	require.Equal(t, newEmail, result.User.PendingEmail)
+
+	verifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      newEmail,
+		SecretCode: random.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	verifyResult, err := store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailId:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+	require.Equal(t, newEmail, verifyResult.User.Email)
+	require.Empty(t, verifyResult.User.PendingEmail)
+	require.True(t, verifyResult.User.IsEmailVerified)
+}
+
+func TestUpdateUserTxCancelEmailChange(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	newEmail := random.RandomEmail()
+
+	_, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+		},
+		NewEmail: sql.NullString{
+			String: newEmail,
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+
+	verifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      newEmail,
+		SecretCode: random.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	// asking for the current email cancels the change and its code
+	result, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+		},
+		NewEmail: sql.NullString{
+			String: user.Email,
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.EmailChangeStarted)
+	require.Empty(t, result.User.PendingEmail)
+
+	_, err = store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailId:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpdateUserTxPasswordChange(t *testing.T) {
+	store := NewStore(testDB)
+	session := createRandomSession(t)
+
+	result, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: session.Username,
+			HashedPassword: sql.NullString{
+				String: random.RandomString(60),
+				Valid:  true,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, session.Username, result.User.Username)
+
+	blockedSession, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+}
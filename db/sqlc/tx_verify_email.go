@@ -15,7 +15,11 @@ type VerifyEmailTxResult struct {
 	VerifyEmail VerifyEmail
 }
 
-func (s *SQLStore) VerifyEmailTrx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error) {
+// VerifyEmailTx consumes the verify code and marks the user's email as verified.
+// A code sent to the pending email replaces users.email with it.
+// It returns sql.ErrNoRows when the code is wrong, used or expired
+// and ErrVerifyEmailOutdated when the code belongs to an email the user doesn't use anymore
+func (s *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error) {
 	var result VerifyEmailTxResult
 
 	err := s.execTx(ctx, func(q *Queries) error {
@@ -27,18 +31,38 @@ func (s *SQLStore) VerifyEmailTrx(ctx context.Context, arg VerifyEmailTxParams)
 		if err != nil {
 			return err
 		}
-		q.UpdateUser(ctx, UpdateUserParams{
-			Username: result.VerifyEmail.Username,
+
+		user, err := q.GetUserForUpdate(ctx, result.VerifyEmail.Username)
+		if err != nil {
+			return err
+		}
+
+		updateArg := UpdateUserParams{
+			Username: user.Username,
 			IsEmailVerified: sql.NullBool{
 				Bool:  true,
 				Valid: true,
 			},
-		})
+		}
 
+		switch result.VerifyEmail.Email {
+		case user.PendingEmail:
+			updateArg.Email = sql.NullString{
+				String: user.PendingEmail,
+				Valid:  true,
+			}
+			updateArg.PendingEmail = sql.NullString{
+				String: "",
+				Valid:  true,
+			}
+		case user.Email:
+		default:
+			return ErrVerifyEmailOutdated
+		}
+
+		result.User, err = q.UpdateUser(ctx, updateArg)
 		return err
 	})
 
-	// TODO: update accounts balance
-
 	return result, err
 }
@@ -18,7 +18,7 @@ INSERT INTO users (
   email
 ) VALUES (
   $1, $2, $3, $4
-) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified
+) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, totp_enabled, pending_email, totp_last_used_step
 `
 
 type CreateUserParams struct {
@@ -44,12 +44,17 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.PendingEmail,
+		&i.TotpLastUsedStep,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified FROM users
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, totp_enabled, pending_email, totp_last_used_step FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -64,6 +69,62 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.PendingEmail,
+		&i.TotpLastUsedStep,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, totp_enabled, pending_email, totp_last_used_step FROM users
+WHERE email = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.PendingEmail,
+		&i.TotpLastUsedStep,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, totp_enabled, pending_email, totp_last_used_step FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.PendingEmail,
+		&i.TotpLastUsedStep,
 	)
 	return i, err
 }
@@ -75,10 +136,14 @@ SET
   password_changed_at = COALESCE($2, password_changed_at),
   full_name = COALESCE($3, full_name),
   email = COALESCE($4, email),
-  is_email_verified = COALESCE($5, is_email_verified)
+  pending_email = COALESCE($5, pending_email),
+  is_email_verified = COALESCE($6, is_email_verified),
+  role = COALESCE($7, role),
+  totp_secret = COALESCE($8, totp_secret),
+  totp_enabled = COALESCE($9, totp_enabled)
 WHERE
-  username = $6
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified
+  username = $10
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, totp_secret, totp_enabled, pending_email, totp_last_used_step
 `
 
 type UpdateUserParams struct {
@@ -86,7 +151,11 @@ type UpdateUserParams struct {
 	PasswordChangedAt sql.NullTime   `json:"password_changed_at"`
 	FullName          sql.NullString `json:"full_name"`
 	Email             sql.NullString `json:"email"`
+	PendingEmail      sql.NullString `json:"pending_email"`
 	IsEmailVerified   sql.NullBool   `json:"is_email_verified"`
+	Role              sql.NullString `json:"role"`
+	TotpSecret        sql.NullString `json:"totp_secret"`
+	TotpEnabled       sql.NullBool   `json:"totp_enabled"`
 	Username          string         `json:"username"`
 }
 
@@ -96,7 +165,11 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.PasswordChangedAt,
 		arg.FullName,
 		arg.Email,
+		arg.PendingEmail,
 		arg.IsEmailVerified,
+		arg.Role,
+		arg.TotpSecret,
+		arg.TotpEnabled,
 		arg.Username,
 	)
 	var i User
@@ -108,6 +181,30 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
+		&i.Role,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.PendingEmail,
+		&i.TotpLastUsedStep,
 	)
 	return i, err
 }
+
+const useTOTPStep = `-- name: UseTOTPStep :execrows
+UPDATE users
+SET totp_last_used_step = $1
+WHERE username = $2 AND totp_last_used_step < $1
+`
+
+type UseTOTPStepParams struct {
+	Step     int64  `json:"step"`
+	Username string `json:"username"`
+}
+
+func (q *Queries) UseTOTPStep(ctx context.Context, arg UseTOTPStepParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useTOTPStep, arg.Step, arg.Username)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
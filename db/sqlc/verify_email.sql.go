@@ -7,8 +7,28 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
+const countVerifyEmailsSince = `-- name: CountVerifyEmailsSince :one
+SELECT COUNT(*) FROM verify_emails
+WHERE
+    username = $1
+    AND created_at > $2
+`
+
+type CountVerifyEmailsSinceParams struct {
+	Username string    `json:"username"`
+	Since    time.Time `json:"since"`
+}
+
+func (q *Queries) CountVerifyEmailsSince(ctx context.Context, arg CountVerifyEmailsSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countVerifyEmailsSince, arg.Username, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createVerifyEmail = `-- name: CreateVerifyEmail :one
 INSERT INTO verify_emails(
   username,
@@ -40,6 +60,52 @@ func (q *Queries) CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailPa
 	return i, err
 }
 
+const expireVerifyEmails = `-- name: ExpireVerifyEmails :exec
+UPDATE verify_emails
+SET
+    expires_at = NOW()
+WHERE
+    username = $1
+    AND is_used = FALSE
+    AND expires_at > NOW()
+`
+
+func (q *Queries) ExpireVerifyEmails(ctx context.Context, username string) error {
+	_, err := q.db.ExecContext(ctx, expireVerifyEmails, username)
+	return err
+}
+
+const getActiveVerifyEmail = `-- name: GetActiveVerifyEmail :one
+SELECT id, username, email, secret_code, is_used, created_at, expires_at FROM verify_emails
+WHERE
+    username = $1
+    AND email = $2
+    AND is_used = FALSE
+    AND expires_at > NOW()
+ORDER BY id DESC
+LIMIT 1
+`
+
+type GetActiveVerifyEmailParams struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func (q *Queries) GetActiveVerifyEmail(ctx context.Context, arg GetActiveVerifyEmailParams) (VerifyEmail, error) {
+	row := q.db.QueryRowContext(ctx, getActiveVerifyEmail, arg.Username, arg.Email)
+	var i VerifyEmail
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCode,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
 const updateVerifyEmail = `-- name: UpdateVerifyEmail :one
 UPDATE verify_emails
 SET
@@ -48,7 +114,7 @@ WHERE
     id = $1
     AND secret_code = $2
     AND is_used = FALSE
-    AND expired_at > NOW()
+    AND expires_at > NOW()
 RETURNING id, username, email, secret_code, is_used, created_at, expires_at
 `
 
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetActiveVerifyEmail(t *testing.T) {
+	user := createRandomUser(t)
+	arg := GetActiveVerifyEmailParams{
+		Username: user.Username,
+		Email:    user.Email,
+	}
+
+	_, err := testQueries.GetActiveVerifyEmail(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	verifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: random.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	activeVerifyEmail, err := testQueries.GetActiveVerifyEmail(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, verifyEmail.ID, activeVerifyEmail.ID)
+	require.Equal(t, verifyEmail.SecretCode, activeVerifyEmail.SecretCode)
+
+	// another email address has its own code
+	_, err = testQueries.GetActiveVerifyEmail(context.Background(), GetActiveVerifyEmailParams{
+		Username: user.Username,
+		Email:    random.RandomEmail(),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.UpdateVerifyEmail(context.Background(), UpdateVerifyEmailParams{
+		ID:         verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetActiveVerifyEmail(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,15 @@
+package denylist
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenDenylist keeps the ids of revoked tokens until they expire
+type TokenDenylist interface {
+	// Deny revokes the token, expiresAt is when the token would expire anyway
+	Deny(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error
+	IsDenied(ctx context.Context, tokenID uuid.UUID) (bool, error)
+}
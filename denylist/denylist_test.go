@@ -0,0 +1,67 @@
+package denylist
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryDenylist(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	d := NewMemoryDenylist()
+	d.now = func() time.Time { return now }
+
+	tokenID := uuid.New()
+	require.NoError(t, d.Deny(ctx, tokenID, now.Add(time.Minute)))
+
+	denied, err := d.IsDenied(ctx, tokenID)
+	require.NoError(t, err)
+	require.True(t, denied)
+
+	denied, err = d.IsDenied(ctx, uuid.New())
+	require.NoError(t, err)
+	require.False(t, denied)
+
+	// the token is forgotten once it expired
+	now = now.Add(time.Minute)
+	denied, err = d.IsDenied(ctx, tokenID)
+	require.NoError(t, err)
+	require.False(t, denied)
+
+	// expired tokens are not stored
+	expiredTokenID := uuid.New()
+	require.NoError(t, d.Deny(ctx, expiredTokenID, now.Add(-time.Second)))
+	require.NotContains(t, d.tokens, expiredTokenID)
+}
+
+func TestRedisDenylistFallback(t *testing.T) {
+	ctx := context.Background()
+
+	// nothing listens on port 1, every redis call fails
+	client := redis.NewClient(&redis.Options{
+		Addr:       "127.0.0.1:1",
+		MaxRetries: -1,
+	})
+	defer client.Close()
+
+	d := NewRedisDenylist(client)
+	tokenID := uuid.New()
+
+	err := d.Deny(ctx, tokenID, time.Now().Add(time.Minute))
+	require.Error(t, err)
+
+	// the token revoked by this server is still known
+	denied, err := d.IsDenied(ctx, tokenID)
+	require.NoError(t, err)
+	require.True(t, denied)
+
+	denied, err = d.IsDenied(ctx, uuid.New())
+	require.NoError(t, err)
+	require.False(t, denied)
+}
@@ -0,0 +1,72 @@
+package denylist
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryDenylist keeps the revoked tokens in the process memory.
+// They are lost on restart and are not shared between servers
+type MemoryDenylist struct {
+	now       func() time.Time
+	mu        sync.Mutex
+	tokens    map[uuid.UUID]time.Time
+	lastSweep time.Time
+}
+
+// sweepInterval is how often expired tokens are removed
+const sweepInterval = time.Minute
+
+func NewMemoryDenylist() *MemoryDenylist {
+	return &MemoryDenylist{
+		now:    time.Now,
+		tokens: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (d *MemoryDenylist) Deny(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	d.removeExpired(now)
+
+	if expiresAt.After(now) {
+		d.tokens[tokenID] = expiresAt
+	}
+
+	return nil
+}
+
+func (d *MemoryDenylist) IsDenied(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	expiresAt, ok := d.tokens[tokenID]
+	if !ok {
+		return false, nil
+	}
+
+	if !d.now().Before(expiresAt) {
+		delete(d.tokens, tokenID)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (d *MemoryDenylist) removeExpired(now time.Time) {
+	if now.Sub(d.lastSweep) < sweepInterval {
+		return
+	}
+	d.lastSweep = now
+
+	for tokenID, expiresAt := range d.tokens {
+		if !now.Before(expiresAt) {
+			delete(d.tokens, tokenID)
+		}
+	}
+}
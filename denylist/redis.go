@@ -0,0 +1,56 @@
+package denylist
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const redisKeyPrefix = "token_denylist:"
+
+// RedisDenylist shares the revoked tokens between every server, each key expires with its token.
+// The tokens are also kept in memory, when redis fails only the tokens revoked
+// by this server are known
+type RedisDenylist struct {
+	client *redis.Client
+	local  *MemoryDenylist
+}
+
+func NewRedisDenylist(client *redis.Client) *RedisDenylist {
+	return &RedisDenylist{
+		client: client,
+		local:  NewMemoryDenylist(),
+	}
+}
+
+func (d *RedisDenylist) Deny(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error {
+	err := d.local.Deny(ctx, tokenID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return d.client.Set(ctx, redisKeyPrefix+tokenID.String(), 1, ttl).Err()
+}
+
+func (d *RedisDenylist) IsDenied(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	denied, err := d.local.IsDenied(ctx, tokenID)
+	if err != nil || denied {
+		return denied, err
+	}
+
+	n, err := d.client.Exists(ctx, redisKeyPrefix+tokenID.String()).Result()
+	if err != nil {
+		log.Warn().Err(err).Msg("token denylist: redis exists, use memory fallback")
+		return false, nil
+	}
+
+	return n > 0, nil
+}
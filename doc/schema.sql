@@ -8,6 +8,11 @@ CREATE TABLE "users" (
   "full_name" varchar NOT NULL,
   "email" varchar UNIQUE NOT NULL,
   "is_email_verified" bool NOT NULL DEFAULT false,
+  "pending_email" varchar NOT NULL DEFAULT '',
+  "role" varchar NOT NULL DEFAULT 'depositor',
+  "totp_secret" varchar NOT NULL DEFAULT '',
+  "totp_enabled" bool NOT NULL DEFAULT false,
+  "totp_last_used_step" bigint NOT NULL DEFAULT 0,
   "password_changed_at" timestamptz NOT NULL DEFAULT '0001-01-01',
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
@@ -27,6 +32,7 @@ CREATE TABLE "accounts" (
   "owner" varchar NOT NULL,
   "balance" bigint NOT NULL,
   "currency" varchar NOT NULL,
+  "allow_overdraft" bool NOT NULL DEFAULT false,
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
@@ -53,6 +59,54 @@ CREATE TABLE "sessions" (
   "client_ip" varchar NOT NULL,
   "is_blocked" boolean NOT NULL DEFAULT false,
   "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "family_id" uuid NOT NULL,
+  "replaced_by" uuid
+);
+
+CREATE TABLE "idempotency_keys" (
+  "username" varchar NOT NULL,
+  "idempotency_key" varchar NOT NULL,
+  "request_hash" varchar NOT NULL,
+  "response" jsonb NOT NULL DEFAULT '{}',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "idempotency_key")
+);
+
+CREATE TABLE "reset_passwords" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "secret_code" varchar NOT NULL,
+  "is_used" bool NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
+);
+
+CREATE TABLE "recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "hashed_code" varchar NOT NULL,
+  "is_used" bool NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "login_links" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "hashed_secret_code" varchar NOT NULL,
+  "is_used" bool NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
+);
+
+CREATE TABLE "outbox" (
+  "id" bigserial PRIMARY KEY,
+  "task_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL DEFAULT '{}',
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+  "sent_at" timestamptz,
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
@@ -68,10 +122,30 @@ CREATE INDEX ON "transfers" ("to_account_id");
 
 CREATE INDEX ON "transfers" ("from_account_id", "to_account_id");
 
+CREATE INDEX ON "sessions" ("family_id");
+
+CREATE UNIQUE INDEX ON "recovery_codes" ("username", "hashed_code");
+
+CREATE INDEX ON "outbox" ("sent_at", "next_attempt_at");
+
+COMMENT ON COLUMN "users"."pending_email" IS 'new email until it is verified, empty otherwise';
+
+COMMENT ON COLUMN "users"."totp_secret" IS 'encrypted, empty until the user enrolls';
+
+COMMENT ON COLUMN "users"."totp_last_used_step" IS 'time step of the last accepted totp code';
+
 COMMENT ON COLUMN "entries"."amount" IS 'can be negative or positive';
 
 COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
 
+COMMENT ON COLUMN "sessions"."family_id" IS 'id of the first session created at login';
+
+COMMENT ON COLUMN "sessions"."replaced_by" IS 'set once the refresh token was rotated';
+
+COMMENT ON COLUMN "login_links"."hashed_secret_code" IS 'sha256 of the emailed secret code';
+
+COMMENT ON COLUMN "outbox"."sent_at" IS 'null until the task was published';
+
 ALTER TABLE "verify_emails" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
 
 ALTER TABLE "accounts" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
@@ -83,3 +157,11 @@ ALTER TABLE "transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts
 ALTER TABLE "transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "sessions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "reset_passwords" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "recovery_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "login_links" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
@@ -3,6 +3,8 @@ package gapi
 import (
 	"context"
 	"fmt"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pkg/role"
 	"github.com/milhamh95/simplebank/token"
 	"google.golang.org/grpc/metadata"
 	"strings"
@@ -38,10 +40,52 @@ func (s *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
 	}
 
 	accessToken := fields[1]
-	payload, err := s.tokenMaker.VerifyToken(accessToken)
+	payload, err := s.tokenMaker.VerifyToken(accessToken, token.TokenTypeAccess)
 	if err != nil {
 		return nil, fmt.Errorf("invalid access token: %s", err)
 	}
 
+	denied, err := s.tokenDenylist.IsDenied(ctx, payload.ID)
+	if err != nil {
+		return nil, fmt.Errorf("check token denylist: %s", err)
+	}
+
+	if denied {
+		return nil, fmt.Errorf("access token was revoked")
+	}
+
+	user, err := s.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		return nil, fmt.Errorf("find user: %s", err)
+	}
+
+	// a password change revokes every token issued before it
+	if payload.IssuedBefore(user.PasswordChangedAt) {
+		return nil, fmt.Errorf("access token was issued before the password changed")
+	}
+
 	return payload, nil
 }
+
+// hasPermission reports whether the token role is one of accessibleRoles
+func hasPermission(payload *token.Payload, accessibleRoles ...string) bool {
+	for _, accessibleRole := range accessibleRoles {
+		if payload.Role == accessibleRole {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canReadAccount reports whether the user can read the account.
+// Depositors can only read their own accounts, bankers and admins can read any account
+func canReadAccount(payload *token.Payload, account db.Account) bool {
+	return account.Owner == payload.Username || hasPermission(payload, role.Banker, role.Admin)
+}
+
+// canManageUser reports whether the user can update the given user.
+// Users can update themselves, admins can update anyone
+func canManageUser(payload *token.Payload, username string) bool {
+	return payload.Username == username || hasPermission(payload, role.Admin)
+}
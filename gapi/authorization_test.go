@@ -0,0 +1,107 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuthorizeUser(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name       string
+		buildStubs func(t *testing.T, server *Server, store *fake.FakeStore) context.Context
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "success",
+			buildStubs: func(t *testing.T, server *Server, store *fake.FakeStore) context.Context {
+				store.GetUserReturns(user, nil)
+				return newContextWithBearerToken(t, server.tokenMaker, user.Username, role.Depositor, time.Minute)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "revoked token",
+			buildStubs: func(t *testing.T, server *Server, store *fake.FakeStore) context.Context {
+				store.GetUserReturns(user, nil)
+
+				accessToken, payload, err := server.tokenMaker.CreateToken(user.Username, role.Depositor, token.TokenTypeAccess, time.Minute)
+				require.NoError(t, err)
+
+				err = server.tokenDenylist.Deny(context.Background(), payload.ID, payload.ExpiredAt)
+				require.NoError(t, err)
+
+				md := metadata.MD{
+					authorizationHeader: []string{fmt.Sprintf("%s %s", authorizationBearer, accessToken)},
+				}
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.EqualError(t, err, "access token was revoked")
+			},
+		},
+		{
+			name: "refresh token",
+			buildStubs: func(t *testing.T, server *Server, store *fake.FakeStore) context.Context {
+				store.GetUserReturns(user, nil)
+
+				refreshToken, _, err := server.tokenMaker.CreateToken(user.Username, role.Depositor, token.TokenTypeRefresh, time.Minute)
+				require.NoError(t, err)
+
+				md := metadata.MD{
+					authorizationHeader: []string{fmt.Sprintf("%s %s", authorizationBearer, refreshToken)},
+				}
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, token.ErrWrongTokenType.Error())
+			},
+		},
+		{
+			name: "issued before password change",
+			buildStubs: func(t *testing.T, server *Server, store *fake.FakeStore) context.Context {
+				changedUser := user
+				changedUser.PasswordChangedAt = time.Now().Add(time.Second)
+				store.GetUserReturns(changedUser, nil)
+				return newContextWithBearerToken(t, server.tokenMaker, user.Username, role.Depositor, time.Minute)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.EqualError(t, err, "access token was issued before the password changed")
+			},
+		},
+		{
+			name: "user not found",
+			buildStubs: func(t *testing.T, server *Server, store *fake.FakeStore) context.Context {
+				store.GetUserReturns(db.User{}, sql.ErrNoRows)
+				return newContextWithBearerToken(t, server.tokenMaker, user.Username, role.Depositor, time.Minute)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			server := newTestServer(t, fakeStore, nil)
+
+			ctx := tc.buildStubs(t, server, fakeStore)
+			_, err := server.authorizeUser(ctx)
+			tc.checkError(t, err)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cashRequest is implemented by pb.DepositRequest and pb.WithdrawRequest
+type cashRequest interface {
+	GetAccountId() int64
+	GetAmount() int64
+	GetCurrency() string
+	GetIdempotencyKey() string
+}
+
+// cashTx is the store transaction moving the cash, e.g. db.Store.DepositTx
+type cashTx func(ctx context.Context, arg db.CashTxParams) (db.CashTxResult, error)
+
+// handleCash authorizes and validates a cash request and runs it with cashTx.
+// Only bankers and admins can handle cash
+func (s *Server) handleCash(ctx context.Context, req cashRequest, cashTx cashTx) (db.CashTxResult, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return db.CashTxResult{}, unauthenticatedError(err)
+	}
+
+	if !hasPermission(authPayload, role.Banker, role.Admin) {
+		return db.CashTxResult{}, status.Errorf(codes.PermissionDenied, "only bankers and admins can handle cash")
+	}
+
+	violations := validateCashRequest(req)
+	if violations != nil {
+		return db.CashTxResult{}, invalidArgumentError(violations)
+	}
+
+	_, err = s.validAccount(ctx, "account_id", req.GetAccountId(), req.GetCurrency())
+	if err != nil {
+		return db.CashTxResult{}, err
+	}
+
+	txResult, err := cashTx(ctx, db.CashTxParams{
+		AccountID:      req.GetAccountId(),
+		Amount:         req.GetAmount(),
+		Username:       authPayload.Username,
+		IdempotencyKey: req.GetIdempotencyKey(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			return db.CashTxResult{}, status.Errorf(codes.FailedPrecondition, "account [%d] has insufficient funds", req.GetAccountId())
+		}
+
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			return db.CashTxResult{}, status.Errorf(codes.AlreadyExists, "%s", err.Error())
+		}
+
+		if errors.Is(err, db.ErrVaultAccount) {
+			return db.CashTxResult{}, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+				fieldViolation("account_id", err),
+			})
+		}
+
+		return db.CashTxResult{}, status.Errorf(codes.Internal, "cash transaction: %s", err.Error())
+	}
+
+	return txResult, nil
+}
+
+func validateCashRequest(req cashRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateAccountID(req.GetAccountId())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("account_id", err),
+		)
+	}
+
+	err = validator.ValidateAmount(req.GetAmount())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("amount", err),
+		)
+	}
+
+	err = validator.ValidateCurrency(req.GetCurrency())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("currency", err),
+		)
+	}
+
+	if req.GetIdempotencyKey() != "" {
+		err = validator.ValidateIdempotencyKey(req.GetIdempotencyKey())
+		if err != nil {
+			violations = append(
+				violations,
+				fieldViolation("idempotency_key", err),
+			)
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,44 @@
+package gapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/milhamh95/simplebank/token"
+	"github.com/rs/zerolog/log"
+)
+
+// JWKSHandler serves the public keys of the token maker as a JWKS document,
+// so other services can verify access tokens without the signing key.
+// It responds not found when tokens aren't signed with a public key
+func (s *Server) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		provider, ok := s.tokenMaker.(token.KeySetProvider)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		keySet := provider.KeySet()
+		if len(keySet.Keys) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// verifiers may cache the keys, a new signing key has to be published
+		// this long before it is used
+		w.Header().Set("Cache-Control", "public, max-age=300")
+
+		err := json.NewEncoder(w).Encode(keySet)
+		if err != nil {
+			log.Error().Err(err).Msg("write jwks")
+		}
+	})
+}
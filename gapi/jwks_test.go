@@ -0,0 +1,79 @@
+package gapi
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwtMaker, err := token.NewAsymmetricJWT(privateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		method        string
+		tokenMaker    func(t *testing.T, server *Server) token.Tokener
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "success",
+			method: http.MethodGet,
+			tokenMaker: func(t *testing.T, server *Server) token.Tokener {
+				return jwtMaker
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+				var keySet token.JWKS
+				err := json.Unmarshal(recorder.Body.Bytes(), &keySet)
+				require.NoError(t, err)
+				require.Equal(t, jwtMaker.KeySet(), keySet)
+			},
+		},
+		{
+			name:   "symmetric token maker",
+			method: http.MethodGet,
+			tokenMaker: func(t *testing.T, server *Server) token.Tokener {
+				return server.tokenMaker
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "method not allowed",
+			method: http.MethodPost,
+			tokenMaker: func(t *testing.T, server *Server) token.Tokener {
+				return jwtMaker
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fake.FakeStore{}, nil)
+			server.tokenMaker = tc.tokenMaker(t, server)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/.well-known/jwks.json", nil)
+
+			server.JWKSHandler().ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/milhamh95/simplebank/limiter"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type loginLimit struct {
+	key    string
+	policy limiter.Policy
+}
+
+// loginLimits returns the limits of a login attempt, one for the username and one for the client ip.
+// Either can be empty when it isn't known yet
+func loginLimits(username string, clientIP string) []loginLimit {
+	var limits []loginLimit
+	if username != "" {
+		limits = append(limits, loginLimit{key: limiter.UserKey(username), policy: limiter.UserPolicy})
+	}
+
+	if clientIP != "" {
+		limits = append(limits, loginLimit{key: limiter.IPKey(clientIP), policy: limiter.IPPolicy})
+	}
+
+	return limits
+}
+
+// checkLoginLimits rejects the request while the username or the client ip is blocked.
+// It doesn't count an attempt, use reserveLoginAttempt before checking credentials
+func (s *Server) checkLoginLimits(ctx context.Context, limits []loginLimit) error {
+	var wait time.Duration
+	for _, limit := range limits {
+		keyWait, err := s.loginLimiter.Wait(ctx, limit.key)
+		if err != nil {
+			return status.Errorf(codes.Internal, "check login limiter: %s", err.Error())
+		}
+
+		if keyWait > wait {
+			wait = keyWait
+		}
+	}
+
+	if wait > 0 {
+		return status.Errorf(
+			codes.ResourceExhausted,
+			"too many failed login attempts, try again in %s", wait.Round(time.Second),
+		)
+	}
+
+	return nil
+}
+
+// reserveLoginAttempt counts the attempt against every limit before the credentials are checked,
+// so concurrent attempts can't all pass the check before their failures are recorded.
+// It rejects the attempt while the username or the client ip is blocked.
+// The attempt has to be failed or refunded once the credentials are checked
+func (s *Server) reserveLoginAttempt(ctx context.Context, limits []loginLimit) error {
+	var wait time.Duration
+	var reserved []loginLimit
+	for _, limit := range limits {
+		keyWait, err := s.loginLimiter.Reserve(ctx, limit.key, limit.policy)
+		if err != nil {
+			s.refundLoginAttempt(ctx, reserved)
+			return status.Errorf(codes.Internal, "reserve login attempt: %s", err.Error())
+		}
+
+		if keyWait == 0 {
+			reserved = append(reserved, limit)
+		}
+
+		if keyWait > wait {
+			wait = keyWait
+		}
+	}
+
+	if wait > 0 {
+		// the credentials aren't checked, the attempt doesn't count for the other limits
+		s.refundLoginAttempt(ctx, reserved)
+
+		return status.Errorf(
+			codes.ResourceExhausted,
+			"too many failed login attempts, try again in %s", wait.Round(time.Second),
+		)
+	}
+
+	return nil
+}
+
+// failLogin blocks every limit for the backoff of its failed attempts
+func (s *Server) failLogin(ctx context.Context, limits []loginLimit) {
+	for _, limit := range limits {
+		_, err := s.loginLimiter.Fail(ctx, limit.key, limit.policy)
+		if err != nil {
+			log.Error().Err(err).Str("key", limit.key).Msg("record failed login")
+		}
+	}
+}
+
+// refundLoginAttempt gives back the reserved attempt after the credentials were right,
+// or when they couldn't be checked
+func (s *Server) refundLoginAttempt(ctx context.Context, limits []loginLimit) {
+	for _, limit := range limits {
+		err := s.loginLimiter.Refund(ctx, limit.key)
+		if err != nil {
+			log.Error().Err(err).Str("key", limit.key).Msg("refund login attempt")
+		}
+	}
+}
+
+// resetLoginLimits forgets the failed attempts of the user after a successful login.
+// The client ip is kept, otherwise an attacker could reset it with their own account
+func (s *Server) resetLoginLimits(ctx context.Context, username string) {
+	err := s.loginLimiter.Reset(ctx, limiter.UserKey(username))
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("reset login limiter")
+	}
+}
+
+// checkDummyPassword takes as long as checking a real password.
+// It is used for unknown users, so their response time doesn't differ from a wrong password
+func (s *Server) checkDummyPassword(pwd string) {
+	s.dummyHashedPasswordOnce.Do(func() {
+		s.dummyHashedPassword, _ = s.passwordHasher.HashPassword("dummy password")
+	})
+
+	_ = s.passwordHasher.CheckPassword(pwd, s.dummyHashedPassword)
+}
@@ -0,0 +1,90 @@
+package gapi
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// loginLinkPage reads the link from its own query and posts it to
+// ConsumeLoginLink when the user clicks the button. The post is relative to
+// the page, so it works when the gateway is served under a path prefix
+const loginLinkPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Log in to Simple Bank</title>
+</head>
+<body>
+<form id="login-link" method="post" action="v1/consume_login_link">
+<p>Click the button to log in to Simple Bank.</p>
+<button type="submit">Log in</button>
+</form>
+<p id="result" role="status"></p>
+<script>
+const form = document.getElementById("login-link");
+const result = document.getElementById("result");
+
+form.addEventListener("submit", async (event) => {
+	event.preventDefault();
+	form.querySelector("button").disabled = true;
+
+	const query = new URLSearchParams(window.location.search);
+	try {
+		const response = await fetch(form.getAttribute("action"), {
+			method: "POST",
+			headers: {"Content-Type": "application/json"},
+			body: JSON.stringify({
+				link_id: query.get("link_id"),
+				secret_code: query.get("secret_code"),
+			}),
+		});
+		const body = await response.json();
+		if (!response.ok) {
+			result.textContent = body.message || "The login link is invalid or has expired.";
+			return;
+		}
+
+		sessionStorage.setItem("simplebank_login", JSON.stringify(body));
+		result.textContent = body.mfa_required
+			? "Enter the code of your authenticator app to finish logging in."
+			: "You are logged in.";
+	} catch (err) {
+		result.textContent = "Cannot log in, please try again.";
+		form.querySelector("button").disabled = false;
+	}
+});
+</script>
+</body>
+</html>
+`
+
+// LoginLinkHandler serves the page the login link emails point to.
+// Opening the page doesn't use the link up, only its button does
+func (s *Server) LoginLinkHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		// the secret code is in the url, keep it out of caches and referers
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+		w.Header().Set("Content-Security-Policy",
+			"default-src 'none'; script-src 'unsafe-inline'; connect-src 'self'; form-action 'self'")
+
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		_, err := io.WriteString(w, loginLinkPage)
+		if err != nil {
+			log.Error().Err(err).Msg("write login link page")
+		}
+	})
+}
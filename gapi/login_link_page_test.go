@@ -0,0 +1,78 @@
+package gapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	"github.com/milhamh95/simplebank/mail"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/worker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginLinkHandler(t *testing.T) {
+	renderer, err := mail.NewRenderer("https://bank.example.com")
+	require.NoError(t, err)
+
+	loginURL := worker.LoginLinkURL(renderer, random.RandomInt(1, 1000), random.RandomString(32))
+
+	testCases := []struct {
+		name          string
+		method        string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "success",
+			method: http.MethodGet,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+				require.Equal(t, "no-referrer", recorder.Header().Get("Referrer-Policy"))
+				require.Contains(t, recorder.Body.String(), `action="v1/consume_login_link"`)
+
+				// the form posts relative to the page the email links to
+				page, err := url.Parse(loginURL)
+				require.NoError(t, err)
+				action := page.ResolveReference(&url.URL{Path: "v1/consume_login_link"})
+				require.Equal(t, "/v1/consume_login_link", action.Path)
+			},
+		},
+		{
+			name:   "head",
+			method: http.MethodHead,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Empty(t, recorder.Body.String())
+			},
+		},
+		{
+			name:   "method not allowed",
+			method: http.MethodPost,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+				require.Equal(t, "GET, HEAD", recorder.Header().Get("Allow"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, &fake.FakeStore{}, nil)
+
+			// routes the page like the gateway mux of main
+			mux := http.NewServeMux()
+			mux.Handle("/", http.NotFoundHandler())
+			mux.Handle(worker.LoginLinkPath, server.LoginLinkHandler())
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, loginURL, nil)
+
+			mux.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -1,23 +1,46 @@
 package gapi
 
 import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
 	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/denylist"
+	"github.com/milhamh95/simplebank/limiter"
 	"github.com/milhamh95/simplebank/pkg/config"
 	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/token"
 	"github.com/milhamh95/simplebank/worker"
 	"github.com/stretchr/testify/require"
-	"testing"
-	"time"
+	"google.golang.org/grpc/metadata"
 )
 
 func newTestServer(t *testing.T, store db.Store, taskDistributor worker.TaskDistributor) *Server {
 	cfg := config.Config{
-		TokenSymmetricKey:   random.RandomString(32),
-		AccessTokenDuration: time.Minute,
+		TokenSymmetricKey:    random.RandomString(32),
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Hour,
+		TOTPEncryptionKey:    random.RandomString(32),
 	}
 
-	server, err := NewServer(cfg, store, taskDistributor)
+	server, err := NewServer(cfg, store, taskDistributor, limiter.NewMemoryLoginLimiter(), denylist.NewMemoryDenylist())
 	require.NoError(t, err)
 
 	return server
 }
+
+func newContextWithBearerToken(t *testing.T, tokenMaker token.Tokener, username string, userRole string, duration time.Duration) context.Context {
+	accessToken, _, err := tokenMaker.CreateToken(username, userRole, token.TokenTypeAccess, duration)
+	require.NoError(t, err)
+
+	bearerToken := fmt.Sprintf("%s %s", authorizationBearer, accessToken)
+	md := metadata.MD{
+		authorizationHeader: []string{
+			bearerToken,
+		},
+	}
+
+	return metadata.NewIncomingContext(context.Background(), md)
+}
@@ -3,6 +3,9 @@ package gapi
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
+	"strings"
 
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
@@ -17,6 +20,8 @@ const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	xForwardedForHeader        = "x-forwarded-for"
 	userAgentHeader            = "user-agent"
+	grpcGatewayLanguageHeader  = "grpcgateway-accept-language"
+	acceptLanguageHeader       = "accept-language"
 )
 
 func (s *Server) extractMetadata(ctx context.Context) (*Metadata, error) {
@@ -33,22 +38,128 @@ func (s *Server) extractMetadata(ctx context.Context) (*Metadata, error) {
 	}
 
 	httpUserAgent := reqMetadata.Get(userAgentHeader)
-	if len(userAgentHeader) > 0 {
+	if len(httpUserAgent) > 0 {
 		loginMetadata.UserAgent = httpUserAgent[0]
 	}
 
-	clientIP := reqMetadata.Get(xForwardedForHeader)
-	if len(clientIP) > 0 {
-		loginMetadata.ClientIP = clientIP[0]
+	var forwardedFor []string
+	for _, value := range reqMetadata.Get(xForwardedForHeader) {
+		for _, hop := range strings.Split(value, ",") {
+			hop = strings.TrimSpace(hop)
+			if hop != "" {
+				forwardedFor = append(forwardedFor, hop)
+			}
+		}
 	}
 
 	// peer info  contains the information of the peer for an RPC, such as the address
 	// and authentication information.
+	// Calls through the HTTP gateway have no peer, the gateway appends
+	// the address of the HTTP client to x-forwarded-for instead
+	var remoteIP string
 	peerInfo, ok := peer.FromContext(ctx)
-	if !ok {
-		return nil, errors.New("rpc peer info is not found")
+	if ok {
+		remoteIP = hostIP(peerInfo.Addr.String())
+	} else if n := len(forwardedFor); n > 0 {
+		remoteIP = hostIP(forwardedFor[n-1])
+		forwardedFor = forwardedFor[:n-1]
 	}
-	loginMetadata.ClientIP = peerInfo.Addr.String()
+
+	loginMetadata.ClientIP = s.clientIP(remoteIP, forwardedFor)
 
 	return &loginMetadata, nil
 }
+
+// clientIP returns the right-most address that isn't a trusted proxy, starting from the address
+// the request came from. Every hop of x-forwarded-for left of it can be forged by the client
+func (s *Server) clientIP(remoteIP string, forwardedFor []string) string {
+	ip := remoteIP
+	for i := len(forwardedFor) - 1; i >= 0 && s.isTrustedProxy(ip); i-- {
+		ip = hostIP(forwardedFor[i])
+	}
+
+	return ip
+}
+
+func (s *Server) isTrustedProxy(ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+
+	for _, proxy := range s.trustedProxies {
+		if proxy.Contains(parsedIP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hostIP removes the port from an address, so every connection of a client has the same ip
+func hostIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = strings.Trim(addr, "[]")
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+
+	return host
+}
+
+// parseTrustedProxies parses ips and CIDR ranges, e.g. 10.0.0.1 or 10.0.0.0/8
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	var trustedProxies []*net.IPNet
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+
+			trustedProxies = append(trustedProxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+
+	return trustedProxies, nil
+}
+
+// requestLocale returns the Accept-Language of the request, empty when it has none.
+// The emails of the tasks the request starts are rendered in it
+func requestLocale(ctx context.Context) string {
+	reqMetadata, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, header := range []string{acceptLanguageHeader, grpcGatewayLanguageHeader} {
+		values := reqMetadata.Get(header)
+		if len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
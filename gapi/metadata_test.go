@@ -0,0 +1,100 @@
+package gapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestExtractMetadataClientIP(t *testing.T) {
+	trustedProxies, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		peerAddr     string
+		forwardedFor []string
+		clientIP     string
+	}{
+		{
+			name:     "peer without port",
+			peerAddr: "203.0.113.7:51234",
+			clientIP: "203.0.113.7",
+		},
+		{
+			name:     "ipv6 peer without port",
+			peerAddr: "[2001:db8::1]:51234",
+			clientIP: "2001:db8::1",
+		},
+		{
+			name:         "untrusted peer ignores x-forwarded-for",
+			peerAddr:     "203.0.113.7:51234",
+			forwardedFor: []string{"198.51.100.1"},
+			clientIP:     "203.0.113.7",
+		},
+		{
+			name:         "trusted proxy peer",
+			peerAddr:     "10.1.2.3:51234",
+			forwardedFor: []string{"1.1.1.1, 198.51.100.1"},
+			clientIP:     "198.51.100.1",
+		},
+		{
+			name:         "chain of trusted proxies",
+			peerAddr:     "10.1.2.3:51234",
+			forwardedFor: []string{"1.1.1.1, 198.51.100.1, 192.168.1.1"},
+			clientIP:     "198.51.100.1",
+		},
+		{
+			name:         "gateway appends the http client",
+			forwardedFor: []string{"1.1.1.1, 198.51.100.1"},
+			clientIP:     "198.51.100.1",
+		},
+		{
+			name:         "gateway behind a trusted proxy",
+			forwardedFor: []string{"1.1.1.1, 198.51.100.1, 10.0.0.5"},
+			clientIP:     "198.51.100.1",
+		},
+		{
+			name:     "unknown",
+			clientIP: "",
+		},
+	}
+
+	server := &Server{trustedProxies: trustedProxies}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			md := metadata.MD{}
+			for _, value := range tc.forwardedFor {
+				md.Append(xForwardedForHeader, value)
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			if tc.peerAddr != "" {
+				addr, err := net.ResolveTCPAddr("tcp", tc.peerAddr)
+				require.NoError(t, err)
+				ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+			}
+
+			loginMetadata, err := server.extractMetadata(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tc.clientIP, loginMetadata.ClientIP)
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	_, err := parseTrustedProxies([]string{"not an ip"})
+	require.Error(t, err)
+
+	_, err = parseTrustedProxies([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+
+	proxies, err := parseTrustedProxies([]string{"", " 10.0.0.1 "})
+	require.NoError(t, err)
+	require.Len(t, proxies, 1)
+}
@@ -0,0 +1,24 @@
+package gapi
+
+import (
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// validateUserPassword checks that the password doesn't contain the username or the stored
+// email of the user. The email isn't part of every request, so it is checked once the user is loaded
+func (s *Server) validateUserPassword(field string, password string, user db.User) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, err := range s.passwordPolicy.Validate(password, user.Username, user.Email) {
+		violations = append(
+			violations,
+			fieldViolation(field, err),
+		)
+	}
+
+	if violations != nil {
+		return invalidArgumentError(violations)
+	}
+
+	return nil
+}
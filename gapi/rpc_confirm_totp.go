@@ -0,0 +1,114 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/totp"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConfirmTOTP enables TOTP once the user proves the enrolled secret works.
+// The recovery codes are returned only here, the database keeps their hashes
+func (s *Server) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateConfirmTOTPRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := s.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "find user: %s", err.Error())
+	}
+
+	if user.TotpEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "totp is already enabled")
+	}
+
+	if user.TotpSecret == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "totp is not enrolled")
+	}
+
+	secret, err := s.totpEncryptor.Decrypt(user.TotpSecret)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decrypt totp secret: %s", err.Error())
+	}
+
+	// codes are guessed against the same limits as a login
+	limits := loginLimits(user.Username, "")
+	err = s.reserveLoginAttempt(ctx, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := totp.ValidateStep(req.GetCode(), secret, time.Now())
+	if !ok {
+		s.failLogin(ctx, limits)
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect code")
+	}
+
+	err = s.useTOTPStep(ctx, user.Username, step)
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			s.failLogin(ctx, limits)
+		} else {
+			s.refundLoginAttempt(ctx, limits)
+		}
+
+		return nil, err
+	}
+
+	s.refundLoginAttempt(ctx, limits)
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes(totp.RecoveryCodeCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate recovery codes: %s", err.Error())
+	}
+
+	hashedRecoveryCodes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashedRecoveryCodes = append(hashedRecoveryCodes, totp.HashRecoveryCode(code))
+	}
+
+	_, err = s.store.EnableTOTPTx(ctx, db.EnableTOTPTxParams{
+		Username:            user.Username,
+		HashedRecoveryCodes: hashedRecoveryCodes,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "enable totp: %s", err.Error())
+	}
+
+	rsp := &pb.ConfirmTOTPResponse{
+		RecoveryCodes: recoveryCodes,
+	}
+
+	return rsp, nil
+}
+
+func validateConfirmTOTPRequest(req *pb.ConfirmTOTPRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateTOTPCode(req.GetCode())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("code", err),
+		)
+	}
+
+	return violations
+}
@@ -0,0 +1,177 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/limiter"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/totp"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestConfirmTOTPAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	otherSecret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	// enrolledUser has a secret, but TOTP is not enabled yet
+	enrolledUser := func(t *testing.T, server *Server) db.User {
+		u := totpUser(t, server, user, secret)
+		u.TotpEnabled = false
+		return u
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ConfirmTOTPRequest
+		buildStubs    func(t *testing.T, store *fake.FakeStore, server *Server)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.ConfirmTOTPResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.ConfirmTOTPRequest{
+				Code: totpCode(t, secret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(enrolledUser(t, server), nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConfirmTOTPResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, resp.GetRecoveryCodes(), totp.RecoveryCodeCount)
+
+				require.Equal(t, 1, store.UseTOTPStepCallCount())
+				_, stepArg := store.UseTOTPStepArgsForCall(0)
+				require.Equal(t, user.Username, stepArg.Username)
+
+				require.Equal(t, 1, store.EnableTOTPTxCallCount())
+				_, arg := store.EnableTOTPTxArgsForCall(0)
+				require.Equal(t, user.Username, arg.Username)
+				require.Len(t, arg.HashedRecoveryCodes, totp.RecoveryCodeCount)
+				for i, code := range resp.GetRecoveryCodes() {
+					require.Equal(t, totp.HashRecoveryCode(code), arg.HashedRecoveryCodes[i])
+				}
+			},
+		},
+		{
+			name: "incorrect code",
+			req: &pb.ConfirmTOTPRequest{
+				Code: totpCode(t, otherSecret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(enrolledUser(t, server), nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConfirmTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.EnableTOTPTxCallCount())
+			},
+		},
+		{
+			name: "reused code",
+			req: &pb.ConfirmTOTPRequest{
+				Code: totpCode(t, secret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(enrolledUser(t, server), nil)
+				store.UseTOTPStepReturns(0, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConfirmTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.EnableTOTPTxCallCount())
+			},
+		},
+		{
+			name: "locked out",
+			req: &pb.ConfirmTOTPRequest{
+				Code: totpCode(t, secret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(enrolledUser(t, server), nil)
+				failLoginAttempts(t, server, user.Username, limiter.UserPolicy.LockoutAttempts)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConfirmTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.ResourceExhausted)
+				require.Equal(t, 0, store.UseTOTPStepCallCount())
+				require.Equal(t, 0, store.EnableTOTPTxCallCount())
+			},
+		},
+		{
+			name: "not enrolled",
+			req: &pb.ConfirmTOTPRequest{
+				Code: totpCode(t, secret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(user, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConfirmTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "already enabled",
+			req: &pb.ConfirmTOTPRequest{
+				Code: totpCode(t, secret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(totpUser(t, server, user, secret), nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConfirmTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+				require.Equal(t, 0, store.EnableTOTPTxCallCount())
+			},
+		},
+		{
+			name: "invalid code",
+			req: &pb.ConfirmTOTPRequest{
+				Code: "12ab56",
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConfirmTOTPResponse, err error) {
+				requireFieldViolations(t, err, "code")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			fakeStore.UseTOTPStepReturns(1, nil)
+			server := newTestServer(t, fakeStore, nil)
+			tc.buildStubs(t, fakeStore, server)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.ConfirmTOTP(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConsumeLoginLink logs in with an emailed login link, the link can be used once.
+// Users with TOTP enabled still get a mfa token for VerifyLoginMFA instead of the session
+func (s *Server) ConsumeLoginLink(ctx context.Context, req *pb.ConsumeLoginLinkRequest) (*pb.ConsumeLoginLinkResponse, error) {
+	violations := validateConsumeLoginLinkRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	loginMetadata, err := s.extractMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the user isn't known before the link is checked
+	limits := loginLimits("", loginMetadata.ClientIP)
+	err = s.reserveLoginAttempt(ctx, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	loginLink, err := s.store.UpdateLoginLink(ctx, db.UpdateLoginLinkParams{
+		ID:               req.GetLinkId(),
+		HashedSecretCode: db.HashLoginLinkSecret(req.GetSecretCode()),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.failLogin(ctx, limits)
+			return nil, status.Errorf(codes.Unauthenticated, "login link is invalid, used or expired")
+		}
+
+		s.refundLoginAttempt(ctx, limits)
+		return nil, status.Errorf(codes.Internal, "consume login link: %s", err.Error())
+	}
+
+	s.refundLoginAttempt(ctx, limits)
+
+	user, err := s.store.GetUser(ctx, loginLink.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "find user: %s", err.Error())
+	}
+
+	// a locked out user can't get around the lockout with a link
+	err = s.checkLoginLimits(ctx, loginLimits(user.Username, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cfg.RequireVerifiedEmail && !user.IsEmailVerified {
+		return nil, status.Errorf(codes.FailedPrecondition, "email address is not verified")
+	}
+
+	var loginResp *pb.LoginUserResponse
+	if user.TotpEnabled {
+		loginResp, err = s.createMFAChallenge(user)
+	} else {
+		s.resetLoginLimits(ctx, user.Username)
+		loginResp, err = s.createLoginSession(ctx, user)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ConsumeLoginLinkResponse{
+		User:                  loginResp.GetUser(),
+		SessionId:             loginResp.GetSessionId(),
+		AccessToken:           loginResp.GetAccessToken(),
+		AccessTokenExpiredAt:  loginResp.GetAccessTokenExpiredAt(),
+		RefreshToken:          loginResp.GetRefreshToken(),
+		RefreshTokenExpiredAt: loginResp.GetRefreshTokenExpiredAt(),
+		MfaRequired:           loginResp.GetMfaRequired(),
+		MfaToken:              loginResp.GetMfaToken(),
+		MfaTokenExpiredAt:     loginResp.GetMfaTokenExpiredAt(),
+	}
+
+	return resp, nil
+}
+
+func validateConsumeLoginLinkRequest(req *pb.ConsumeLoginLinkRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateLoginLinkID(req.GetLinkId())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("link_id", err),
+		)
+	}
+
+	err = validator.ValidateSecretCode(req.GetSecretCode())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("secret_code", err),
+		)
+	}
+
+	return violations
+}
@@ -0,0 +1,139 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/limiter"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestConsumeLoginLinkAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	secretCode := random.RandomString(32)
+	loginLink := db.LoginLink{
+		ID:               1,
+		Username:         user.Username,
+		HashedSecretCode: db.HashLoginLinkSecret(secretCode),
+	}
+	req := &pb.ConsumeLoginLinkRequest{
+		LinkId:     loginLink.ID,
+		SecretCode: secretCode,
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ConsumeLoginLinkRequest
+		buildStubs    func(t *testing.T, store *fake.FakeStore, server *Server)
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.ConsumeLoginLinkResponse, err error)
+	}{
+		{
+			name: "success",
+			req:  req,
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.UpdateLoginLinkReturns(loginLink, nil)
+				store.GetUserReturns(user, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConsumeLoginLinkResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, resp.GetMfaRequired())
+				require.NotEmpty(t, resp.GetAccessToken())
+				require.NotEmpty(t, resp.GetRefreshToken())
+				require.Equal(t, user.Username, resp.GetUser().GetUsername())
+
+				_, arg := store.UpdateLoginLinkArgsForCall(0)
+				require.Equal(t, loginLink.ID, arg.ID)
+				require.Equal(t, loginLink.HashedSecretCode, arg.HashedSecretCode)
+
+				require.Equal(t, 1, store.CreateSessionCallCount())
+				_, sessionArg := store.CreateSessionArgsForCall(0)
+				require.Equal(t, "test-agent", sessionArg.UserAgent)
+			},
+		},
+		{
+			name: "mfa required",
+			req:  req,
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.UpdateLoginLinkReturns(loginLink, nil)
+				store.GetUserReturns(totpUser(t, server, user, "JBSWY3DPEHPK3PXP"), nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConsumeLoginLinkResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, resp.GetMfaRequired())
+				require.NotEmpty(t, resp.GetMfaToken())
+				require.Empty(t, resp.GetAccessToken())
+				require.Equal(t, 0, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "invalid, used or expired link",
+			req:  req,
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.UpdateLoginLinkReturns(db.LoginLink{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConsumeLoginLinkResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.GetUserCallCount())
+			},
+		},
+		{
+			name: "locked out user",
+			req:  req,
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.UpdateLoginLinkReturns(loginLink, nil)
+				store.GetUserReturns(user, nil)
+				failLoginAttempts(t, server, user.Username, limiter.UserPolicy.LockoutAttempts)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConsumeLoginLinkResponse, err error) {
+				requireStatusCode(t, err, codes.ResourceExhausted)
+				require.Equal(t, 0, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "internal error",
+			req:  req,
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.UpdateLoginLinkReturns(db.LoginLink{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConsumeLoginLinkResponse, err error) {
+				requireStatusCode(t, err, codes.Internal)
+			},
+		},
+		{
+			name: "invalid request",
+			req: &pb.ConsumeLoginLinkRequest{
+				LinkId:     0,
+				SecretCode: "short",
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ConsumeLoginLinkResponse, err error) {
+				requireFieldViolations(t, err, "link_id", "secret_code")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			fakeStore.CreateSessionStub = func(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+				return db.Session{ID: arg.ID, Username: arg.Username}, nil
+			}
+
+			server := newTestServer(t, fakeStore, nil)
+			tc.buildStubs(t, fakeStore, server)
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+				userAgentHeader: []string{"test-agent"},
+			})
+			res, err := server.ConsumeLoginLink(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateAccountAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateAccountRequest
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.CreateAccountResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.CreateAccountRequest{
+				Currency: account.Currency,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.CreateAccountReturns(account, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateAccountResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+
+				require.Equal(t, 1, store.CreateAccountCallCount())
+				_, arg := store.CreateAccountArgsForCall(0)
+				require.Equal(t, user.Username, arg.Owner)
+				require.Equal(t, account.Currency, arg.Currency)
+				require.Zero(t, arg.Balance)
+
+				createdAccount := resp.GetAccount()
+				require.Equal(t, account.ID, createdAccount.Id)
+				require.Equal(t, account.Owner, createdAccount.Owner)
+				require.Equal(t, account.Currency, createdAccount.Currency)
+			},
+		},
+		{
+			name: "no authorization",
+			req: &pb.CreateAccountRequest{
+				Currency: account.Currency,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateAccountResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.CreateAccountCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "invalid currency",
+			req: &pb.CreateAccountRequest{
+				Currency: "XYZ",
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateAccountResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.CreateAccountCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "duplicate currency",
+			req: &pb.CreateAccountRequest{
+				Currency: account.Currency,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.CreateAccountReturns(db.Account{}, &pq.Error{Code: "23505"})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateAccountResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.AlreadyExists, st.Code())
+			},
+		},
+		{
+			name: "internal error",
+			req: &pb.CreateAccountRequest{
+				Currency: account.Currency,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.CreateAccountReturns(db.Account{}, sql.ErrConnDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateAccountResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.CreateAccount(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
+
+func randomAccount(owner string) db.Account {
+	return db.Account{
+		ID:       random.RandomInt(1, 1000),
+		Owner:    owner,
+		Balance:  random.RandomMoney(),
+		Currency: random.RandomCurrency(),
+	}
+}
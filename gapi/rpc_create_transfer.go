@@ -0,0 +1,145 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := s.validAccount(ctx, "from_account_id", req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "from account doesn't belong to the authenticated user")
+	}
+
+	_, err = s.validAccount(ctx, "to_account_id", req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	txResult, err := s.store.TransferTrx(ctx, db.TransferTxParams{
+		FromAccountID:  req.GetFromAccountId(),
+		ToAccountID:    req.GetToAccountId(),
+		Amount:         req.GetAmount(),
+		Username:       authPayload.Username,
+		IdempotencyKey: req.GetIdempotencyKey(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account [%d] has insufficient funds", req.GetFromAccountId())
+		}
+
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			return nil, status.Errorf(codes.AlreadyExists, "%s", err.Error())
+		}
+
+		return nil, status.Errorf(codes.Internal, "transfer: %s", err.Error())
+	}
+
+	rsp := &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(txResult.Transfer),
+		FromAccount: convertAccount(txResult.FromAccount),
+		ToAccount:   convertAccount(txResult.ToAccount),
+		FromEntry:   convertEntry(txResult.FromEntry),
+		ToEntry:     convertEntry(txResult.ToEntry),
+	}
+
+	return rsp, nil
+}
+
+// validAccount returns the account if it exists and uses the given currency.
+// field is the request field holding accountID, used to report a violation.
+func (s *Server) validAccount(ctx context.Context, field string, accountID int64, currency string) (db.Account, error) {
+	account, err := s.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+
+		return account, status.Errorf(codes.Internal, "get account: %s", err.Error())
+	}
+
+	if account.Currency != currency {
+		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+		return account, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation(field, err),
+		})
+	}
+
+	return account, nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateAccountID(req.GetFromAccountId())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("from_account_id", err),
+		)
+	}
+
+	err = validator.ValidateAccountID(req.GetToAccountId())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("to_account_id", err),
+		)
+	}
+
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(
+			violations,
+			fieldViolation("to_account_id", errors.New("must be different from from_account_id")),
+		)
+	}
+
+	err = validator.ValidateAmount(req.GetAmount())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("amount", err),
+		)
+	}
+
+	err = validator.ValidateCurrency(req.GetCurrency())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("currency", err),
+		)
+	}
+
+	if req.GetIdempotencyKey() != "" {
+		err = validator.ValidateIdempotencyKey(req.GetIdempotencyKey())
+		if err != nil {
+			violations = append(
+				violations,
+				fieldViolation("idempotency_key", err),
+			)
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,345 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/currency"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateTransferAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	user3, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account3 := randomAccount(user3.Username)
+
+	account1.Currency = currency.USD
+	account2.Currency = currency.USD
+	account3.Currency = currency.EUR
+	account2.ID = account1.ID + 1
+	account3.ID = account1.ID + 2
+
+	amount := int64(10)
+	idempotencyKey := random.RandomString(32)
+
+	getAccountStub := func(ctx context.Context, id int64) (db.Account, error) {
+		for _, account := range []db.Account{account1, account2, account3} {
+			if account.ID == id {
+				return account, nil
+			}
+		}
+
+		return db.Account{}, sql.ErrNoRows
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateTransferRequest
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountStub = getAccountStub
+				store.TransferTrxReturns(db.TransferTxResult{
+					Transfer: db.Transfer{
+						ID:            1,
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+					},
+					FromAccount: account1,
+					ToAccount:   account2,
+				}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+
+				require.Equal(t, 1, store.TransferTrxCallCount())
+				_, arg := store.TransferTrxArgsForCall(0)
+				require.Equal(t, account1.ID, arg.FromAccountID)
+				require.Equal(t, account2.ID, arg.ToAccountID)
+				require.Equal(t, amount, arg.Amount)
+
+				require.Equal(t, account1.ID, resp.GetTransfer().GetFromAccountId())
+				require.Equal(t, account2.ID, resp.GetTransfer().GetToAccountId())
+				require.Equal(t, amount, resp.GetTransfer().GetAmount())
+			},
+		},
+		{
+			name: "no authorization",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.TransferTrxCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "unauthorized user",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountStub = getAccountStub
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, user2.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.TransferTrxCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "from account not found",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(db.Account{}, sql.ErrNoRows)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.TransferTrxCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "to account currency mismatch",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account3.ID,
+				Amount:        amount,
+				Currency:      currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountStub = getAccountStub
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.TransferTrxCallCount())
+				requireFieldViolations(t, err, "to_account_id")
+			},
+		},
+		{
+			name: "self transfer",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account1.ID,
+				Amount:        amount,
+				Currency:      currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.GetAccountCallCount())
+				requireFieldViolations(t, err, "to_account_id")
+			},
+		},
+		{
+			name: "negative amount and invalid currency",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        -amount,
+				Currency:      "XYZ",
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.GetAccountCallCount())
+				requireFieldViolations(t, err, "amount", "currency")
+			},
+		},
+		{
+			name: "insufficient funds",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountStub = getAccountStub
+				store.TransferTrxReturns(db.TransferTxResult{}, db.ErrInsufficientFunds)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "idempotency key conflict",
+			req: &pb.CreateTransferRequest{
+				FromAccountId:  account1.ID,
+				ToAccountId:    account2.ID,
+				Amount:         amount,
+				Currency:       currency.USD,
+				IdempotencyKey: idempotencyKey,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountStub = getAccountStub
+				store.TransferTrxReturns(db.TransferTxResult{}, db.ErrIdempotencyKeyConflict)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+
+				require.Equal(t, 1, store.TransferTrxCallCount())
+				_, arg := store.TransferTrxArgsForCall(0)
+				require.Equal(t, user1.Username, arg.Username)
+				require.Equal(t, idempotencyKey, arg.IdempotencyKey)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.AlreadyExists, st.Code())
+			},
+		},
+		{
+			name: "invalid idempotency key",
+			req: &pb.CreateTransferRequest{
+				FromAccountId:  account1.ID,
+				ToAccountId:    account2.ID,
+				Amount:         amount,
+				Currency:       currency.USD,
+				IdempotencyKey: "abc",
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.TransferTrxCallCount())
+				requireFieldViolations(t, err, "idempotency_key")
+			},
+		},
+		{
+			name: "transfer error",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        amount,
+				Currency:      currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountStub = getAccountStub
+				store.TransferTrxReturns(db.TransferTxResult{}, sql.ErrTxDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.CreateTransfer(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
+
+func requireFieldViolations(t *testing.T, err error, fields ...string) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	var gotFields []string
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, violation := range badRequest.GetFieldViolations() {
+			gotFields = append(gotFields, violation.GetField())
+		}
+	}
+
+	require.ElementsMatch(t, fields, gotFields)
+}
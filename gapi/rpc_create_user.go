@@ -2,28 +2,24 @@ package gapi
 
 import (
 	"context"
-	"github.com/hibiken/asynq"
 	"github.com/milhamh95/simplebank/worker"
-	"time"
 
 	"github.com/lib/pq"
 	db "github.com/milhamh95/simplebank/db/sqlc"
 	"github.com/milhamh95/simplebank/pb"
-	"github.com/milhamh95/simplebank/pkg/password"
 	"github.com/milhamh95/simplebank/pkg/validator"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
-	violations := validateCreateUserRequest(req)
+	violations := validateCreateUserRequest(req, s.passwordPolicy)
 	if violations != nil {
 		return nil, invalidArgumentError(violations)
 	}
 
-	hashedPassword, err := password.HashPassword(req.GetPassword())
+	hashedPassword, err := s.passwordHasher.HashPassword(req.GetPassword())
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
@@ -31,6 +27,15 @@ func (s *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb
 		)
 	}
 
+	// the verify email task is published by the outbox relay once the user is committed
+	verifyEmailMsg, err := worker.NewOutboxMessage(worker.TaskSendVerifyEmail, &worker.PayloadSendVerifyEmail{
+		Username: req.GetUsername(),
+		Locale:   requestLocale(ctx),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create verify email task: %s", err.Error())
+	}
+
 	arg := db.CreateUserTxParams{
 		CreateUserParams: db.CreateUserParams{
 			Username:       req.GetUsername(),
@@ -38,25 +43,7 @@ func (s *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb
 			FullName:       req.GetFullName(),
 			Email:          req.GetEmail(),
 		},
-		AfterCreate: func(user db.User) error {
-			taskPayload := &worker.PayloadSendVerifyEmail{
-				Username: user.Username,
-			}
-			opts := []asynq.Option{
-				asynq.MaxRetry(10),
-				// need to delay send task to redis pub sub
-				// so we can maske sure insert data to database first
-				// then async task is able to get the dat
-				asynq.ProcessIn(10 * time.Second),
-				asynq.Queue(worker.QueueCritical),
-			}
-			err = s.taskDistributor.DistributeTaskSenderVerifyEmail(ctx, taskPayload, opts...)
-			if err != nil {
-				return status.Errorf(codes.Internal, "distribute task to send verify email: %s", err.Error())
-			}
-
-			return nil
-		},
+		OutboxMessages: []db.CreateOutboxMessageParams{verifyEmailMsg},
 	}
 
 	txResult, err := s.store.CreateUserTrx(ctx, arg)
@@ -75,19 +62,13 @@ func (s *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb
 	}
 
 	rsp := &pb.CreateUserResponse{
-		User: &pb.User{
-			Username:          txResult.User.Username,
-			FullName:          txResult.User.FullName,
-			Email:             txResult.User.Email,
-			PasswordChangedAt: timestamppb.New(txResult.User.PasswordChangedAt),
-			CreatedAt:         timestamppb.New(txResult.User.CreatedAt),
-		},
+		User: convertUser(txResult.User),
 	}
 
 	return rsp, nil
 }
 
-func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+func validateCreateUserRequest(req *pb.CreateUserRequest, passwordPolicy *validator.PasswordPolicy) (violations []*errdetails.BadRequest_FieldViolation) {
 	err := validator.ValidateUsername(req.GetUsername())
 	if err != nil {
 		violations = append(
@@ -96,8 +77,7 @@ func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []*errdeta
 		)
 	}
 
-	err = validator.ValidatePassword(req.GetPassword())
-	if err != nil {
+	for _, err := range passwordPolicy.Validate(req.GetPassword(), req.GetUsername(), req.GetEmail()) {
 		violations = append(
 			violations,
 			fieldViolation("password", err),
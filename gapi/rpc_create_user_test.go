@@ -8,6 +8,9 @@ import (
 	"github.com/milhamh95/simplebank/pb"
 	"github.com/milhamh95/simplebank/pkg/password"
 	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"github.com/milhamh95/simplebank/worker"
 	workerFake "github.com/milhamh95/simplebank/worker/fake"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
@@ -44,6 +47,12 @@ func TestCreateUserAPI(t *testing.T) {
 				//}
 
 				store.CreateUserTrxStub = func(ctx context.Context, params db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+					// the verify email task is written to the outbox instead of being enqueued
+					require.Len(t, params.OutboxMessages, 1)
+					require.Equal(t, worker.TaskSendVerifyEmail, params.OutboxMessages[0].TaskType)
+					require.JSONEq(t, `{"username":"`+user.Username+`"}`, string(params.OutboxMessages[0].Payload))
+					require.Equal(t, 0, taskDistributor.DistributeTaskSenderVerifyEmailCallCount())
+
 					return db.CreateUserTxResult{User: user}, nil
 				}
 			},
@@ -93,6 +102,48 @@ func TestCreateUserAPI(t *testing.T) {
 	}
 }
 
+func TestCreateUserPasswordPolicy(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name     string
+		password string
+		fields   []string
+	}{
+		{
+			name:     "every rule is a violation",
+			password: "abc",
+			fields:   []string{"password", "password"},
+		},
+		{
+			name:     "contains username",
+			password: "My-" + user.Username + "-1",
+			fields:   []string{"password"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			server := newTestServer(t, fakeStore, nil)
+			server.passwordPolicy = &validator.PasswordPolicy{
+				MinLength:      8,
+				MaxLength:      100,
+				MinCharClasses: 3,
+			}
+
+			_, err := server.CreateUser(context.Background(), &pb.CreateUserRequest{
+				Username: user.Username,
+				Password: tc.password,
+				FullName: user.FullName,
+				Email:    user.Email,
+			})
+			requireFieldViolations(t, err, tc.fields...)
+			require.Equal(t, 0, fakeStore.CreateUserTrxCallCount())
+		})
+	}
+}
+
 func randomUser(t *testing.T) (user db.User, pwd string) {
 	pwd = random.RandomString(6)
 	hashedPassword, err := password.HashPassword(pwd)
@@ -103,6 +154,7 @@ func randomUser(t *testing.T) (user db.User, pwd string) {
 		HashedPassword: hashedPassword,
 		FullName:       random.RandomOwner(),
 		Email:          random.RandomEmail(),
+		Role:           role.Depositor,
 	}
 	return
 }
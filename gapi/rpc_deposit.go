@@ -0,0 +1,23 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/milhamh95/simplebank/pb"
+)
+
+// Deposit moves cash from the bank vault into an account.
+// Only bankers and admins can handle cash
+func (s *Server) Deposit(ctx context.Context, req *pb.DepositRequest) (*pb.DepositResponse, error) {
+	txResult, err := s.handleCash(ctx, req, s.store.DepositTx)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.DepositResponse{
+		Account: convertAccount(txResult.Account),
+		Entry:   convertEntry(txResult.Entry),
+	}
+
+	return rsp, nil
+}
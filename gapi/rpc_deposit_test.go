@@ -0,0 +1,206 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/currency"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDepositAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+	banker.Role = role.Banker
+	depositor, _ := randomUser(t)
+
+	account := randomAccount(depositor.Username)
+	account.Currency = currency.USD
+	amount := int64(10)
+
+	testCases := []struct {
+		name          string
+		req           *pb.DepositRequest
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.DepositResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.DepositRequest{
+				AccountId:      account.ID,
+				Amount:         amount,
+				Currency:       currency.USD,
+				IdempotencyKey: "deposit-key",
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+
+				depositedAccount := account
+				depositedAccount.Balance += amount
+				store.DepositTxReturns(db.CashTxResult{
+					Account: depositedAccount,
+					Entry: db.Entry{
+						ID:        1,
+						AccountID: account.ID,
+						Amount:    amount,
+					},
+				}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DepositResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+
+				require.Equal(t, 1, store.DepositTxCallCount())
+				_, arg := store.DepositTxArgsForCall(0)
+				require.Equal(t, account.ID, arg.AccountID)
+				require.Equal(t, amount, arg.Amount)
+				require.Equal(t, banker.Username, arg.Username)
+				require.Equal(t, "deposit-key", arg.IdempotencyKey)
+
+				require.Equal(t, account.Balance+amount, resp.GetAccount().GetBalance())
+				require.Equal(t, amount, resp.GetEntry().GetAmount())
+			},
+		},
+		{
+			name: "no authorization",
+			req: &pb.DepositRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.DepositTxCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "depositor can't handle cash",
+			req: &pb.DepositRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, depositor.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.DepositTxCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "invalid request",
+			req: &pb.DepositRequest{
+				AccountId: 0,
+				Amount:    -amount,
+				Currency:  "XYZ",
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.DepositTxCallCount())
+				requireFieldViolations(t, err, "account_id", "amount", "currency")
+			},
+		},
+		{
+			name: "account not found",
+			req: &pb.DepositRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(db.Account{}, sql.ErrNoRows)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.DepositTxCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "vault account",
+			req: &pb.DepositRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+				store.DepositTxReturns(db.CashTxResult{}, db.ErrVaultAccount)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				requireFieldViolations(t, err, "account_id")
+			},
+		},
+		{
+			name: "idempotency key conflict",
+			req: &pb.DepositRequest{
+				AccountId:      account.ID,
+				Amount:         amount,
+				Currency:       currency.USD,
+				IdempotencyKey: "deposit-key",
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+				store.DepositTxReturns(db.CashTxResult{}, db.ErrIdempotencyKeyConflict)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DepositResponse, err error) {
+				requireStatusCode(t, err, codes.AlreadyExists)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.Deposit(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDisableTOTPRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := s.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "find user: %s", err.Error())
+	}
+
+	if !user.TotpEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "totp is not enabled")
+	}
+
+	// without a limit a stolen access token could guess the code and remove MFA
+	limits := loginLimits(user.Username, "")
+	err = s.reserveLoginAttempt(ctx, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkMFACode(ctx, user, req.GetCode())
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			s.failLogin(ctx, limits)
+		} else {
+			s.refundLoginAttempt(ctx, limits)
+		}
+
+		return nil, err
+	}
+
+	s.refundLoginAttempt(ctx, limits)
+
+	_, err = s.store.DisableTOTPTx(ctx, user.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "disable totp: %s", err.Error())
+	}
+
+	return &pb.DisableTOTPResponse{}, nil
+}
+
+func validateDisableTOTPRequest(req *pb.DisableTOTPRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateMFACode(req.GetCode())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("code", err),
+		)
+	}
+
+	return violations
+}
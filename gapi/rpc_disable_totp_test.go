@@ -0,0 +1,146 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/limiter"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/totp"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestDisableTOTPAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	otherSecret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		req           *pb.DisableTOTPRequest
+		buildStubs    func(t *testing.T, store *fake.FakeStore, server *Server)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.DisableTOTPResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.DisableTOTPRequest{
+				Code: totpCode(t, secret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(totpUser(t, server, user, secret), nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DisableTOTPResponse, err error) {
+				require.NoError(t, err)
+
+				require.Equal(t, 1, store.DisableTOTPTxCallCount())
+				_, username := store.DisableTOTPTxArgsForCall(0)
+				require.Equal(t, user.Username, username)
+			},
+		},
+		{
+			name: "incorrect code",
+			req: &pb.DisableTOTPRequest{
+				Code: totpCode(t, otherSecret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(totpUser(t, server, user, secret), nil)
+				store.UseRecoveryCodeReturns(db.RecoveryCode{}, sql.ErrNoRows)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DisableTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.DisableTOTPTxCallCount())
+			},
+		},
+		{
+			name: "reused code",
+			req: &pb.DisableTOTPRequest{
+				Code: totpCode(t, secret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(totpUser(t, server, user, secret), nil)
+				store.UseTOTPStepReturns(0, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DisableTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.DisableTOTPTxCallCount())
+			},
+		},
+		{
+			name: "locked out",
+			req: &pb.DisableTOTPRequest{
+				Code: totpCode(t, secret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(totpUser(t, server, user, secret), nil)
+				failLoginAttempts(t, server, user.Username, limiter.UserPolicy.LockoutAttempts)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DisableTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.ResourceExhausted)
+				require.Equal(t, 0, store.DisableTOTPTxCallCount())
+			},
+		},
+		{
+			name: "not enabled",
+			req: &pb.DisableTOTPRequest{
+				Code: totpCode(t, secret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(user, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DisableTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "unauthenticated",
+			req: &pb.DisableTOTPRequest{
+				Code: totpCode(t, secret),
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.DisableTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			fakeStore.UseTOTPStepReturns(1, nil)
+			server := newTestServer(t, fakeStore, nil)
+			tc.buildStubs(t, fakeStore, server)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.DisableTOTP(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/totp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EnrollTOTP stores a new TOTP secret for the authenticated user.
+// TOTP stays disabled until the secret is confirmed with ConfirmTOTP
+func (s *Server) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	user, err := s.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "find user: %s", err.Error())
+	}
+
+	if user.TotpEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "totp is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate totp secret: %s", err.Error())
+	}
+
+	encryptedSecret, err := s.totpEncryptor.Encrypt(secret)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encrypt totp secret: %s", err.Error())
+	}
+
+	_, err = s.store.UpdateUser(ctx, db.UpdateUserParams{
+		Username: user.Username,
+		TotpSecret: sql.NullString{
+			String: encryptedSecret,
+			Valid:  true,
+		},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "update user: %s", err.Error())
+	}
+
+	rsp := &pb.EnrollTOTPResponse{
+		Secret:          secret,
+		ProvisioningUri: totp.ProvisioningURI(totpIssuer, user.Username, secret),
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,86 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestEnrollTOTPAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(t *testing.T, store *fake.FakeStore, server *Server)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.EnrollTOTPResponse, err error)
+	}{
+		{
+			name: "success",
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(user, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.EnrollTOTPResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, resp.GetSecret())
+				require.Contains(t, resp.GetProvisioningUri(), "secret="+resp.GetSecret())
+
+				require.Equal(t, 1, store.UpdateUserCallCount())
+				_, arg := store.UpdateUserArgsForCall(0)
+				require.Equal(t, user.Username, arg.Username)
+				require.True(t, arg.TotpSecret.Valid)
+				require.False(t, arg.TotpEnabled.Valid)
+
+				// the secret is stored encrypted
+				require.NotEqual(t, resp.GetSecret(), arg.TotpSecret.String)
+				secret, err := server.totpEncryptor.Decrypt(arg.TotpSecret.String)
+				require.NoError(t, err)
+				require.Equal(t, resp.GetSecret(), secret)
+			},
+		},
+		{
+			name: "already enabled",
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(totpUser(t, server, user, "JBSWY3DPEHPK3PXP"), nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.EnrollTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+				require.Equal(t, 0, store.UpdateUserCallCount())
+			},
+		},
+		{
+			name:       "unauthenticated",
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.EnrollTOTPResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			server := newTestServer(t, fakeStore, nil)
+			tc.buildStubs(t, fakeStore, server)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.EnrollTOTP(ctx, &pb.EnrollTOTPRequest{})
+			tc.checkResponse(t, fakeStore, server, res, err)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAccountAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	banker, _ := randomUser(t)
+	banker.Role = role.Banker
+	account := randomAccount(user.Username)
+
+	testCases := []struct {
+		name          string
+		req           *pb.GetAccountRequest
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, resp *pb.GetAccountResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.GetAccountRequest{
+				Id: account.ID,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, resp *pb.GetAccountResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+
+				gotAccount := resp.GetAccount()
+				require.Equal(t, account.ID, gotAccount.Id)
+				require.Equal(t, account.Owner, gotAccount.Owner)
+				require.Equal(t, account.Balance, gotAccount.Balance)
+				require.Equal(t, account.Currency, gotAccount.Currency)
+			},
+		},
+		{
+			name: "expired token",
+			req: &pb.GetAccountRequest{
+				Id: account.ID,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, -time.Minute)
+			},
+			checkResponse: func(t *testing.T, resp *pb.GetAccountResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "invalid id",
+			req: &pb.GetAccountRequest{
+				Id: 0,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, resp *pb.GetAccountResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "not found",
+			req: &pb.GetAccountRequest{
+				Id: account.ID,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(db.Account{}, sql.ErrNoRows)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, resp *pb.GetAccountResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "unauthorized user",
+			req: &pb.GetAccountRequest{
+				Id: account.ID,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, otherUser.Username, otherUser.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, resp *pb.GetAccountResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "banker reads other user's account",
+			req: &pb.GetAccountRequest{
+				Id: account.ID,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, resp *pb.GetAccountResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account.ID, resp.GetAccount().GetId())
+			},
+		},
+		{
+			name: "internal error",
+			req: &pb.GetAccountRequest{
+				Id: account.ID,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(db.Account{}, sql.ErrConnDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, resp *pb.GetAccountResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.GetAccount(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
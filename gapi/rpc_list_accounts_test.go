@@ -0,0 +1,134 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListAccountsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	n := 5
+	accounts := make([]db.Account, n)
+	for i := 0; i < n; i++ {
+		accounts[i] = randomAccount(user.Username)
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListAccountsRequest
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.ListAccountsResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.ListAccountsRequest{
+				PageId:   2,
+				PageSize: int32(n),
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.ListAccountsReturns(accounts, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ListAccountsResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+
+				require.Equal(t, 1, store.ListAccountsCallCount())
+				_, arg := store.ListAccountsArgsForCall(0)
+				require.Equal(t, user.Username, arg.Owner)
+				require.Equal(t, int32(n), arg.Limit)
+				require.Equal(t, int32(n), arg.Offset)
+
+				require.Len(t, resp.GetAccounts(), n)
+				for i, account := range resp.GetAccounts() {
+					require.Equal(t, accounts[i].ID, account.Id)
+					require.Equal(t, accounts[i].Owner, account.Owner)
+				}
+			},
+		},
+		{
+			name: "no authorization",
+			req: &pb.ListAccountsRequest{
+				PageId:   1,
+				PageSize: int32(n),
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ListAccountsResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.ListAccountsCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "invalid page size",
+			req: &pb.ListAccountsRequest{
+				PageId:   1,
+				PageSize: 100,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ListAccountsResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.ListAccountsCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "internal error",
+			req: &pb.ListAccountsRequest{
+				PageId:   1,
+				PageSize: int32(n),
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.ListAccountsReturns([]db.Account{}, sql.ErrConnDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ListAccountsResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.ListAccounts(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/milhamh95/simplebank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListSessions returns the sessions of the authenticated user
+// that can still renew their access token
+func (s *Server) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	sessions, err := s.store.ListActiveSessions(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list sessions: %s", err.Error())
+	}
+
+	rsp := &pb.ListSessionsResponse{
+		Sessions: make([]*pb.Session, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		rsp.Sessions = append(rsp.Sessions, convertSession(session))
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,105 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestListSessionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	n := 3
+	sessions := make([]db.Session, n)
+	for i := range sessions {
+		sessions[i] = randomSession(user.Username)
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.ListSessionsResponse, err error)
+	}{
+		{
+			name: "success",
+			buildStubs: func(store *fake.FakeStore) {
+				store.ListActiveSessionsReturns(sessions, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ListSessionsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, resp.GetSessions(), n)
+
+				_, username := store.ListActiveSessionsArgsForCall(0)
+				require.Equal(t, user.Username, username)
+
+				for i, session := range resp.GetSessions() {
+					require.Equal(t, sessions[i].FamilyID.String(), session.GetId())
+					require.Equal(t, sessions[i].UserAgent, session.GetUserAgent())
+					require.Equal(t, sessions[i].ClientIp, session.GetClientIp())
+				}
+			},
+		},
+		{
+			name:       "no authorization",
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ListSessionsResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.ListActiveSessionsCallCount())
+			},
+		},
+		{
+			name: "internal error",
+			buildStubs: func(store *fake.FakeStore) {
+				store.ListActiveSessionsReturns(nil, sql.ErrConnDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ListSessionsResponse, err error) {
+				requireStatusCode(t, err, codes.Internal)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.ListSessions(ctx, &pb.ListSessionsRequest{})
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
+
+// randomSession returns the renewed session of a login,
+// so its id differs from its family id
+func randomSession(username string) db.Session {
+	return db.Session{
+		ID:        uuid.New(),
+		Username:  username,
+		UserAgent: "grpc-go",
+		ClientIp:  "127.0.0.1",
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+		FamilyID:  uuid.New(),
+	}
+}
@@ -3,32 +3,169 @@ package gapi
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	db "github.com/milhamh95/simplebank/db/sqlc"
 	"github.com/milhamh95/simplebank/pb"
-	"github.com/milhamh95/simplebank/pkg/password"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// LoginUser logs in with the username or the email of the user
 func (s *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
-	user, err := s.store.GetUser(ctx, req.Username)
+	violations := validateLoginUserRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	loginMetadata, err := s.extractMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loginName := req.GetUsername()
+	if req.GetEmail() != "" {
+		loginName = req.GetEmail()
+	}
+
+	limits := loginLimits(loginName, loginMetadata.ClientIP)
+	err = s.reserveLoginAttempt(ctx, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	// unknown users and wrong passwords get the same error,
+	// so the response doesn't tell whether the username or email exists
+	user, err := s.findLoginUser(ctx, req)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found")
+			s.checkDummyPassword(req.GetPassword())
+			s.failLogin(ctx, limits)
+			return nil, status.Errorf(codes.Unauthenticated, "incorrect username or password")
 		}
 
+		s.refundLoginAttempt(ctx, limits)
 		return nil, status.Errorf(codes.Internal, "find user: %s", err.Error())
 	}
 
-	err = password.CheckPassword(req.Password, user.HashedPassword)
+	// logins by email count against the username too,
+	// so switching between both gives no extra attempts
+	if loginName != user.Username {
+		usernameLimits := loginLimits(user.Username, "")
+		err = s.reserveLoginAttempt(ctx, usernameLimits)
+		if err != nil {
+			s.refundLoginAttempt(ctx, limits)
+			return nil, err
+		}
+
+		limits = append(limits, usernameLimits...)
+	}
+
+	err = s.passwordHasher.CheckPassword(req.GetPassword(), user.HashedPassword)
+	if err != nil {
+		s.failLogin(ctx, limits)
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect username or password")
+	}
+
+	// the password is right, the attempt isn't a failed one
+	s.refundLoginAttempt(ctx, limits)
+
+	if s.passwordHasher.NeedsRehash(user.HashedPassword) {
+		user = s.rehashPassword(ctx, user, req.GetPassword())
+	}
+
+	if s.cfg.RequireVerifiedEmail && !user.IsEmailVerified {
+		return nil, status.Errorf(codes.FailedPrecondition, "email address is not verified")
+	}
+
+	// the failed attempts are kept until the second step succeeds,
+	// so the password can't be used to reset them while guessing codes
+	if user.TotpEnabled {
+		return s.createMFAChallenge(user)
+	}
+
+	s.resetLoginLimits(ctx, user.Username)
+	if loginName != user.Username {
+		s.resetLoginLimits(ctx, loginName)
+	}
+
+	return s.createLoginSession(ctx, user)
+}
+
+func (s *Server) findLoginUser(ctx context.Context, req *pb.LoginUserRequest) (db.User, error) {
+	if req.GetEmail() != "" {
+		return s.store.GetUserByEmail(ctx, req.GetEmail())
+	}
+
+	return s.store.GetUser(ctx, req.GetUsername())
+}
+
+func validateLoginUserRequest(req *pb.LoginUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	switch {
+	case req.GetUsername() != "" && req.GetEmail() != "":
+		violations = append(
+			violations,
+			fieldViolation("email", fmt.Errorf("must not be set together with username")),
+		)
+	case req.GetEmail() != "":
+		err := validator.ValidateEmail(req.GetEmail())
+		if err != nil {
+			violations = append(
+				violations,
+				fieldViolation("email", err),
+			)
+		}
+	default:
+		err := validator.ValidateUsername(req.GetUsername())
+		if err != nil {
+			violations = append(
+				violations,
+				fieldViolation("username", err),
+			)
+		}
+	}
+
+	// the length isn't checked, the policy of the password may have changed since it was set
+	err := validator.ValidateString(req.GetPassword(), 1, 1000)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "incorrect password: %s", err.Error())
+		violations = append(
+			violations,
+			fieldViolation("password", err),
+		)
+	}
+
+	return violations
+}
+
+// createMFAChallenge returns the mfa token of a user with TOTP enabled,
+// it is exchanged for the session with VerifyLoginMFA
+func (s *Server) createMFAChallenge(user db.User) (*pb.LoginUserResponse, error) {
+	mfaToken, mfaPayload, err := s.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeMFA, mfaTokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create mfa token: %s", err.Error())
+	}
+
+	resp := &pb.LoginUserResponse{
+		MfaRequired:       true,
+		MfaToken:          mfaToken,
+		MfaTokenExpiredAt: timestamppb.New(mfaPayload.ExpiredAt),
 	}
 
+	return resp, nil
+}
+
+// createLoginSession issues the access and refresh tokens of a user
+// who passed every login check, and stores the refresh token session
+func (s *Server) createLoginSession(ctx context.Context, user db.User) (*pb.LoginUserResponse, error) {
 	accessToken, accessPayload, err := s.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
+		token.TokenTypeAccess,
 		s.cfg.AccessTokenDuration,
 	)
 	if err != nil {
@@ -37,7 +174,9 @@ func (s *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.L
 
 	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(
 		user.Username,
-		s.cfg.AccessTokenDuration,
+		user.Role,
+		token.TokenTypeRefresh,
+		s.cfg.RefreshTokenDuration,
 	)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "create refresh token: %s", err.Error())
@@ -56,19 +195,14 @@ func (s *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.L
 		ClientIp:     loginMetadata.ClientIP,
 		IsBlocked:    false,
 		ExpiresAt:    refreshPayload.ExpiredAt,
+		FamilyID:     refreshPayload.ID,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "create session: %s", err.Error())
 	}
 
 	resp := &pb.LoginUserResponse{
-		User: &pb.User{
-			Username:          user.Username,
-			FullName:          user.FullName,
-			Email:             user.Email,
-			PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
-			CreatedAt:         timestamppb.New(user.CreatedAt),
-		},
+		User:                  convertUser(user),
 		SessionId:             sess.ID.String(),
 		AccessToken:           accessToken,
 		AccessTokenExpiredAt:  timestamppb.New(accessPayload.ExpiredAt),
@@ -78,3 +212,28 @@ func (s *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.L
 
 	return resp, nil
 }
+
+// rehashPassword stores a new hash of the password when the stored hash uses an outdated
+// algorithm or parameters. The login goes on with the old hash if it fails.
+// password_changed_at is kept, so the tokens of the user stay valid
+func (s *Server) rehashPassword(ctx context.Context, user db.User, pwd string) db.User {
+	hashedPassword, err := s.passwordHasher.HashPassword(pwd)
+	if err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("rehash password")
+		return user
+	}
+
+	updatedUser, err := s.store.UpdateUser(ctx, db.UpdateUserParams{
+		Username: user.Username,
+		HashedPassword: sql.NullString{
+			String: hashedPassword,
+			Valid:  true,
+		},
+	})
+	if err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("store rehashed password")
+		return user
+	}
+
+	return updatedUser
+}
@@ -0,0 +1,282 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/limiter"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLoginUserAPI(t *testing.T) {
+	user, password := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		req           *pb.LoginUserRequest
+		buildStubs    func(t *testing.T, store *fake.FakeStore, server *Server)
+		checkResponse func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: password,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(user, nil)
+				failLoginAttempts(t, server, user.Username, limiter.UserPolicy.FreeAttempts)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, resp.GetMfaRequired())
+				require.NotEmpty(t, resp.GetAccessToken())
+				require.NotEmpty(t, resp.GetRefreshToken())
+				require.Equal(t, 1, store.CreateSessionCallCount())
+				require.Equal(t, 0, store.UpdateUserCallCount())
+
+				// the failed attempts of the user are forgotten
+				delay, err := server.loginLimiter.Fail(context.Background(), limiter.UserKey(user.Username), limiter.UserPolicy)
+				require.NoError(t, err)
+				require.Zero(t, delay)
+			},
+		},
+		{
+			name: "login with email",
+			req: &pb.LoginUserRequest{
+				Email:    user.Email,
+				Password: password,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserByEmailReturns(user, nil)
+				failLoginAttempts(t, server, user.Username, limiter.UserPolicy.FreeAttempts)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, resp.GetAccessToken())
+				require.Equal(t, user.Username, resp.GetUser().GetUsername())
+
+				require.Equal(t, 0, store.GetUserCallCount())
+				require.Equal(t, 1, store.GetUserByEmailCallCount())
+				_, email := store.GetUserByEmailArgsForCall(0)
+				require.Equal(t, user.Email, email)
+
+				// the failed attempts of the username are forgotten too
+				delay, err := server.loginLimiter.Fail(context.Background(), limiter.UserKey(user.Username), limiter.UserPolicy)
+				require.NoError(t, err)
+				require.Zero(t, delay)
+			},
+		},
+		{
+			name: "email login counts against the username",
+			req: &pb.LoginUserRequest{
+				Email:    user.Email,
+				Password: password,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserByEmailReturns(user, nil)
+				failLoginAttempts(t, server, user.Username, limiter.UserPolicy.LockoutAttempts)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				requireStatusCode(t, err, codes.ResourceExhausted)
+				require.Equal(t, 0, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "username and email",
+			req: &pb.LoginUserRequest{
+				Username: user.Username,
+				Email:    user.Email,
+				Password: password,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				requireFieldViolations(t, err, "email")
+			},
+		},
+		{
+			name: "email not verified",
+			req: &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: password,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				server.cfg.RequireVerifiedEmail = true
+				store.GetUserReturns(user, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+				require.Equal(t, 0, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "email verified",
+			req: &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: password,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				server.cfg.RequireVerifiedEmail = true
+
+				verifiedUser := user
+				verifiedUser.IsEmailVerified = true
+				store.GetUserReturns(verifiedUser, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "rehash outdated password",
+			req: &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: password,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+				require.NoError(t, err)
+
+				bcryptUser := user
+				bcryptUser.HashedPassword = string(hashedPassword)
+				store.GetUserReturns(bcryptUser, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, resp.GetAccessToken())
+
+				require.Equal(t, 1, store.UpdateUserCallCount())
+				_, arg := store.UpdateUserArgsForCall(0)
+				require.Equal(t, user.Username, arg.Username)
+				require.False(t, arg.PasswordChangedAt.Valid)
+				require.False(t, server.passwordHasher.NeedsRehash(arg.HashedPassword.String))
+				require.NoError(t, server.passwordHasher.CheckPassword(password, arg.HashedPassword.String))
+			},
+		},
+		{
+			name: "mfa required",
+			req: &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: password,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(totpUser(t, server, user, "JBSWY3DPEHPK3PXP"), nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, resp.GetMfaRequired())
+				require.Empty(t, resp.GetAccessToken())
+				require.Empty(t, resp.GetRefreshToken())
+				require.Equal(t, 0, store.CreateSessionCallCount())
+
+				// the mfa token can't be used as an access token
+				_, err = server.tokenMaker.VerifyToken(resp.GetMfaToken(), token.TokenTypeAccess)
+				require.Error(t, err)
+
+				payload, err := server.tokenMaker.VerifyToken(resp.GetMfaToken(), token.TokenTypeMFA)
+				require.NoError(t, err)
+				require.Equal(t, user.Username, payload.Username)
+			},
+		},
+		{
+			name: "incorrect password",
+			req: &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: "incorrect",
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(user, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "user not found",
+			req: &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: password,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(db.User{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "failed attempts are delayed",
+			req: &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: "incorrect",
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(user, nil)
+				failLoginAttempts(t, server, user.Username, limiter.UserPolicy.FreeAttempts)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+
+				wait, err := server.loginLimiter.Wait(context.Background(), limiter.UserKey(user.Username))
+				require.NoError(t, err)
+				require.Equal(t, limiter.UserPolicy.BaseDelay, wait.Round(time.Second))
+			},
+		},
+		{
+			name: "locked out",
+			req: &pb.LoginUserRequest{
+				Username: user.Username,
+				Password: password,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserReturns(user, nil)
+				failLoginAttempts(t, server, user.Username, limiter.UserPolicy.LockoutAttempts)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, server *Server, resp *pb.LoginUserResponse, err error) {
+				requireStatusCode(t, err, codes.ResourceExhausted)
+				require.Equal(t, 0, store.GetUserCallCount())
+				require.Equal(t, 0, store.CreateSessionCallCount())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			fakeStore.CreateSessionStub = func(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+				return db.Session{ID: arg.ID, Username: arg.Username}, nil
+			}
+
+			server := newTestServer(t, fakeStore, nil)
+			tc.buildStubs(t, fakeStore, server)
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+			res, err := server.LoginUser(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, server, res, err)
+		})
+	}
+}
+
+// failLoginAttempts records n failed attempts of the user, the way a burst of logins does
+func failLoginAttempts(t *testing.T, server *Server, username string, n int64) {
+	key := limiter.UserKey(username)
+	for i := int64(0); i < n; i++ {
+		wait, err := server.loginLimiter.Reserve(context.Background(), key, limiter.UserPolicy)
+		require.NoError(t, err)
+		require.Zero(t, wait)
+	}
+
+	_, err := server.loginLimiter.Fail(context.Background(), key, limiter.UserPolicy)
+	require.NoError(t, err)
+}
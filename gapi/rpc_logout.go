@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Logout blocks the session family of the refresh token, so it can't be renewed anymore,
+// and revokes the access token of the request if there is one.
+// Logging out of a blocked session succeeds
+func (s *Server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	violations := validateLogoutRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, session, err := s.refreshSession(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+
+	// a rotated refresh token was replaced by a session that is still valid,
+	// so the whole family is blocked and not only the session of the token
+	if !session.IsBlocked || session.ReplacedBy.Valid {
+		err = s.store.BlockSessionFamily(ctx, session.FamilyID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "block session family: %s", err.Error())
+		}
+	}
+
+	// the access token sent along is revoked too, it would stay valid until it expires
+	accessPayload, err := s.authorizeUser(ctx)
+	if err == nil && accessPayload.Username == session.Username {
+		err = s.tokenDenylist.Deny(ctx, accessPayload.ID, accessPayload.ExpiredAt)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "revoke access token: %s", err.Error())
+		}
+	}
+
+	return &pb.LogoutResponse{}, nil
+}
+
+func validateLogoutRequest(req *pb.LogoutRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateRefreshToken(req.GetRefreshToken())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("refresh_token", err),
+		)
+	}
+
+	return violations
+}
@@ -0,0 +1,148 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestLogoutAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildRequest  func(refreshToken string) *pb.LogoutRequest
+		buildStubs    func(store *fake.FakeStore, session db.Session)
+		checkResponse func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.LogoutResponse, err error)
+	}{
+		{
+			name: "success",
+			buildRequest: func(refreshToken string) *pb.LogoutRequest {
+				return &pb.LogoutRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				store.GetSessionReturns(session, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.LogoutResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+
+				require.Equal(t, 1, store.BlockSessionFamilyCallCount())
+				_, familyID := store.BlockSessionFamilyArgsForCall(0)
+				require.Equal(t, session.FamilyID, familyID)
+			},
+		},
+		{
+			name: "rotated session",
+			buildRequest: func(refreshToken string) *pb.LogoutRequest {
+				return &pb.LogoutRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				session.IsBlocked = true
+				session.ReplacedBy = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+				store.GetSessionReturns(session, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.LogoutResponse, err error) {
+				require.NoError(t, err)
+
+				// the session that replaced it is blocked with the family
+				require.Equal(t, 1, store.BlockSessionFamilyCallCount())
+				_, familyID := store.BlockSessionFamilyArgsForCall(0)
+				require.Equal(t, session.FamilyID, familyID)
+			},
+		},
+		{
+			name: "already blocked",
+			buildRequest: func(refreshToken string) *pb.LogoutRequest {
+				return &pb.LogoutRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				session.IsBlocked = true
+				store.GetSessionReturns(session, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.LogoutResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 0, store.BlockSessionFamilyCallCount())
+			},
+		},
+		{
+			name: "invalid refresh token",
+			buildRequest: func(refreshToken string) *pb.LogoutRequest {
+				return &pb.LogoutRequest{RefreshToken: "invalid"}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.LogoutResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.BlockSessionFamilyCallCount())
+			},
+		},
+		{
+			name: "mismatched session token",
+			buildRequest: func(refreshToken string) *pb.LogoutRequest {
+				return &pb.LogoutRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				session.RefreshToken = "other"
+				store.GetSessionReturns(session, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.LogoutResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.BlockSessionFamilyCallCount())
+			},
+		},
+		{
+			name: "internal error",
+			buildRequest: func(refreshToken string) *pb.LogoutRequest {
+				return &pb.LogoutRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				store.GetSessionReturns(session, nil)
+				store.BlockSessionFamilyReturns(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.LogoutResponse, err error) {
+				requireStatusCode(t, err, codes.Internal)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			server := newTestServer(t, fakeStore, nil)
+
+			session := newTestSession(t, server, user)
+			tc.buildStubs(fakeStore, session)
+
+			res, err := server.Logout(context.Background(), tc.buildRequest(session.RefreshToken))
+			tc.checkResponse(t, fakeStore, session, res, err)
+		})
+	}
+}
+
+func TestLogoutRevokesAccessToken(t *testing.T) {
+	user, _ := randomUser(t)
+
+	fakeStore := &fake.FakeStore{}
+	server := newTestServer(t, fakeStore, nil)
+
+	session := newTestSession(t, server, user)
+	fakeStore.GetSessionReturns(session, nil)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, user.Role, time.Minute)
+	_, err := server.authorizeUser(ctx)
+	require.NoError(t, err)
+
+	_, err = server.Logout(ctx, &pb.LogoutRequest{RefreshToken: session.RefreshToken})
+	require.NoError(t, err)
+
+	_, err = server.authorizeUser(ctx)
+	require.EqualError(t, err, "access token was revoked")
+}
@@ -0,0 +1,127 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"github.com/milhamh95/simplebank/token"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RenewAccessToken rotates the refresh token: it returns a new access token and
+// a new refresh token, and the session of the old refresh token is replaced.
+// Presenting a refresh token that was already rotated blocks its whole session family
+func (s *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
+	violations := validateRenewAccessTokenRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	refreshPayload, session, err := s.refreshSession(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if session.ReplacedBy.Valid {
+		return nil, s.blockReusedSessionFamily(ctx, session)
+	}
+
+	if session.IsBlocked {
+		return nil, status.Errorf(codes.Unauthenticated, "blocked session")
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "expired session")
+	}
+
+	// the role is read again, so a demoted user doesn't keep the old role by refreshing
+	user, err := s.store.GetUser(ctx, refreshPayload.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "get user: %s", err.Error())
+	}
+
+	// a password change revokes every refresh token issued before it
+	if refreshPayload.IssuedBefore(user.PasswordChangedAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "refresh token was issued before the password changed")
+	}
+
+	accessToken, accessPayload, err := s.tokenMaker.CreateToken(
+		user.Username,
+		user.Role,
+		token.TokenTypeAccess,
+		s.cfg.AccessTokenDuration,
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create access token: %s", err.Error())
+	}
+
+	// the new refresh token expires with the session family, rotating doesn't extend the login
+	refreshToken, newRefreshPayload, err := s.tokenMaker.CreateToken(
+		user.Username,
+		user.Role,
+		token.TokenTypeRefresh,
+		time.Until(session.ExpiresAt),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create refresh token: %s", err.Error())
+	}
+
+	renewMetadata, err := s.extractMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		OldSessionID: session.ID,
+		NewSession: db.CreateSessionParams{
+			ID:           newRefreshPayload.ID,
+			Username:     session.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    renewMetadata.UserAgent,
+			ClientIp:     renewMetadata.ClientIP,
+			IsBlocked:    false,
+			ExpiresAt:    session.ExpiresAt,
+			FamilyID:     session.FamilyID,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			return nil, s.blockReusedSessionFamily(ctx, session)
+		}
+
+		return nil, status.Errorf(codes.Internal, "rotate session: %s", err.Error())
+	}
+
+	rsp := &pb.RenewAccessTokenResponse{
+		AccessToken:           accessToken,
+		AccessTokenExpiredAt:  timestamppb.New(accessPayload.ExpiredAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiredAt: timestamppb.New(newRefreshPayload.ExpiredAt),
+	}
+
+	return rsp, nil
+}
+
+func validateRenewAccessTokenRequest(req *pb.RenewAccessTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateRefreshToken(req.GetRefreshToken())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("refresh_token", err),
+		)
+	}
+
+	return violations
+}
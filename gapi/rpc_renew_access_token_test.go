@@ -0,0 +1,270 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRenewAccessTokenAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildRequest  func(refreshToken string) *pb.RenewAccessTokenRequest
+		buildStubs    func(store *fake.FakeStore, session db.Session)
+		checkResponse func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error)
+	}{
+		{
+			name: "success",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				store.GetSessionReturns(session, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, resp.GetAccessToken())
+				require.NotNil(t, resp.GetAccessTokenExpiredAt())
+				require.NotEmpty(t, resp.GetRefreshToken())
+				require.NotEqual(t, session.RefreshToken, resp.GetRefreshToken())
+
+				require.Equal(t, 1, store.RotateSessionTxCallCount())
+				_, arg := store.RotateSessionTxArgsForCall(0)
+				require.Equal(t, session.ID, arg.OldSessionID)
+				require.Equal(t, session.FamilyID, arg.NewSession.FamilyID)
+				require.Equal(t, resp.GetRefreshToken(), arg.NewSession.RefreshToken)
+
+				// rotating keeps the expiry of the session family
+				require.Equal(t, session.ExpiresAt, arg.NewSession.ExpiresAt)
+				require.WithinDuration(t, session.ExpiresAt, resp.GetRefreshTokenExpiredAt().AsTime(), time.Second)
+			},
+		},
+		{
+			name: "user not found",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				store.GetSessionReturns(session, nil)
+				store.GetUserReturns(db.User{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+				require.Equal(t, 0, store.RotateSessionTxCallCount())
+			},
+		},
+		{
+			name: "password changed",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				store.GetSessionReturns(session, nil)
+
+				changedUser := user
+				changedUser.PasswordChangedAt = time.Now().Add(time.Minute)
+				store.GetUserReturns(changedUser, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.RotateSessionTxCallCount())
+			},
+		},
+		{
+			name: "reused refresh token",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				session.ReplacedBy = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+				store.GetSessionReturns(session, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.RotateSessionTxCallCount())
+
+				require.Equal(t, 1, store.BlockSessionFamilyCallCount())
+				_, familyID := store.BlockSessionFamilyArgsForCall(0)
+				require.Equal(t, session.FamilyID, familyID)
+			},
+		},
+		{
+			name: "concurrent rotation",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				store.GetSessionReturns(session, nil)
+				store.RotateSessionTxReturns(db.Session{}, db.ErrRefreshTokenReused)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 1, store.BlockSessionFamilyCallCount())
+			},
+		},
+		{
+			name: "invalid refresh token",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: "invalid"}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "session not found",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				store.GetSessionReturns(db.Session{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "blocked session",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				session.IsBlocked = true
+				store.GetSessionReturns(session, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "mismatched session token",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				session.RefreshToken = "other"
+				store.GetSessionReturns(session, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "incorrect session user",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				session.Username = "other"
+				store.GetSessionReturns(session, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "expired session",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{RefreshToken: refreshToken}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {
+				session.ExpiresAt = time.Now().Add(-time.Minute)
+				store.GetSessionReturns(session, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "empty refresh token",
+			buildRequest: func(refreshToken string) *pb.RenewAccessTokenRequest {
+				return &pb.RenewAccessTokenRequest{}
+			},
+			buildStubs: func(store *fake.FakeStore, session db.Session) {},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, session db.Session, resp *pb.RenewAccessTokenResponse, err error) {
+				requireFieldViolations(t, err, "refresh_token")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			fakeStore.GetUserReturns(user, nil)
+			server := newTestServer(t, fakeStore, nil)
+
+			session := newTestSession(t, server, user)
+			tc.buildStubs(fakeStore, session)
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+			res, err := server.RenewAccessToken(ctx, tc.buildRequest(session.RefreshToken))
+			tc.checkResponse(t, fakeStore, session, res, err)
+		})
+	}
+}
+
+func TestRenewAccessTokenUsesCurrentRole(t *testing.T) {
+	user, _ := randomUser(t)
+	user.Role = role.Admin
+
+	fakeStore := &fake.FakeStore{}
+	server := newTestServer(t, fakeStore, nil)
+
+	session := newTestSession(t, server, user)
+	fakeStore.GetSessionReturns(session, nil)
+
+	// the admin was demoted after logging in
+	demotedUser := user
+	demotedUser.Role = role.Depositor
+	fakeStore.GetUserReturns(demotedUser, nil)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+	res, err := server.RenewAccessToken(ctx, &pb.RenewAccessTokenRequest{RefreshToken: session.RefreshToken})
+	require.NoError(t, err)
+
+	accessPayload, err := server.tokenMaker.VerifyToken(res.GetAccessToken(), token.TokenTypeAccess)
+	require.NoError(t, err)
+	require.Equal(t, role.Depositor, accessPayload.Role)
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(res.GetRefreshToken(), token.TokenTypeRefresh)
+	require.NoError(t, err)
+	require.Equal(t, role.Depositor, refreshPayload.Role)
+}
+
+// newTestSession creates a refresh token for the user
+// and the session LoginUser would store for it
+func newTestSession(t *testing.T, server *Server, user db.User) db.Session {
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeRefresh, time.Hour)
+	require.NoError(t, err)
+
+	return db.Session{
+		ID:           refreshPayload.ID,
+		Username:     user.Username,
+		RefreshToken: refreshToken,
+		ExpiresAt:    refreshPayload.ExpiredAt,
+		FamilyID:     refreshPayload.ID,
+	}
+}
+
+func requireStatusCode(t *testing.T, err error, code codes.Code) {
+	require.Error(t, err)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+}
@@ -0,0 +1,82 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/hibiken/asynq"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"github.com/milhamh95/simplebank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequestLoginLink emails a single-use login link to the user.
+// It succeeds for unknown emails and locked out users too,
+// so it can't be used to find out which users exist
+func (s *Server) RequestLoginLink(ctx context.Context, req *pb.RequestLoginLinkRequest) (*pb.RequestLoginLinkResponse, error) {
+	violations := validateRequestLoginLinkRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	loginMetadata, err := s.extractMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkLoginLimits(ctx, loginLimits(req.GetEmail(), loginMetadata.ClientIP))
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.RequestLoginLinkResponse{}
+
+	user, err := s.store.GetUserByEmail(ctx, req.GetEmail())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return rsp, nil
+		}
+
+		return nil, status.Errorf(codes.Internal, "find user: %s", err.Error())
+	}
+
+	err = s.checkLoginLimits(ctx, loginLimits(user.Username, ""))
+	if err != nil {
+		if status.Code(err) == codes.ResourceExhausted {
+			return rsp, nil
+		}
+
+		return nil, err
+	}
+
+	taskPayload := &worker.PayloadSendLoginLink{
+		Username: user.Username,
+		Locale:   requestLocale(ctx),
+	}
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+	err = s.taskDistributor.DistributeTaskSendLoginLink(ctx, taskPayload, opts...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "distribute task to send login link: %s", err.Error())
+	}
+
+	return rsp, nil
+}
+
+func validateRequestLoginLinkRequest(req *pb.RequestLoginLinkRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateEmail(req.GetEmail())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("email", err),
+		)
+	}
+
+	return violations
+}
@@ -0,0 +1,112 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/limiter"
+	"github.com/milhamh95/simplebank/pb"
+	workerFake "github.com/milhamh95/simplebank/worker/fake"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestLoginLinkAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		req           *pb.RequestLoginLinkRequest
+		buildStubs    func(t *testing.T, store *fake.FakeStore, server *Server)
+		checkResponse func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestLoginLinkResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.RequestLoginLinkRequest{
+				Email: user.Email,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserByEmailReturns(user, nil)
+			},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestLoginLinkResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, taskDistributor.DistributeTaskSendLoginLinkCallCount())
+
+				_, payload, _ := taskDistributor.DistributeTaskSendLoginLinkArgsForCall(0)
+				require.Equal(t, user.Username, payload.Username)
+				require.Equal(t, "id-ID,id;q=0.9", payload.Locale)
+			},
+		},
+		{
+			name: "unknown email",
+			req: &pb.RequestLoginLinkRequest{
+				Email: user.Email,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserByEmailReturns(db.User{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestLoginLinkResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				require.Equal(t, 0, taskDistributor.DistributeTaskSendLoginLinkCallCount())
+			},
+		},
+		{
+			name: "locked out user",
+			req: &pb.RequestLoginLinkRequest{
+				Email: user.Email,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserByEmailReturns(user, nil)
+				failLoginAttempts(t, server, user.Username, limiter.UserPolicy.LockoutAttempts)
+			},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestLoginLinkResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 0, taskDistributor.DistributeTaskSendLoginLinkCallCount())
+			},
+		},
+		{
+			name: "internal error",
+			req: &pb.RequestLoginLinkRequest{
+				Email: user.Email,
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {
+				store.GetUserByEmailReturns(db.User{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestLoginLinkResponse, err error) {
+				requireStatusCode(t, err, codes.Internal)
+			},
+		},
+		{
+			name: "invalid email",
+			req: &pb.RequestLoginLinkRequest{
+				Email: "invalid-email",
+			},
+			buildStubs: func(t *testing.T, store *fake.FakeStore, server *Server) {},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestLoginLinkResponse, err error) {
+				requireFieldViolations(t, err, "email")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			taskDistributor := &workerFake.FakeTaskDistributor{}
+
+			server := newTestServer(t, fakeStore, taskDistributor)
+			tc.buildStubs(t, fakeStore, server)
+
+			// the gateway forwards the Accept-Language header with a prefix
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+				grpcGatewayLanguageHeader: []string{"id-ID,id;q=0.9"},
+			})
+			res, err := server.RequestLoginLink(ctx, tc.req)
+			tc.checkResponse(t, taskDistributor, res, err)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"github.com/milhamh95/simplebank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultResetPasswordCooldown   = time.Minute
+	defaultResetPasswordDailyLimit = 5
+)
+
+// RequestPasswordReset emails a reset code to the user, at most once per cooldown and
+// daily limit of codes. It succeeds for unknown usernames and limited requests too,
+// so it can't be used to find out which users exist
+func (s *Server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	violations := validateRequestPasswordResetRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	rsp := &pb.RequestPasswordResetResponse{}
+
+	cooldown := s.cfg.ResetPasswordCooldown
+	if cooldown <= 0 {
+		cooldown = defaultResetPasswordCooldown
+	}
+
+	dailyLimit := s.cfg.ResetPasswordDailyLimit
+	if dailyLimit <= 0 {
+		dailyLimit = defaultResetPasswordDailyLimit
+	}
+
+	secretCode, err := random.SecretString(32)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate secret code: %s", err.Error())
+	}
+
+	// the code is created here, so the cooldown also counts requests whose email isn't sent yet.
+	// The task emails the active code of the user
+	txResult, err := s.store.RequestPasswordResetTx(ctx, db.RequestPasswordResetTxParams{
+		Username:   req.GetUsername(),
+		SecretCode: secretCode,
+		Cooldown:   cooldown,
+		DailyLimit: dailyLimit,
+	})
+	if err != nil {
+		// limited requests look like successful ones, like the requests for unknown users
+		if errors.Is(err, sql.ErrNoRows) ||
+			errors.Is(err, db.ErrResetPasswordCooldown) ||
+			errors.Is(err, db.ErrResetPasswordLimit) {
+			return rsp, nil
+		}
+
+		return nil, status.Errorf(codes.Internal, "request password reset: %s", err.Error())
+	}
+
+	taskPayload := &worker.PayloadSendResetPassword{
+		Username: txResult.User.Username,
+		Locale:   requestLocale(ctx),
+	}
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+	err = s.taskDistributor.DistributeTaskSendResetPassword(ctx, taskPayload, opts...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "distribute task to send reset password: %s", err.Error())
+	}
+
+	return rsp, nil
+}
+
+func validateRequestPasswordResetRequest(req *pb.RequestPasswordResetRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateUsername(req.GetUsername())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("username", err),
+		)
+	}
+
+	return violations
+}
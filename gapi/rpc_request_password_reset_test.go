@@ -0,0 +1,117 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	workerFake "github.com/milhamh95/simplebank/worker/fake"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRequestPasswordResetAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		req           *pb.RequestPasswordResetRequest
+		buildStubs    func(store *fake.FakeStore)
+		checkResponse func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestPasswordResetResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.RequestPasswordResetRequest{
+				Username: user.Username,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.RequestPasswordResetTxReturns(db.RequestPasswordResetTxResult{User: user}, nil)
+			},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, taskDistributor.DistributeTaskSendResetPasswordCallCount())
+
+				_, payload, _ := taskDistributor.DistributeTaskSendResetPasswordArgsForCall(0)
+				require.Equal(t, user.Username, payload.Username)
+			},
+		},
+		{
+			name: "unknown user",
+			req: &pb.RequestPasswordResetRequest{
+				Username: user.Username,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.RequestPasswordResetTxReturns(db.RequestPasswordResetTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				require.Equal(t, 0, taskDistributor.DistributeTaskSendResetPasswordCallCount())
+			},
+		},
+		{
+			name: "cooldown",
+			req: &pb.RequestPasswordResetRequest{
+				Username: user.Username,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.RequestPasswordResetTxReturns(db.RequestPasswordResetTxResult{}, db.ErrResetPasswordCooldown)
+			},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				require.Equal(t, 0, taskDistributor.DistributeTaskSendResetPasswordCallCount())
+			},
+		},
+		{
+			name: "daily limit",
+			req: &pb.RequestPasswordResetRequest{
+				Username: user.Username,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.RequestPasswordResetTxReturns(db.RequestPasswordResetTxResult{}, db.ErrResetPasswordLimit)
+			},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestPasswordResetResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 0, taskDistributor.DistributeTaskSendResetPasswordCallCount())
+			},
+		},
+		{
+			name: "internal error",
+			req: &pb.RequestPasswordResetRequest{
+				Username: user.Username,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.RequestPasswordResetTxReturns(db.RequestPasswordResetTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestPasswordResetResponse, err error) {
+				requireStatusCode(t, err, codes.Internal)
+			},
+		},
+		{
+			name: "invalid username",
+			req: &pb.RequestPasswordResetRequest{
+				Username: "invalid#user",
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			checkResponse: func(t *testing.T, taskDistributor *workerFake.FakeTaskDistributor, resp *pb.RequestPasswordResetResponse, err error) {
+				requireFieldViolations(t, err, "username")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			taskDistributor := &workerFake.FakeTaskDistributor{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, taskDistributor)
+			res, err := server.RequestPasswordReset(context.Background(), tc.req)
+			tc.checkResponse(t, taskDistributor, res, err)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/worker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultVerifyEmailResendCooldown = time.Minute
+	defaultVerifyEmailDailyLimit     = 5
+)
+
+// ResendVerifyEmail sends a new verification email to the authenticated user.
+// The older unused codes of the user stop working
+func (s *Server) ResendVerifyEmail(ctx context.Context, req *pb.ResendVerifyEmailRequest) (*pb.ResendVerifyEmailResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	verifyEmailMsg, err := worker.NewOutboxMessage(worker.TaskSendVerifyEmail, &worker.PayloadSendVerifyEmail{
+		Username: authPayload.Username,
+		Locale:   requestLocale(ctx),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create verify email task: %s", err.Error())
+	}
+
+	cooldown := s.cfg.VerifyEmailResendCooldown
+	if cooldown <= 0 {
+		cooldown = defaultVerifyEmailResendCooldown
+	}
+
+	dailyLimit := s.cfg.VerifyEmailDailyLimit
+	if dailyLimit <= 0 {
+		dailyLimit = defaultVerifyEmailDailyLimit
+	}
+
+	secretCode, err := random.SecretString(32)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate secret code: %s", err.Error())
+	}
+
+	_, err = s.store.ResendVerifyEmailTx(ctx, db.ResendVerifyEmailTxParams{
+		Username:       authPayload.Username,
+		SecretCode:     secretCode,
+		Cooldown:       cooldown,
+		DailyLimit:     dailyLimit,
+		OutboxMessages: []db.CreateOutboxMessageParams{verifyEmailMsg},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		case errors.Is(err, db.ErrEmailAlreadyVerified):
+			return nil, status.Errorf(codes.FailedPrecondition, "email is already verified")
+		case errors.Is(err, db.ErrVerifyEmailCooldown):
+			return nil, status.Errorf(codes.ResourceExhausted, "a verification email was sent less than %s ago, try again later", cooldown)
+		case errors.Is(err, db.ErrVerifyEmailLimit):
+			return nil, status.Errorf(codes.ResourceExhausted, "at most %d verification emails can be sent in 24 hours", dailyLimit)
+		}
+
+		return nil, status.Errorf(codes.Internal, "resend verify email: %s", err.Error())
+	}
+
+	return &pb.ResendVerifyEmailResponse{}, nil
+}
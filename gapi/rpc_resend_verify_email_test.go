@@ -0,0 +1,124 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/milhamh95/simplebank/worker"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestResendVerifyEmailAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.ResendVerifyEmailResponse, err error)
+	}{
+		{
+			name: "success",
+			buildStubs: func(store *fake.FakeStore) {
+				store.ResendVerifyEmailTxReturns(db.ResendVerifyEmailTxResult{User: user}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResendVerifyEmailResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+
+				require.Equal(t, 1, store.ResendVerifyEmailTxCallCount())
+				_, arg := store.ResendVerifyEmailTxArgsForCall(0)
+				require.Equal(t, user.Username, arg.Username)
+				require.Len(t, arg.SecretCode, 32)
+				require.Equal(t, defaultVerifyEmailResendCooldown, arg.Cooldown)
+				require.Equal(t, int64(defaultVerifyEmailDailyLimit), arg.DailyLimit)
+
+				require.Len(t, arg.OutboxMessages, 1)
+				require.Equal(t, worker.TaskSendVerifyEmail, arg.OutboxMessages[0].TaskType)
+				require.JSONEq(t, `{"username":"`+user.Username+`"}`, string(arg.OutboxMessages[0].Payload))
+			},
+		},
+		{
+			name: "already verified",
+			buildStubs: func(store *fake.FakeStore) {
+				store.ResendVerifyEmailTxReturns(db.ResendVerifyEmailTxResult{}, db.ErrEmailAlreadyVerified)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResendVerifyEmailResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "cooldown",
+			buildStubs: func(store *fake.FakeStore) {
+				store.ResendVerifyEmailTxReturns(db.ResendVerifyEmailTxResult{}, db.ErrVerifyEmailCooldown)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResendVerifyEmailResponse, err error) {
+				requireStatusCode(t, err, codes.ResourceExhausted)
+			},
+		},
+		{
+			name: "daily limit",
+			buildStubs: func(store *fake.FakeStore) {
+				store.ResendVerifyEmailTxReturns(db.ResendVerifyEmailTxResult{}, db.ErrVerifyEmailLimit)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResendVerifyEmailResponse, err error) {
+				requireStatusCode(t, err, codes.ResourceExhausted)
+			},
+		},
+		{
+			name: "internal error",
+			buildStubs: func(store *fake.FakeStore) {
+				store.ResendVerifyEmailTxReturns(db.ResendVerifyEmailTxResult{}, sql.ErrConnDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResendVerifyEmailResponse, err error) {
+				requireStatusCode(t, err, codes.Internal)
+			},
+		},
+		{
+			name:       "unauthenticated",
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResendVerifyEmailResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.ResendVerifyEmailTxCallCount())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			fakeStore.GetUserReturns(user, nil)
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			resp, err := server.ResendVerifyEmail(ctx, &pb.ResendVerifyEmailRequest{})
+			tc.checkResponse(t, fakeStore, resp, err)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	violations := validateResetPasswordRequest(req, s.passwordPolicy)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// the code is only used by ResetPasswordTx, it is looked up here to check the
+	// new password against the username and email of the user
+	resetPassword, err := s.store.GetResetPasswordByCode(ctx, db.GetResetPasswordByCodeParams{
+		ID:         req.GetResetId(),
+		SecretCode: req.GetSecretCode(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "reset code is invalid, used or expired")
+		}
+
+		return nil, status.Errorf(codes.Internal, "get reset password: %s", err.Error())
+	}
+
+	user, err := s.store.GetUser(ctx, resetPassword.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "find user: %s", err.Error())
+	}
+
+	err = s.validateUserPassword("new_password", req.GetNewPassword(), user)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := s.passwordHasher.HashPassword(req.GetNewPassword())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "hash password: %s", err.Error())
+	}
+
+	_, err = s.store.ResetPasswordTx(ctx, db.ResetPasswordTxParams{
+		ResetID:        req.GetResetId(),
+		SecretCode:     req.GetSecretCode(),
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "reset code is invalid, used or expired")
+		}
+
+		return nil, status.Errorf(codes.Internal, "reset password: %s", err.Error())
+	}
+
+	return &pb.ResetPasswordResponse{}, nil
+}
+
+func validateResetPasswordRequest(req *pb.ResetPasswordRequest, passwordPolicy *validator.PasswordPolicy) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateResetID(req.GetResetId())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("reset_id", err),
+		)
+	}
+
+	err = validator.ValidateSecretCode(req.GetSecretCode())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("secret_code", err),
+		)
+	}
+
+	// the username and email are checked by validateUserPassword once the reset code is found
+	for _, err := range passwordPolicy.Validate(req.GetNewPassword(), "", "") {
+		violations = append(
+			violations,
+			fieldViolation("new_password", err),
+		)
+	}
+
+	return violations
+}
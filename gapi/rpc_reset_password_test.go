@@ -0,0 +1,150 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/password"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestResetPasswordAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	newPassword := random.RandomString(8)
+	secretCode := random.RandomString(32)
+	resetPassword := db.ResetPassword{
+		ID:         1,
+		Username:   user.Username,
+		SecretCode: secretCode,
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ResetPasswordRequest
+		buildStubs    func(store *fake.FakeStore)
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.ResetPasswordResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.ResetPasswordRequest{
+				ResetId:     1,
+				SecretCode:  secretCode,
+				NewPassword: newPassword,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetResetPasswordByCodeReturns(resetPassword, nil)
+				store.GetUserReturns(user, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResetPasswordResponse, err error) {
+				require.NoError(t, err)
+
+				_, codeArg := store.GetResetPasswordByCodeArgsForCall(0)
+				require.Equal(t, int64(1), codeArg.ID)
+				require.Equal(t, secretCode, codeArg.SecretCode)
+
+				_, username := store.GetUserArgsForCall(0)
+				require.Equal(t, user.Username, username)
+
+				require.Equal(t, 1, store.ResetPasswordTxCallCount())
+
+				_, arg := store.ResetPasswordTxArgsForCall(0)
+				require.Equal(t, int64(1), arg.ResetID)
+				require.Equal(t, secretCode, arg.SecretCode)
+				require.NoError(t, password.CheckPassword(newPassword, arg.HashedPassword))
+			},
+		},
+		{
+			name: "invalid code",
+			req: &pb.ResetPasswordRequest{
+				ResetId:     1,
+				SecretCode:  secretCode,
+				NewPassword: newPassword,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetResetPasswordByCodeReturns(db.ResetPassword{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResetPasswordResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+				require.Equal(t, 0, store.ResetPasswordTxCallCount())
+			},
+		},
+		{
+			name: "code used concurrently",
+			req: &pb.ResetPasswordRequest{
+				ResetId:     1,
+				SecretCode:  secretCode,
+				NewPassword: newPassword,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetResetPasswordByCodeReturns(resetPassword, nil)
+				store.GetUserReturns(user, nil)
+				store.ResetPasswordTxReturns(db.ResetPasswordTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResetPasswordResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "password contains username",
+			req: &pb.ResetPasswordRequest{
+				ResetId:     1,
+				SecretCode:  secretCode,
+				NewPassword: "Xy1!" + user.Username,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetResetPasswordByCodeReturns(resetPassword, nil)
+				store.GetUserReturns(user, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResetPasswordResponse, err error) {
+				requireFieldViolations(t, err, "new_password")
+				require.Equal(t, 0, store.ResetPasswordTxCallCount())
+			},
+		},
+		{
+			name: "password contains email",
+			req: &pb.ResetPasswordRequest{
+				ResetId:     1,
+				SecretCode:  secretCode,
+				NewPassword: "Xy1!" + user.Email,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetResetPasswordByCodeReturns(resetPassword, nil)
+				store.GetUserReturns(user, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResetPasswordResponse, err error) {
+				requireFieldViolations(t, err, "new_password")
+				require.Equal(t, 0, store.ResetPasswordTxCallCount())
+			},
+		},
+		{
+			name: "invalid request",
+			req: &pb.ResetPasswordRequest{
+				ResetId:     0,
+				SecretCode:  "short",
+				NewPassword: "ab",
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.ResetPasswordResponse, err error) {
+				requireFieldViolations(t, err, "reset_id", "secret_code", "new_password")
+				require.Equal(t, 0, store.ResetPasswordTxCallCount())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			res, err := server.ResetPassword(context.Background(), tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
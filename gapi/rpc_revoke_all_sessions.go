@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeAllSessions blocks every session of the authenticated user,
+// except keep_session_id when it is set
+func (s *Server) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRevokeAllSessionsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// uuid.Nil matches no session family
+	keepFamilyID := uuid.Nil
+	if req.KeepSessionId != nil {
+		session, err := s.userSession(ctx, authPayload.Username, req.GetKeepSessionId())
+		if err != nil {
+			return nil, err
+		}
+
+		keepFamilyID = session.FamilyID
+	}
+
+	err = s.store.BlockUserSessions(ctx, db.BlockUserSessionsParams{
+		Username:     authPayload.Username,
+		KeepFamilyID: keepFamilyID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "block user sessions: %s", err.Error())
+	}
+
+	return &pb.RevokeAllSessionsResponse{}, nil
+}
+
+func validateRevokeAllSessionsRequest(req *pb.RevokeAllSessionsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.KeepSessionId != nil {
+		err := validator.ValidateSessionID(req.GetKeepSessionId())
+		if err != nil {
+			violations = append(
+				violations,
+				fieldViolation("keep_session_id", err),
+			)
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,105 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/milhamh95/simplebank/db/fake"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRevokeAllSessionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	session := randomSession(user.Username)
+	keepSessionID := session.ID.String()
+
+	testCases := []struct {
+		name          string
+		req           *pb.RevokeAllSessionsRequest
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.RevokeAllSessionsResponse, err error)
+	}{
+		{
+			name:       "revoke all",
+			req:        &pb.RevokeAllSessionsRequest{},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.RevokeAllSessionsResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 0, store.GetSessionCallCount())
+
+				_, arg := store.BlockUserSessionsArgsForCall(0)
+				require.Equal(t, user.Username, arg.Username)
+				require.Equal(t, uuid.Nil, arg.KeepFamilyID)
+			},
+		},
+		{
+			name: "keep session",
+			req: &pb.RevokeAllSessionsRequest{
+				KeepSessionId: &keepSessionID,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetSessionReturns(session, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.RevokeAllSessionsResponse, err error) {
+				require.NoError(t, err)
+
+				_, arg := store.BlockUserSessionsArgsForCall(0)
+				require.Equal(t, user.Username, arg.Username)
+				require.Equal(t, session.FamilyID, arg.KeepFamilyID)
+			},
+		},
+		{
+			name: "keep session of other user",
+			req: &pb.RevokeAllSessionsRequest{
+				KeepSessionId: &keepSessionID,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetSessionReturns(session, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, otherUser.Username, otherUser.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.RevokeAllSessionsResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+				require.Equal(t, 0, store.BlockUserSessionsCallCount())
+			},
+		},
+		{
+			name:       "no authorization",
+			req:        &pb.RevokeAllSessionsRequest{},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.RevokeAllSessionsResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.BlockUserSessionsCallCount())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.RevokeAllSessions(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeSession blocks a session of the authenticated user,
+// including every refresh token renewed from it
+func (s *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	authPayload, err := s.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRevokeSessionRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	session, err := s.userSession(ctx, authPayload.Username, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.BlockSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "block session family: %s", err.Error())
+	}
+
+	return &pb.RevokeSessionResponse{}, nil
+}
+
+func validateRevokeSessionRequest(req *pb.RevokeSessionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateSessionID(req.GetSessionId())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("session_id", err),
+		)
+	}
+
+	return violations
+}
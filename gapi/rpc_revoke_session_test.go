@@ -0,0 +1,108 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRevokeSessionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	session := randomSession(user.Username)
+
+	testCases := []struct {
+		name          string
+		req           *pb.RevokeSessionRequest
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.RevokeSessionResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.RevokeSessionRequest{
+				SessionId: session.FamilyID.String(),
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetSessionReturns(session, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.RevokeSessionResponse, err error) {
+				require.NoError(t, err)
+
+				_, id := store.GetSessionArgsForCall(0)
+				require.Equal(t, session.FamilyID, id)
+
+				require.Equal(t, 1, store.BlockSessionFamilyCallCount())
+				_, familyID := store.BlockSessionFamilyArgsForCall(0)
+				require.Equal(t, session.FamilyID, familyID)
+			},
+		},
+		{
+			name: "session of other user",
+			req: &pb.RevokeSessionRequest{
+				SessionId: session.FamilyID.String(),
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetSessionReturns(session, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, otherUser.Username, otherUser.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.RevokeSessionResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+				require.Equal(t, 0, store.BlockSessionFamilyCallCount())
+			},
+		},
+		{
+			name: "session not found",
+			req: &pb.RevokeSessionRequest{
+				SessionId: session.FamilyID.String(),
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetSessionReturns(db.Session{}, sql.ErrNoRows)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.RevokeSessionResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "invalid session id",
+			req: &pb.RevokeSessionRequest{
+				SessionId: "invalid",
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.RevokeSessionResponse, err error) {
+				requireFieldViolations(t, err, "session_id")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.RevokeSession(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
@@ -8,12 +8,12 @@ import (
 
 	db "github.com/milhamh95/simplebank/db/sqlc"
 	"github.com/milhamh95/simplebank/pb"
-	"github.com/milhamh95/simplebank/pkg/password"
+	"github.com/milhamh95/simplebank/pkg/role"
 	"github.com/milhamh95/simplebank/pkg/validator"
+	"github.com/milhamh95/simplebank/worker"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
@@ -22,29 +22,50 @@ func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb
 		return nil, unauthenticatedError(err)
 	}
 
-	if authPayload.Username != req.GetUsername() {
+	if !canManageUser(authPayload, req.GetUsername()) {
 		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's info")
 	}
 
-	violations := validateUpdateUserRequest(req)
+	if req.Role != nil && !hasPermission(authPayload, role.Admin) {
+		return nil, status.Errorf(codes.PermissionDenied, "only admins can change user roles")
+	}
+
+	violations := validateUpdateUserRequest(req, s.passwordPolicy)
 	if violations != nil {
 		return nil, invalidArgumentError(violations)
 	}
 
+	// the stored email is needed to check the password and to notify it of an email change
+	var user db.User
+	if req.Password != nil || req.Email != nil {
+		user, err = s.store.GetUser(ctx, req.GetUsername())
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, status.Errorf(codes.NotFound, "user not found")
+			}
+			return nil, status.Errorf(codes.Internal, "find user: %s", err.Error())
+		}
+	}
+
 	arg := db.UpdateUserParams{
 		Username: req.GetUsername(),
 		FullName: sql.NullString{
 			String: req.GetFullName(),
 			Valid:  req.FullName != nil,
 		},
-		Email: sql.NullString{
-			String: req.GetEmail(),
-			Valid:  req.Email != nil,
+		Role: sql.NullString{
+			String: req.GetRole(),
+			Valid:  req.Role != nil,
 		},
 	}
 
 	if req.Password != nil {
-		hashedPassword, err := password.HashPassword(req.GetPassword())
+		err = s.validateUserPassword("password", req.GetPassword(), user)
+		if err != nil {
+			return nil, err
+		}
+
+		hashedPassword, err := s.passwordHasher.HashPassword(req.GetPassword())
 		if err != nil {
 			return nil, status.Errorf(
 				codes.Internal,
@@ -63,7 +84,23 @@ func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb
 		}
 	}
 
-	user, err := s.store.UpdateUser(ctx, arg)
+	txArg := db.UpdateUserTxParams{
+		UpdateUserParams: arg,
+	}
+
+	if req.Email != nil {
+		txArg.NewEmail = sql.NullString{
+			String: req.GetEmail(),
+			Valid:  true,
+		}
+
+		txArg.EmailChangeMessages, err = s.emailChangeMessages(ctx, user, req.GetEmail())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	txResult, err := s.store.UpdateUserTx(ctx, txArg)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.NotFound, "user not found")
@@ -72,19 +109,37 @@ func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb
 	}
 
 	rsp := &pb.UpdateUserResponse{
-		User: &pb.User{
-			Username:          user.Username,
-			FullName:          user.FullName,
-			Email:             user.Email,
-			PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
-			CreatedAt:         timestamppb.New(user.CreatedAt),
-		},
+		User: convertUser(txResult.User),
 	}
 
 	return rsp, nil
 }
 
-func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+// emailChangeMessages are the tasks of an email change, the new email is verified
+// and the current one is told about the change. They are only sent if the change starts
+func (s *Server) emailChangeMessages(ctx context.Context, user db.User, newEmail string) ([]db.CreateOutboxMessageParams, error) {
+	verifyEmailMsg, err := worker.NewOutboxMessage(worker.TaskSendVerifyEmail, &worker.PayloadSendVerifyEmail{
+		Username: user.Username,
+		Locale:   requestLocale(ctx),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create verify email task: %s", err.Error())
+	}
+
+	noticeMsg, err := worker.NewOutboxMessage(worker.TaskSendEmailChangeNotice, &worker.PayloadSendEmailChangeNotice{
+		Username: user.Username,
+		OldEmail: user.Email,
+		NewEmail: newEmail,
+		Locale:   requestLocale(ctx),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create email change notice task: %s", err.Error())
+	}
+
+	return []db.CreateOutboxMessageParams{verifyEmailMsg, noticeMsg}, nil
+}
+
+func validateUpdateUserRequest(req *pb.UpdateUserRequest, passwordPolicy *validator.PasswordPolicy) (violations []*errdetails.BadRequest_FieldViolation) {
 	err := validator.ValidateUsername(req.GetUsername())
 	if err != nil {
 		violations = append(
@@ -93,9 +148,9 @@ func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdeta
 		)
 	}
 
+	// the stored email is checked by validateUserPassword, the new one here
 	if req.Password != nil {
-		err = validator.ValidatePassword(req.GetPassword())
-		if err != nil {
+		for _, err := range passwordPolicy.Validate(req.GetPassword(), req.GetUsername(), req.GetEmail()) {
 			violations = append(
 				violations,
 				fieldViolation("password", err),
@@ -123,5 +178,15 @@ func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdeta
 		}
 	}
 
+	if req.Role != nil {
+		err = validator.ValidateRole(req.GetRole())
+		if err != nil {
+			violations = append(
+				violations,
+				fieldViolation("role", err),
+			)
+		}
+	}
+
 	return violations
 }
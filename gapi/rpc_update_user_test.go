@@ -0,0 +1,230 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/milhamh95/simplebank/worker"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateUserAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	admin.Role = role.Admin
+
+	newFullName := random.RandomOwner()
+	newRole := role.Banker
+	newEmail := random.RandomEmail()
+	newPassword := random.RandomString(8) + "Xy1!"
+	emailPassword := "Xy1!" + user.Email
+
+	testCases := []struct {
+		name          string
+		req           *pb.UpdateUserRequest
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.UpdateUserResponse, err error)
+	}{
+		{
+			name: "update self",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newFullName,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				updatedUser := user
+				updatedUser.FullName = newFullName
+				store.UpdateUserTxReturns(db.UpdateUserTxResult{User: updatedUser}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, newFullName, resp.GetUser().GetFullName())
+
+				_, arg := store.UpdateUserTxArgsForCall(0)
+				require.False(t, arg.Role.Valid)
+				require.False(t, arg.NewEmail.Valid)
+				require.Empty(t, arg.EmailChangeMessages)
+			},
+		},
+		{
+			name: "change email",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				Email:    &newEmail,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetUserReturns(user, nil)
+
+				updatedUser := user
+				updatedUser.PendingEmail = newEmail
+				store.UpdateUserTxReturns(db.UpdateUserTxResult{User: updatedUser, EmailChangeStarted: true}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				// the email only changes once the new one is verified
+				require.Equal(t, user.Email, resp.GetUser().GetEmail())
+				require.Equal(t, newEmail, resp.GetUser().GetPendingEmail())
+
+				_, arg := store.UpdateUserTxArgsForCall(0)
+				require.False(t, arg.Email.Valid)
+				require.True(t, arg.NewEmail.Valid)
+				require.Equal(t, newEmail, arg.NewEmail.String)
+
+				require.Len(t, arg.EmailChangeMessages, 2)
+				require.Equal(t, worker.TaskSendVerifyEmail, arg.EmailChangeMessages[0].TaskType)
+				require.Equal(t, worker.TaskSendEmailChangeNotice, arg.EmailChangeMessages[1].TaskType)
+
+				var notice worker.PayloadSendEmailChangeNotice
+				require.NoError(t, json.Unmarshal(arg.EmailChangeMessages[1].Payload, &notice))
+				require.Equal(t, user.Email, notice.OldEmail)
+				require.Equal(t, newEmail, notice.NewEmail)
+			},
+		},
+		{
+			name: "change password",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				Password: &newPassword,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetUserReturns(user, nil)
+				store.UpdateUserTxReturns(db.UpdateUserTxResult{User: user}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+
+				_, arg := store.UpdateUserTxArgsForCall(0)
+				require.True(t, arg.HashedPassword.Valid)
+				require.True(t, arg.PasswordChangedAt.Valid)
+			},
+		},
+		{
+			name: "password contains stored email",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				Password: &emailPassword,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetUserReturns(user, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.UpdateUserResponse, err error) {
+				requireFieldViolations(t, err, "password")
+				require.Equal(t, 0, store.UpdateUserTxCallCount())
+			},
+		},
+		{
+			name: "update other user",
+			req: &pb.UpdateUserRequest{
+				Username: otherUser.Username,
+				FullName: &newFullName,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.UpdateUserTxCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "depositor changes own role",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				Role:     &newRole,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.UpdateUserTxCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "admin changes other user's role",
+			req: &pb.UpdateUserRequest{
+				Username: otherUser.Username,
+				Role:     &newRole,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				updatedUser := otherUser
+				updatedUser.Role = newRole
+				store.UpdateUserTxReturns(db.UpdateUserTxResult{User: updatedUser}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, newRole, resp.GetUser().GetRole())
+
+				_, arg := store.UpdateUserTxArgsForCall(0)
+				require.Equal(t, otherUser.Username, arg.Username)
+				require.True(t, arg.Role.Valid)
+				require.Equal(t, newRole, arg.Role.String)
+			},
+		},
+		{
+			name: "invalid role",
+			req: &pb.UpdateUserRequest{
+				Username: otherUser.Username,
+				Role:     &newFullName,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.UpdateUserTxCallCount())
+				requireFieldViolations(t, err, "role")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.UpdateUser(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
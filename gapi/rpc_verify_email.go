@@ -2,6 +2,10 @@ package gapi
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
 	db "github.com/milhamh95/simplebank/db/sqlc"
 	"github.com/milhamh95/simplebank/pb"
 	"github.com/milhamh95/simplebank/pkg/validator"
@@ -21,7 +25,18 @@ func (s *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*
 		SecretCode: req.SecretCode,
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "verify email")
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return nil, status.Errorf(codes.AlreadyExists, "email is already used by another user")
+		}
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "verify code is invalid, used or expired")
+		case errors.Is(err, db.ErrVerifyEmailOutdated):
+			return nil, status.Errorf(codes.FailedPrecondition, "verify code belongs to an email that was replaced")
+		}
+
+		return nil, status.Errorf(codes.Internal, "verify email: %s", err.Error())
 	}
 
 	rsp := &pb.VerifyEmailResponse{
@@ -0,0 +1,89 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestVerifyEmailAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	user.IsEmailVerified = true
+
+	req := &pb.VerifyEmailRequest{
+		EmailId:    1,
+		SecretCode: random.RandomString(32),
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *fake.FakeStore)
+		checkResponse func(t *testing.T, resp *pb.VerifyEmailResponse, err error)
+	}{
+		{
+			name: "success",
+			buildStubs: func(store *fake.FakeStore) {
+				store.VerifyEmailTxReturns(db.VerifyEmailTxResult{User: user}, nil)
+			},
+			checkResponse: func(t *testing.T, resp *pb.VerifyEmailResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, resp.GetIsVerified())
+			},
+		},
+		{
+			name: "invalid code",
+			buildStubs: func(store *fake.FakeStore) {
+				store.VerifyEmailTxReturns(db.VerifyEmailTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, resp *pb.VerifyEmailResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "replaced email",
+			buildStubs: func(store *fake.FakeStore) {
+				store.VerifyEmailTxReturns(db.VerifyEmailTxResult{}, db.ErrVerifyEmailOutdated)
+			},
+			checkResponse: func(t *testing.T, resp *pb.VerifyEmailResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "email used by another user",
+			buildStubs: func(store *fake.FakeStore) {
+				store.VerifyEmailTxReturns(db.VerifyEmailTxResult{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, resp *pb.VerifyEmailResponse, err error) {
+				requireStatusCode(t, err, codes.AlreadyExists)
+			},
+		},
+		{
+			name: "internal error",
+			buildStubs: func(store *fake.FakeStore) {
+				store.VerifyEmailTxReturns(db.VerifyEmailTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, resp *pb.VerifyEmailResponse, err error) {
+				requireStatusCode(t, err, codes.Internal)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			resp, err := server.VerifyEmail(context.Background(), req)
+			tc.checkResponse(t, resp, err)
+		})
+	}
+}
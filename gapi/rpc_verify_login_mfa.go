@@ -0,0 +1,100 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/validator"
+	"github.com/milhamh95/simplebank/token"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) VerifyLoginMFA(ctx context.Context, req *pb.VerifyLoginMFARequest) (*pb.VerifyLoginMFAResponse, error) {
+	violations := validateVerifyLoginMFARequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	mfaPayload, err := s.tokenMaker.VerifyToken(req.GetMfaToken(), token.TokenTypeMFA)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid mfa token: %s", err.Error())
+	}
+
+	user, err := s.store.GetUser(ctx, mfaPayload.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "find user: %s", err.Error())
+	}
+
+	if !user.TotpEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "totp is not enabled")
+	}
+
+	loginMetadata, err := s.extractMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := loginLimits(user.Username, loginMetadata.ClientIP)
+	err = s.reserveLoginAttempt(ctx, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkMFACode(ctx, user, req.GetCode())
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			s.failLogin(ctx, limits)
+		} else {
+			s.refundLoginAttempt(ctx, limits)
+		}
+
+		return nil, err
+	}
+
+	s.refundLoginAttempt(ctx, limits)
+	s.resetLoginLimits(ctx, user.Username)
+
+	loginResp, err := s.createLoginSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.VerifyLoginMFAResponse{
+		User:                  loginResp.GetUser(),
+		SessionId:             loginResp.GetSessionId(),
+		AccessToken:           loginResp.GetAccessToken(),
+		AccessTokenExpiredAt:  loginResp.GetAccessTokenExpiredAt(),
+		RefreshToken:          loginResp.GetRefreshToken(),
+		RefreshTokenExpiredAt: loginResp.GetRefreshTokenExpiredAt(),
+	}
+
+	return resp, nil
+}
+
+func validateVerifyLoginMFARequest(req *pb.VerifyLoginMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	err := validator.ValidateMFAToken(req.GetMfaToken())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("mfa_token", err),
+		)
+	}
+
+	err = validator.ValidateMFACode(req.GetCode())
+	if err != nil {
+		violations = append(
+			violations,
+			fieldViolation("code", err),
+		)
+	}
+
+	return violations
+}
@@ -0,0 +1,208 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/limiter"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/totp"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestVerifyLoginMFAAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes(1)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		buildRequest  func(t *testing.T, server *Server) *pb.VerifyLoginMFARequest
+		buildStubs    func(store *fake.FakeStore)
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.VerifyLoginMFAResponse, err error)
+	}{
+		{
+			name: "success with totp code",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyLoginMFARequest {
+				return &pb.VerifyLoginMFARequest{
+					MfaToken: newMFAToken(t, server, user),
+					Code:     totpCode(t, secret),
+				}
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.VerifyLoginMFAResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, resp.GetAccessToken())
+				require.NotEmpty(t, resp.GetRefreshToken())
+				require.Equal(t, user.Username, resp.GetUser().GetUsername())
+
+				require.Equal(t, 0, store.UseRecoveryCodeCallCount())
+				require.Equal(t, 1, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "success with recovery code",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyLoginMFARequest {
+				return &pb.VerifyLoginMFARequest{
+					MfaToken: newMFAToken(t, server, user),
+					Code:     recoveryCodes[0],
+				}
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.UseRecoveryCodeReturns(db.RecoveryCode{Username: user.Username, IsUsed: true}, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.VerifyLoginMFAResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, resp.GetAccessToken())
+
+				require.Equal(t, 1, store.UseRecoveryCodeCallCount())
+				_, arg := store.UseRecoveryCodeArgsForCall(0)
+				require.Equal(t, user.Username, arg.Username)
+				require.Equal(t, totp.HashRecoveryCode(recoveryCodes[0]), arg.HashedCode)
+			},
+		},
+		{
+			name: "incorrect code",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyLoginMFARequest {
+				return &pb.VerifyLoginMFARequest{
+					MfaToken: newMFAToken(t, server, user),
+					Code:     recoveryCodes[0],
+				}
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.UseRecoveryCodeReturns(db.RecoveryCode{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.VerifyLoginMFAResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "reused totp code",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyLoginMFARequest {
+				return &pb.VerifyLoginMFARequest{
+					MfaToken: newMFAToken(t, server, user),
+					Code:     totpCode(t, secret),
+				}
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.UseTOTPStepReturns(0, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.VerifyLoginMFAResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "locked out",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyLoginMFARequest {
+				failLoginAttempts(t, server, user.Username, limiter.UserPolicy.LockoutAttempts)
+
+				return &pb.VerifyLoginMFARequest{
+					MfaToken: newMFAToken(t, server, user),
+					Code:     totpCode(t, secret),
+				}
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.VerifyLoginMFAResponse, err error) {
+				requireStatusCode(t, err, codes.ResourceExhausted)
+				require.Equal(t, 0, store.CreateSessionCallCount())
+			},
+		},
+		{
+			name: "access token is not a mfa token",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyLoginMFARequest {
+				accessToken, _, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeAccess, time.Minute)
+				require.NoError(t, err)
+
+				return &pb.VerifyLoginMFARequest{
+					MfaToken: accessToken,
+					Code:     totpCode(t, secret),
+				}
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.VerifyLoginMFAResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+				require.Equal(t, 0, store.GetUserCallCount())
+			},
+		},
+		{
+			name: "totp not enabled",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyLoginMFARequest {
+				return &pb.VerifyLoginMFARequest{
+					MfaToken: newMFAToken(t, server, user),
+					Code:     totpCode(t, secret),
+				}
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetUserReturns(user, nil)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.VerifyLoginMFAResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "invalid argument",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyLoginMFARequest {
+				return &pb.VerifyLoginMFARequest{
+					MfaToken: "",
+					Code:     "123",
+				}
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.VerifyLoginMFAResponse, err error) {
+				requireFieldViolations(t, err, "mfa_token", "code")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			fakeStore.UseTOTPStepReturns(1, nil)
+			server := newTestServer(t, fakeStore, nil)
+
+			fakeStore.GetUserReturns(totpUser(t, server, user, secret), nil)
+			tc.buildStubs(fakeStore)
+
+			req := tc.buildRequest(t, server)
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+			res, err := server.VerifyLoginMFA(ctx, req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
+
+func newMFAToken(t *testing.T, server *Server, user db.User) string {
+	mfaToken, _, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeMFA, mfaTokenDuration)
+	require.NoError(t, err)
+
+	return mfaToken
+}
+
+// totpUser returns the user with TOTP enabled for the secret
+func totpUser(t *testing.T, server *Server, user db.User, secret string) db.User {
+	encryptedSecret, err := server.totpEncryptor.Encrypt(secret)
+	require.NoError(t, err)
+
+	user.TotpSecret = encryptedSecret
+	user.TotpEnabled = true
+	return user
+}
+
+func totpCode(t *testing.T, secret string) string {
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	return code
+}
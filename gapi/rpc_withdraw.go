@@ -0,0 +1,23 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/milhamh95/simplebank/pb"
+)
+
+// Withdraw moves cash out of an account into the bank vault.
+// Only bankers and admins can handle cash
+func (s *Server) Withdraw(ctx context.Context, req *pb.WithdrawRequest) (*pb.WithdrawResponse, error) {
+	txResult, err := s.handleCash(ctx, req, s.store.WithdrawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.WithdrawResponse{
+		Account: convertAccount(txResult.Account),
+		Entry:   convertEntry(txResult.Entry),
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,146 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pb"
+	"github.com/milhamh95/simplebank/pkg/currency"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/milhamh95/simplebank/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithdrawAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = role.Admin
+	depositor, _ := randomUser(t)
+
+	account := randomAccount(depositor.Username)
+	account.Currency = currency.USD
+	amount := int64(10)
+
+	testCases := []struct {
+		name          string
+		req           *pb.WithdrawRequest
+		buildStubs    func(store *fake.FakeStore)
+		buildContext  func(t *testing.T, tokenMaker token.Tokener) context.Context
+		checkResponse func(t *testing.T, store *fake.FakeStore, resp *pb.WithdrawResponse, err error)
+	}{
+		{
+			name: "success",
+			req: &pb.WithdrawRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+
+				withdrawnAccount := account
+				withdrawnAccount.Balance -= amount
+				store.WithdrawTxReturns(db.CashTxResult{
+					Account: withdrawnAccount,
+					Entry: db.Entry{
+						ID:        1,
+						AccountID: account.ID,
+						Amount:    -amount,
+					},
+				}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.WithdrawResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+
+				require.Equal(t, 1, store.WithdrawTxCallCount())
+				_, arg := store.WithdrawTxArgsForCall(0)
+				require.Equal(t, account.ID, arg.AccountID)
+				require.Equal(t, amount, arg.Amount)
+
+				require.Equal(t, account.Balance-amount, resp.GetAccount().GetBalance())
+				require.Equal(t, -amount, resp.GetEntry().GetAmount())
+			},
+		},
+		{
+			name: "depositor can't handle cash",
+			req: &pb.WithdrawRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor.Username, depositor.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.WithdrawResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.WithdrawTxCallCount())
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "currency mismatch",
+			req: &pb.WithdrawRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  currency.EUR,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.WithdrawResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.WithdrawTxCallCount())
+				requireFieldViolations(t, err, "account_id")
+			},
+		},
+		{
+			name: "insufficient funds",
+			req: &pb.WithdrawRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+				Currency:  currency.USD,
+			},
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetAccountReturns(account, nil)
+				store.WithdrawTxReturns(db.CashTxResult{}, db.ErrInsufficientFunds)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Tokener) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, admin.Username, admin.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, store *fake.FakeStore, resp *pb.WithdrawResponse, err error) {
+				require.Error(t, err)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeStore := &fake.FakeStore{}
+			tc.buildStubs(fakeStore)
+
+			server := newTestServer(t, fakeStore, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.Withdraw(ctx, tc.req)
+			tc.checkResponse(t, fakeStore, res, err)
+		})
+	}
+}
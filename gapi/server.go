@@ -2,6 +2,15 @@ package gapi
 
 import (
 	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/milhamh95/simplebank/denylist"
+	"github.com/milhamh95/simplebank/limiter"
+	"github.com/milhamh95/simplebank/pkg/encryptor"
+	"github.com/milhamh95/simplebank/pkg/password"
+	"github.com/milhamh95/simplebank/pkg/validator"
 	"github.com/milhamh95/simplebank/worker"
 
 	"github.com/gin-gonic/gin"
@@ -18,18 +27,55 @@ type Server struct {
 	store           db.Store
 	tokenMaker      token.Tokener
 	taskDistributor worker.TaskDistributor
+	totpEncryptor   *encryptor.Encryptor
+	loginLimiter    limiter.LoginLimiter
+	tokenDenylist   denylist.TokenDenylist
+	passwordHasher  password.Hasher
+	passwordPolicy  *validator.PasswordPolicy
+	trustedProxies  []*net.IPNet
+	// dummyHashedPassword is hashed on the first login of an unknown user
+	dummyHashedPasswordOnce sync.Once
+	dummyHashedPassword     string
 }
 
-func NewServer(cfg config.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
-	tokenMaker, err := token.NewPaseto(cfg.TokenSymmetricKey)
+const mfaTokenDuration = 5 * time.Minute
+
+func NewServer(cfg config.Config, store db.Store, taskDistributor worker.TaskDistributor, loginLimiter limiter.LoginLimiter, tokenDenylist denylist.TokenDenylist) (*Server, error) {
+	tokenMaker, err := token.NewTokener(cfg.TokenFormat, cfg.TokenSymmetricKey, cfg.TokenPrivateKeyFile, cfg.TokenPublicKeyFiles)
 	if err != nil {
 		return nil, fmt.Errorf("init token maker: %w", err)
 	}
+
+	totpEncryptor, err := encryptor.NewEncryptor(cfg.TOTPEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("init totp encryptor: %w", err)
+	}
+
+	passwordPolicy, err := newPasswordPolicy(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("init password policy: %w", err)
+	}
+
+	trustedProxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("init trusted proxies: %w", err)
+	}
+
 	server := &Server{
 		cfg:             cfg,
 		store:           store,
 		tokenMaker:      tokenMaker,
 		taskDistributor: taskDistributor,
+		totpEncryptor:   totpEncryptor,
+		loginLimiter:    loginLimiter,
+		tokenDenylist:   tokenDenylist,
+		passwordHasher: password.NewArgon2idHasher(password.Argon2idParams{
+			Memory:      cfg.PasswordArgon2Memory,
+			Iterations:  cfg.PasswordArgon2Iterations,
+			Parallelism: cfg.PasswordArgon2Parallelism,
+		}),
+		passwordPolicy: passwordPolicy,
+		trustedProxies: trustedProxies,
 	}
 
 	return server, nil
@@ -38,3 +84,13 @@ func NewServer(cfg config.Config, store db.Store, taskDistributor worker.TaskDis
 func errResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
+
+func newPasswordPolicy(cfg config.Config) (*validator.PasswordPolicy, error) {
+	policy := validator.PasswordPolicy{
+		MinLength:      cfg.PasswordMinLength,
+		MinCharClasses: cfg.PasswordMinCharClasses,
+		MaxRepeated:    cfg.PasswordMaxRepeated,
+	}
+
+	return validator.LoadPasswordPolicy(policy, cfg.PasswordBreachedFile)
+}
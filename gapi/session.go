@@ -0,0 +1,78 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// refreshSession verifies the refresh token and returns the session it was issued for.
+// It rejects sessions that belong to another user or store another token,
+// callers still have to check whether the session is blocked or expired
+func (s *Server) refreshSession(ctx context.Context, refreshToken string) (*token.Payload, db.Session, error) {
+	refreshPayload, err := s.tokenMaker.VerifyToken(refreshToken, token.TokenTypeRefresh)
+	if err != nil {
+		return nil, db.Session{}, status.Errorf(codes.Unauthenticated, "invalid refresh token: %s", err.Error())
+	}
+
+	session, err := s.store.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, session, status.Errorf(codes.NotFound, "session not found")
+		}
+
+		return nil, session, status.Errorf(codes.Internal, "get session: %s", err.Error())
+	}
+
+	if session.Username != refreshPayload.Username {
+		return nil, session, status.Errorf(codes.Unauthenticated, "incorrect session user")
+	}
+
+	if session.RefreshToken != refreshToken {
+		return nil, session, status.Errorf(codes.Unauthenticated, "mismatched session token")
+	}
+
+	return refreshPayload, session, nil
+}
+
+// blockReusedSessionFamily blocks every session of the family after a rotated
+// refresh token was presented again. Either the user or an attacker holds a stolen copy,
+// so both have to log in again
+func (s *Server) blockReusedSessionFamily(ctx context.Context, session db.Session) error {
+	err := s.store.BlockSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "block session family: %s", err.Error())
+	}
+
+	return status.Errorf(codes.Unauthenticated, "refresh token was already used")
+}
+
+// userSession returns the session with the given id if it belongs to the user.
+// Sessions of other users are reported as not found
+func (s *Server) userSession(ctx context.Context, username string, sessionID string) (db.Session, error) {
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return db.Session{}, status.Errorf(codes.NotFound, "session not found")
+	}
+
+	session, err := s.store.GetSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return session, status.Errorf(codes.NotFound, "session not found")
+		}
+
+		return session, status.Errorf(codes.Internal, "get session: %s", err.Error())
+	}
+
+	if session.Username != username {
+		return db.Session{}, status.Errorf(codes.NotFound, "session not found")
+	}
+
+	return session, nil
+}
@@ -0,0 +1,61 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/pkg/totp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const totpIssuer = "Simple Bank"
+
+// checkMFACode accepts a valid TOTP code or an unused recovery code of the user.
+// A recovery code is consumed, so it can be used only once
+func (s *Server) checkMFACode(ctx context.Context, user db.User, code string) error {
+	secret, err := s.totpEncryptor.Decrypt(user.TotpSecret)
+	if err != nil {
+		return status.Errorf(codes.Internal, "decrypt totp secret: %s", err.Error())
+	}
+
+	step, ok := totp.ValidateStep(code, secret, time.Now())
+	if ok {
+		return s.useTOTPStep(ctx, user.Username, step)
+	}
+
+	_, err = s.store.UseRecoveryCode(ctx, db.UseRecoveryCodeParams{
+		Username:   user.Username,
+		HashedCode: totp.HashRecoveryCode(code),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Errorf(codes.Unauthenticated, "incorrect code")
+		}
+
+		return status.Errorf(codes.Internal, "use recovery code: %s", err.Error())
+	}
+
+	return nil
+}
+
+// useTOTPStep stores the time step of an accepted TOTP code. A code of the same
+// or an earlier step is rejected, so an observed code can't be replayed (RFC 6238 section 5.2)
+func (s *Server) useTOTPStep(ctx context.Context, username string, step int64) error {
+	rows, err := s.store.UseTOTPStep(ctx, db.UseTOTPStepParams{
+		Username: username,
+		Step:     step,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "use totp step: %s", err.Error())
+	}
+
+	if rows == 0 {
+		return status.Errorf(codes.Unauthenticated, "code was already used")
+	}
+
+	return nil
+}
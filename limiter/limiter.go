@@ -0,0 +1,87 @@
+package limiter
+
+import (
+	"context"
+	"time"
+)
+
+// LoginLimiter counts failed login attempts per key, e.g. a username or a client ip,
+// and tells how long the key has to wait before its next attempt.
+// An attempt is reserved before the credentials are checked, it counts as failed
+// until it is refunded, so concurrent attempts can't get past LockoutAttempts
+type LoginLimiter interface {
+	// Wait returns how long the key is still blocked, zero if it can try again
+	Wait(ctx context.Context, key string) (time.Duration, error)
+	// Reserve counts an attempt of the key and returns zero if it can be made.
+	// Otherwise it returns how long the key is blocked, an attempt of a blocked key isn't counted
+	Reserve(ctx context.Context, key string, policy Policy) (time.Duration, error)
+	// Fail blocks the key for the delay of its counted attempts and returns it.
+	// A failure without a reserved attempt is counted
+	Fail(ctx context.Context, key string, policy Policy) (time.Duration, error)
+	// Refund forgets a reserved attempt that succeeded
+	Refund(ctx context.Context, key string) error
+	// Reset forgets the failed attempts of the key
+	Reset(ctx context.Context, key string) error
+}
+
+// Policy decides how long a key is blocked after a number of failed attempts.
+// The first FreeAttempts failures are not delayed, the next ones wait BaseDelay
+// doubled on every failure up to MaxDelay. After LockoutAttempts failures the key
+// is locked out for LockoutDuration. Failures are forgotten after LockoutDuration
+// without any new failure
+type Policy struct {
+	FreeAttempts    int64
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutAttempts int64
+	LockoutDuration time.Duration
+}
+
+var (
+	// UserPolicy protects a single account from password guessing
+	UserPolicy = Policy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		LockoutAttempts: 10,
+		LockoutDuration: 15 * time.Minute,
+	}
+
+	// IPPolicy is looser, many users may share the ip of a proxy or a NAT
+	IPPolicy = Policy{
+		FreeAttempts:    20,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		LockoutAttempts: 100,
+		LockoutDuration: 15 * time.Minute,
+	}
+)
+
+// Delay returns how long a key is blocked after the given number of failed attempts
+func (p Policy) Delay(failures int64) time.Duration {
+	if failures >= p.LockoutAttempts {
+		return p.LockoutDuration
+	}
+
+	if failures <= p.FreeAttempts {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+
+	return delay
+}
+
+func UserKey(username string) string {
+	return "user:" + username
+}
+
+func IPKey(ip string) string {
+	return "ip:" + ip
+}
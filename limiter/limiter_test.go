@@ -0,0 +1,187 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = Policy{
+	FreeAttempts:    2,
+	BaseDelay:       time.Second,
+	MaxDelay:        5 * time.Second,
+	LockoutAttempts: 8,
+	LockoutDuration: time.Minute,
+}
+
+func TestPolicyDelay(t *testing.T) {
+	expected := []time.Duration{
+		0,
+		0,
+		0,
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		5 * time.Second,
+		5 * time.Second,
+		time.Minute,
+		time.Minute,
+	}
+
+	for failures, delay := range expected {
+		require.Equal(t, delay, testPolicy.Delay(int64(failures)), "failures: %d", failures)
+	}
+}
+
+func TestMemoryLoginLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	l := NewMemoryLoginLimiter()
+	l.now = func() time.Time { return now }
+
+	key := UserKey("alice")
+
+	for i := 0; i < 2; i++ {
+		delay := failAttempt(t, l, key)
+		require.Zero(t, delay)
+	}
+
+	delay := failAttempt(t, l, key)
+	require.Equal(t, time.Second, delay)
+
+	wait, err := l.Wait(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, time.Second, wait)
+
+	// other keys are not affected
+	wait, err = l.Wait(ctx, IPKey("127.0.0.1"))
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	now = now.Add(time.Second)
+	wait, err = l.Wait(ctx, key)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	require.NoError(t, l.Reset(ctx, key))
+	delay = failAttempt(t, l, key)
+	require.Zero(t, delay)
+}
+
+func TestMemoryLoginLimiterLockout(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	l := NewMemoryLoginLimiter()
+	l.now = func() time.Time { return now }
+
+	key := UserKey("alice")
+
+	var delay time.Duration
+	for i := int64(0); i < testPolicy.LockoutAttempts; i++ {
+		// wait out the backoff of the previous failure
+		now = now.Add(delay)
+		delay = failAttempt(t, l, key)
+	}
+	require.Equal(t, testPolicy.LockoutDuration, delay)
+
+	now = now.Add(testPolicy.LockoutDuration - time.Second)
+	wait, err := l.Wait(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, time.Second, wait)
+
+	// the failures are forgotten once the lockout is over
+	now = now.Add(time.Second)
+	delay = failAttempt(t, l, key)
+	require.Zero(t, delay)
+}
+
+func TestMemoryLoginLimiterConcurrentReserve(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	l := NewMemoryLoginLimiter()
+	l.now = func() time.Time { return now }
+
+	key := UserKey("alice")
+
+	// a burst reserves its attempts before any of them fails,
+	// only LockoutAttempts of them get to check the password
+	allowed := 0
+	for i := int64(0); i < 2*testPolicy.LockoutAttempts; i++ {
+		wait, err := l.Reserve(ctx, key, testPolicy)
+		require.NoError(t, err)
+
+		if wait == 0 {
+			allowed++
+		}
+	}
+	require.Equal(t, int(testPolicy.LockoutAttempts), allowed)
+
+	wait, err := l.Wait(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, testPolicy.LockoutDuration, wait)
+}
+
+func TestMemoryLoginLimiterRefund(t *testing.T) {
+	ctx := context.Background()
+
+	l := NewMemoryLoginLimiter()
+	key := IPKey("127.0.0.1")
+
+	// successful attempts don't add up to a block
+	for i := int64(0); i <= testPolicy.LockoutAttempts; i++ {
+		wait, err := l.Reserve(ctx, key, testPolicy)
+		require.NoError(t, err)
+		require.Zero(t, wait)
+
+		require.NoError(t, l.Refund(ctx, key))
+	}
+
+	delay := failAttempt(t, l, key)
+	require.Zero(t, delay)
+}
+
+func TestRedisLoginLimiterFallback(t *testing.T) {
+	ctx := context.Background()
+
+	// nothing listens on port 1, every redis call fails
+	client := redis.NewClient(&redis.Options{
+		Addr:       "127.0.0.1:1",
+		MaxRetries: -1,
+	})
+	defer client.Close()
+
+	l := NewRedisLoginLimiter(client)
+	key := UserKey("alice")
+
+	var delay time.Duration
+	for i := int64(0); i <= testPolicy.FreeAttempts; i++ {
+		delay = failAttempt(t, l, key)
+	}
+	require.Equal(t, testPolicy.BaseDelay, delay)
+
+	wait, err := l.Wait(ctx, key)
+	require.NoError(t, err)
+	require.NotZero(t, wait)
+
+	wait, err = l.Reserve(ctx, key, testPolicy)
+	require.NoError(t, err)
+	require.NotZero(t, wait)
+}
+
+// failAttempt reserves an attempt of the key and records it failed
+func failAttempt(t *testing.T, l LoginLimiter, key string) time.Duration {
+	wait, err := l.Reserve(context.Background(), key, testPolicy)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	delay, err := l.Fail(context.Background(), key, testPolicy)
+	require.NoError(t, err)
+
+	return delay
+}
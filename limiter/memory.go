@@ -0,0 +1,156 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	failures     int64
+	blockedUntil time.Time
+	expiresAt    time.Time
+}
+
+// MemoryLoginLimiter keeps the counters in the process memory.
+// They are lost on restart and are not shared between servers
+type MemoryLoginLimiter struct {
+	now       func() time.Time
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+// sweepInterval is how often expired counters are removed
+const sweepInterval = time.Minute
+
+func NewMemoryLoginLimiter() *MemoryLoginLimiter {
+	return &MemoryLoginLimiter{
+		now:     time.Now,
+		entries: make(map[string]*memoryEntry),
+	}
+}
+
+func (l *MemoryLoginLimiter) Wait(ctx context.Context, key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := l.entry(key)
+	if entry == nil {
+		return 0, nil
+	}
+
+	return waitUntil(entry.blockedUntil, l.now()), nil
+}
+
+func (l *MemoryLoginLimiter) Reserve(ctx context.Context, key string, policy Policy) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	entry := l.entry(key)
+	if entry != nil {
+		if wait := waitUntil(entry.blockedUntil, now); wait > 0 {
+			return wait, nil
+		}
+	} else {
+		l.removeExpired(now)
+
+		entry = &memoryEntry{}
+		l.entries[key] = entry
+	}
+
+	entry.failures++
+	entry.expiresAt = now.Add(policy.LockoutDuration)
+
+	if entry.failures > policy.LockoutAttempts {
+		entry.blockedUntil = now.Add(policy.LockoutDuration)
+		return policy.LockoutDuration, nil
+	}
+
+	return 0, nil
+}
+
+func (l *MemoryLoginLimiter) Fail(ctx context.Context, key string, policy Policy) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	entry := l.entry(key)
+	if entry == nil {
+		l.removeExpired(now)
+
+		entry = &memoryEntry{failures: 1}
+		l.entries[key] = entry
+	}
+
+	entry.expiresAt = now.Add(policy.LockoutDuration)
+
+	delay := policy.Delay(entry.failures)
+	if blockedUntil := now.Add(delay); blockedUntil.After(entry.blockedUntil) {
+		entry.blockedUntil = blockedUntil
+	}
+
+	return delay, nil
+}
+
+func (l *MemoryLoginLimiter) Refund(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := l.entry(key)
+	if entry == nil {
+		return nil
+	}
+
+	entry.failures--
+	if entry.failures <= 0 {
+		delete(l.entries, key)
+	}
+
+	return nil
+}
+
+func (l *MemoryLoginLimiter) Reset(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.entries, key)
+	return nil
+}
+
+// entry returns the counters of the key, nil if there are none or they expired
+func (l *MemoryLoginLimiter) entry(key string) *memoryEntry {
+	entry, ok := l.entries[key]
+	if !ok {
+		return nil
+	}
+
+	if !l.now().Before(entry.expiresAt) {
+		delete(l.entries, key)
+		return nil
+	}
+
+	return entry
+}
+
+func (l *MemoryLoginLimiter) removeExpired(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, entry := range l.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(l.entries, key)
+		}
+	}
+}
+
+func waitUntil(blockedUntil time.Time, now time.Time) time.Duration {
+	if wait := blockedUntil.Sub(now); wait > 0 {
+		return wait
+	}
+
+	return 0
+}
@@ -0,0 +1,177 @@
+package limiter
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+)
+
+const redisKeyPrefix = "login_limiter:"
+
+// RedisLoginLimiter shares the counters between every server.
+// When redis fails it falls back to counting in memory,
+// so an outage doesn't turn off the protection
+type RedisLoginLimiter struct {
+	client   *redis.Client
+	fallback *MemoryLoginLimiter
+}
+
+func NewRedisLoginLimiter(client *redis.Client) *RedisLoginLimiter {
+	return &RedisLoginLimiter{
+		client:   client,
+		fallback: NewMemoryLoginLimiter(),
+	}
+}
+
+func (l *RedisLoginLimiter) Wait(ctx context.Context, key string) (time.Duration, error) {
+	wait, err := l.client.PTTL(ctx, blockKey(key)).Result()
+	if err != nil {
+		log.Warn().Err(err).Msg("login limiter: redis wait, use memory fallback")
+		return l.fallback.Wait(ctx, key)
+	}
+
+	// PTTL returns a negative duration when the key doesn't exist
+	if wait < 0 {
+		wait = 0
+	}
+
+	fallbackWait, err := l.fallback.Wait(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	if fallbackWait > wait {
+		return fallbackWait, nil
+	}
+
+	return wait, nil
+}
+
+// reserveScript counts the attempt unless the key is blocked, in a single step,
+// so concurrent attempts all see the count of each other.
+// KEYS[1] is the failures key, KEYS[2] the block key, ARGV[1] the lockout duration
+// in milliseconds and ARGV[2] the lockout attempts. It returns the wait in milliseconds
+var reserveScript = redis.NewScript(`
+local wait = redis.call('PTTL', KEYS[2])
+if wait > 0 then
+  return wait
+end
+
+local failures = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[1])
+if failures > tonumber(ARGV[2]) then
+  redis.call('SET', KEYS[2], 1, 'PX', ARGV[1])
+  return tonumber(ARGV[1])
+end
+
+return 0
+`)
+
+// failScript returns the failures of the key, a failure without a reserved attempt is counted.
+// KEYS[1] is the failures key and ARGV[1] the lockout duration in milliseconds
+var failScript = redis.NewScript(`
+local failures = tonumber(redis.call('GET', KEYS[1]) or '0')
+if failures == 0 then
+  failures = redis.call('INCR', KEYS[1])
+end
+
+redis.call('PEXPIRE', KEYS[1], ARGV[1])
+return failures
+`)
+
+// blockScript blocks the key for ARGV[1] milliseconds unless it is blocked for longer
+var blockScript = redis.NewScript(`
+if redis.call('PTTL', KEYS[1]) < tonumber(ARGV[1]) then
+  redis.call('SET', KEYS[1], 1, 'PX', ARGV[1])
+end
+
+return 0
+`)
+
+// refundScript forgets one attempt of the failures key KEYS[1]
+var refundScript = redis.NewScript(`
+if redis.call('DECR', KEYS[1]) <= 0 then
+  redis.call('DEL', KEYS[1])
+end
+
+return 0
+`)
+
+func (l *RedisLoginLimiter) Reserve(ctx context.Context, key string, policy Policy) (time.Duration, error) {
+	fallbackWait, err := l.fallback.Wait(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	if fallbackWait > 0 {
+		return fallbackWait, nil
+	}
+
+	wait, err := reserveScript.Run(
+		ctx,
+		l.client,
+		[]string{failuresKey(key), blockKey(key)},
+		policy.LockoutDuration.Milliseconds(),
+		policy.LockoutAttempts,
+	).Int64()
+	if err != nil {
+		log.Warn().Err(err).Msg("login limiter: redis reserve, use memory fallback")
+		return l.fallback.Reserve(ctx, key, policy)
+	}
+
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+func (l *RedisLoginLimiter) Fail(ctx context.Context, key string, policy Policy) (time.Duration, error) {
+	failures, err := failScript.Run(
+		ctx,
+		l.client,
+		[]string{failuresKey(key)},
+		policy.LockoutDuration.Milliseconds(),
+	).Int64()
+	if err != nil {
+		log.Warn().Err(err).Msg("login limiter: redis fail, use memory fallback")
+		return l.fallback.Fail(ctx, key, policy)
+	}
+
+	delay := policy.Delay(failures)
+	if delay == 0 {
+		return 0, nil
+	}
+
+	err = blockScript.Run(ctx, l.client, []string{blockKey(key)}, delay.Milliseconds()).Err()
+	if err != nil {
+		log.Warn().Err(err).Msg("login limiter: redis block, use memory fallback")
+		return l.fallback.Fail(ctx, key, policy)
+	}
+
+	return delay, nil
+}
+
+func (l *RedisLoginLimiter) Refund(ctx context.Context, key string) error {
+	err := l.fallback.Refund(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	return refundScript.Run(ctx, l.client, []string{failuresKey(key)}).Err()
+}
+
+func (l *RedisLoginLimiter) Reset(ctx context.Context, key string) error {
+	err := l.fallback.Reset(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	return l.client.Del(ctx, failuresKey(key), blockKey(key)).Err()
+}
+
+func failuresKey(key string) string {
+	return redisKeyPrefix + "failures:" + key
+}
+
+func blockKey(key string) string {
+	return redisKeyPrefix + "block:" + key
+}
@@ -12,12 +12,14 @@ const (
 )
 
 type EmailContent struct {
-	Subject     string
-	Content     string
-	To          []string
-	CC          []string
-	BCC         []string
-	AttachFiles []string
+	Subject string
+	// Content is the HTML body, PlainContent the optional plain text alternative
+	Content      string
+	PlainContent string
+	To           []string
+	CC           []string
+	BCC          []string
+	AttachFiles  []string
 }
 
 type EmailSender interface {
@@ -43,6 +45,10 @@ func (s GmailSender) SendEmail(emailContent EmailContent) error {
 	email.From = fmt.Sprintf("%s <%s>", s.name, s.fromEmailAddress)
 	email.Subject = emailContent.Subject
 	email.HTML = []byte(emailContent.Content)
+	// with both bodies the email is sent as multipart/alternative
+	if emailContent.PlainContent != "" {
+		email.Text = []byte(emailContent.PlainContent)
+	}
 	email.To = emailContent.To
 	email.Cc = emailContent.CC
 	email.Bcc = emailContent.BCC
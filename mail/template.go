@@ -0,0 +1,214 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// DefaultLocale is used when a message isn't translated to the locale of the user
+const DefaultLocale = "en"
+
+// Message is the type of a transactional email, it names the templates
+// templates/<locale>/<message>.html and templates/<locale>/<message>.txt.
+// The text template defines the "subject" template
+type Message string
+
+const (
+	MessageVerifyEmail       Message = "verify_email"
+	MessageResetPassword     Message = "reset_password"
+	MessageLoginLink         Message = "login_link"
+	MessageEmailChangeNotice Message = "email_change_notice"
+)
+
+var messages = []Message{
+	MessageVerifyEmail,
+	MessageResetPassword,
+	MessageLoginLink,
+	MessageEmailChangeNotice,
+}
+
+type VerifyEmailData struct {
+	Username string
+	// NewEmail is true when the email verifies an email change instead of a signup
+	NewEmail  bool
+	VerifyURL string
+}
+
+type ResetPasswordData struct {
+	Username   string
+	ResetID    int64
+	SecretCode string
+}
+
+type LoginLinkData struct {
+	Username string
+	LoginURL string
+}
+
+type EmailChangeNoticeData struct {
+	Username string
+	NewEmail string
+}
+
+type messageTemplates struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// Renderer renders the embedded templates of the transactional emails
+type Renderer struct {
+	baseURL   *url.URL
+	templates map[string]map[Message]messageTemplates
+}
+
+// NewRenderer parses the embedded templates. publicBaseURL is the address
+// of the HTTP gateway the links in the emails point to, e.g. https://bank.example.com
+func NewRenderer(publicBaseURL string) (*Renderer, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(publicBaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parse public base url: %w", err)
+	}
+
+	if (baseURL.Scheme != "http" && baseURL.Scheme != "https") || baseURL.Host == "" {
+		return nil, fmt.Errorf("public base url %q must be an absolute http or https url", publicBaseURL)
+	}
+
+	entries, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("read templates: %w", err)
+	}
+
+	renderer := &Renderer{
+		baseURL:   baseURL,
+		templates: make(map[string]map[Message]messageTemplates),
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		locale := entry.Name()
+		renderer.templates[locale], err = parseLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, message := range messages {
+		if _, ok := renderer.templates[DefaultLocale][message]; !ok {
+			return nil, fmt.Errorf("message %s has no %s template", message, DefaultLocale)
+		}
+	}
+
+	return renderer, nil
+}
+
+func parseLocale(locale string) (map[Message]messageTemplates, error) {
+	funcs := map[string]interface{}{
+		"locale": func() string { return locale },
+	}
+
+	localeTemplates := make(map[Message]messageTemplates)
+	for _, message := range messages {
+		htmlFile := path.Join("templates", locale, string(message)+".html")
+		textFile := path.Join("templates", locale, string(message)+".txt")
+
+		_, err := fs.Stat(templateFS, htmlFile)
+		if err != nil {
+			// the message isn't translated, DefaultLocale is used
+			continue
+		}
+
+		html, err := htmltemplate.New("layout.html").
+			Funcs(funcs).
+			Option("missingkey=error").
+			ParseFS(templateFS, "templates/layout.html", htmlFile)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", htmlFile, err)
+		}
+
+		text, err := texttemplate.New(path.Base(textFile)).
+			Option("missingkey=error").
+			ParseFS(templateFS, textFile)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", textFile, err)
+		}
+
+		if text.Lookup("subject") == nil {
+			return nil, fmt.Errorf("%s doesn't define the subject", textFile)
+		}
+
+		localeTemplates[message] = messageTemplates{
+			html: html,
+			text: text,
+		}
+	}
+
+	return localeTemplates, nil
+}
+
+// Render renders the subject, the HTML and the plain text content of the message.
+// locale can be an Accept-Language header, the first language is used
+// and DefaultLocale when the message isn't translated to it
+func (r *Renderer) Render(message Message, locale string, data interface{}) (EmailContent, error) {
+	tmpl, ok := r.templates[normalizeLocale(locale)][message]
+	if !ok {
+		tmpl, ok = r.templates[DefaultLocale][message]
+		if !ok {
+			return EmailContent{}, fmt.Errorf("unknown message %s", message)
+		}
+	}
+
+	var subject, html, text bytes.Buffer
+
+	err := tmpl.text.ExecuteTemplate(&subject, "subject", data)
+	if err != nil {
+		return EmailContent{}, fmt.Errorf("render %s subject: %w", message, err)
+	}
+
+	err = tmpl.text.Execute(&text, data)
+	if err != nil {
+		return EmailContent{}, fmt.Errorf("render %s text: %w", message, err)
+	}
+
+	err = tmpl.html.Execute(&html, data)
+	if err != nil {
+		return EmailContent{}, fmt.Errorf("render %s html: %w", message, err)
+	}
+
+	return EmailContent{
+		Subject:      strings.TrimSpace(subject.String()),
+		Content:      html.String(),
+		PlainContent: strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
+
+// Link returns the absolute url of a path of the HTTP gateway
+func (r *Renderer) Link(urlPath string, query url.Values) string {
+	link := *r.baseURL
+	link.Path = path.Join(link.Path, urlPath)
+	link.RawQuery = query.Encode()
+
+	return link.String()
+}
+
+// normalizeLocale returns the primary language of the first tag, e.g. "id" for "id-ID,en;q=0.8"
+func normalizeLocale(locale string) string {
+	locale = strings.SplitN(locale, ",", 2)[0]
+	locale = strings.SplitN(locale, ";", 2)[0]
+	locale = strings.TrimSpace(locale)
+	locale = strings.SplitN(strings.ReplaceAll(locale, "_", "-"), "-", 2)[0]
+
+	return strings.ToLower(locale)
+}
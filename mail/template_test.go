@@ -0,0 +1,88 @@
+package mail
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer(t *testing.T) {
+	renderer, err := NewRenderer("https://bank.example.com/")
+	require.NoError(t, err)
+
+	data := map[Message]interface{}{
+		MessageVerifyEmail:       VerifyEmailData{Username: "alice", VerifyURL: "https://bank.example.com/v1/verify_email"},
+		MessageResetPassword:     ResetPasswordData{Username: "alice", ResetID: 1, SecretCode: "secret"},
+		MessageLoginLink:         LoginLinkData{Username: "alice", LoginURL: "https://bank.example.com/login_link"},
+		MessageEmailChangeNotice: EmailChangeNoticeData{Username: "alice", NewEmail: "alice@new-email.com"},
+	}
+
+	// every message is translated to every locale
+	for _, locale := range []string{"en", "id"} {
+		for _, message := range messages {
+			content, err := renderer.Render(message, locale, data[message])
+			require.NoError(t, err, "%s %s", locale, message)
+			require.NotEmpty(t, content.Subject)
+			require.NotContains(t, content.Subject, "\n")
+			require.Contains(t, content.Content, `<html lang="`+locale+`">`)
+			require.Contains(t, content.Content, "alice")
+			require.Contains(t, content.PlainContent, "alice")
+			require.NotContains(t, content.PlainContent, "<")
+		}
+	}
+}
+
+func TestRendererLocale(t *testing.T) {
+	renderer, err := NewRenderer("http://localhost:8080")
+	require.NoError(t, err)
+
+	data := LoginLinkData{Username: "alice", LoginURL: "http://localhost:8080/login_link"}
+
+	testCases := []struct {
+		locale  string
+		subject string
+	}{
+		{locale: "", subject: "Log in to simple bank"},
+		{locale: "id", subject: "Masuk ke simple bank"},
+		{locale: "id-ID,id;q=0.9,en;q=0.8", subject: "Masuk ke simple bank"},
+		{locale: "ID_id", subject: "Masuk ke simple bank"},
+		{locale: "fr-FR", subject: "Log in to simple bank"},
+	}
+
+	for _, tc := range testCases {
+		content, err := renderer.Render(MessageLoginLink, tc.locale, data)
+		require.NoError(t, err)
+		require.Equal(t, tc.subject, content.Subject, tc.locale)
+	}
+}
+
+func TestRendererEscapesHTML(t *testing.T) {
+	renderer, err := NewRenderer("http://localhost:8080")
+	require.NoError(t, err)
+
+	content, err := renderer.Render(MessageEmailChangeNotice, "en", EmailChangeNoticeData{
+		Username: "<script>alert(1)</script>",
+		NewEmail: "alice@new-email.com",
+	})
+	require.NoError(t, err)
+	require.NotContains(t, content.Content, "<script>")
+	require.Contains(t, content.Content, "&lt;script&gt;")
+}
+
+func TestRendererLink(t *testing.T) {
+	renderer, err := NewRenderer("https://bank.example.com/api/")
+	require.NoError(t, err)
+
+	link := renderer.Link("/v1/verify_email", url.Values{
+		"email_id":    []string{"1"},
+		"secret_code": []string{"a b&c"},
+	})
+	require.Equal(t, "https://bank.example.com/api/v1/verify_email?email_id=1&secret_code=a+b%26c", link)
+
+	_, err = NewRenderer("localhost:8080")
+	require.Error(t, err)
+
+	_, err = NewRenderer("")
+	require.Error(t, err)
+}
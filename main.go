@@ -4,7 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"github.com/go-redis/redis/v8"
 	"github.com/hibiken/asynq"
+	"github.com/milhamh95/simplebank/denylist"
+	"github.com/milhamh95/simplebank/limiter"
 	"github.com/milhamh95/simplebank/mail"
 	"github.com/milhamh95/simplebank/worker"
 	"net"
@@ -64,8 +67,17 @@ func main() {
 	// because it will block by get all data
 	// from redis
 	go runTaskProcessor(cfg, redisOpt, store)
-	go runGatewayServer(cfg, store, taskDistributor)
-	runGrpcServer(cfg, store, taskDistributor)
+	go runTaskScheduler(cfg, redisOpt)
+	go runOutboxRelay(cfg, store, taskDistributor)
+	// the grpc and gateway servers share the login limiter and the token denylist
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: cfg.RedisAddress,
+	})
+	loginLimiter := limiter.NewRedisLoginLimiter(redisClient)
+	tokenDenylist := denylist.NewRedisDenylist(redisClient)
+
+	go runGatewayServer(cfg, store, taskDistributor, loginLimiter, tokenDenylist)
+	runGrpcServer(cfg, store, taskDistributor, loginLimiter, tokenDenylist)
 }
 
 func runDBMigration(migrationURL string, dbSource string) {
@@ -86,16 +98,39 @@ func runDBMigration(migrationURL string, dbSource string) {
 
 func runTaskProcessor(cfg config.Config, redisOpt asynq.RedisClientOpt, store db.Store) {
 	mailer := mail.NewGmailSender(cfg.EmailSenderName, cfg.EmailSenderAddress, cfg.EmailSenderPassword)
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
+	renderer, err := mail.NewRenderer(cfg.PublicBaseURL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create email renderer")
+	}
+
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer, renderer)
 	log.Info().Msg("start task processor")
-	err := taskProcessor.Start()
+	err = taskProcessor.Start()
 	if err != nil {
 		log.Fatal().Err(err).Msg("start task processor")
 	}
 }
 
-func runGrpcServer(cfg config.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(cfg, store, taskDistributor)
+func runTaskScheduler(cfg config.Config, redisOpt asynq.RedisClientOpt) {
+	taskScheduler := worker.NewRedisTaskScheduler(redisOpt, cfg.SessionCleanupSchedule)
+	log.Info().Msg("start task scheduler")
+	err := taskScheduler.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("start task scheduler")
+	}
+}
+
+func runOutboxRelay(cfg config.Config, store db.Store, taskDistributor worker.TaskDistributor) {
+	outboxRelay := worker.NewOutboxRelay(store, taskDistributor, cfg.OutboxRelayInterval)
+	log.Info().Msg("start outbox relay")
+	err := outboxRelay.Start(context.Background())
+	if err != nil {
+		log.Fatal().Err(err).Msg("start outbox relay")
+	}
+}
+
+func runGrpcServer(cfg config.Config, store db.Store, taskDistributor worker.TaskDistributor, loginLimiter limiter.LoginLimiter, tokenDenylist denylist.TokenDenylist) {
+	server, err := gapi.NewServer(cfg, store, taskDistributor, loginLimiter, tokenDenylist)
 	if err != nil {
 		log.Fatal().Err(err).Msg("initialize server:")
 	}
@@ -119,8 +154,8 @@ func runGrpcServer(cfg config.Config, store db.Store, taskDistributor worker.Tas
 	}
 }
 
-func runGatewayServer(cfg config.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(cfg, store, taskDistributor)
+func runGatewayServer(cfg config.Config, store db.Store, taskDistributor worker.TaskDistributor, loginLimiter limiter.LoginLimiter, tokenDenylist denylist.TokenDenylist) {
+	server, err := gapi.NewServer(cfg, store, taskDistributor, loginLimiter, tokenDenylist)
 	if err != nil {
 		log.Fatal().Err(err).Msg("initialize server")
 	}
@@ -147,6 +182,8 @@ func runGatewayServer(cfg config.Config, store db.Store, taskDistributor worker.
 
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
+	mux.Handle("/.well-known/jwks.json", server.JWKSHandler())
+	mux.Handle(worker.LoginLinkPath, server.LoginLinkHandler())
 
 	statikFS, err := fs.New()
 	if err != nil {
@@ -170,8 +207,8 @@ func runGatewayServer(cfg config.Config, store db.Store, taskDistributor worker.
 	}
 }
 
-func runGinServer(cfg config.Config, store db.Store) {
-	server, err := api.NewServer(cfg, store)
+func runGinServer(cfg config.Config, store db.Store, tokenDenylist denylist.TokenDenylist) {
+	server, err := api.NewServer(cfg, store, tokenDenylist)
 	if err != nil {
 		log.Fatal().Err(err).Msg("initialize server")
 	}
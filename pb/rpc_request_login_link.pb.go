@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: rpc_request_login_link.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RequestLoginLinkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+}
+
+func (x *RequestLoginLinkRequest) Reset() {
+	*x = RequestLoginLinkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_request_login_link_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestLoginLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLoginLinkRequest) ProtoMessage() {}
+
+func (x *RequestLoginLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_request_login_link_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLoginLinkRequest.ProtoReflect.Descriptor instead.
+func (*RequestLoginLinkRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_request_login_link_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RequestLoginLinkRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RequestLoginLinkResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RequestLoginLinkResponse) Reset() {
+	*x = RequestLoginLinkResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_request_login_link_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestLoginLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLoginLinkResponse) ProtoMessage() {}
+
+func (x *RequestLoginLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_request_login_link_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLoginLinkResponse.ProtoReflect.Descriptor instead.
+func (*RequestLoginLinkResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_request_login_link_proto_rawDescGZIP(), []int{1}
+}
+
+var File_rpc_request_login_link_proto protoreflect.FileDescriptor
+
+var file_rpc_request_login_link_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x72, 0x70, 0x63, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x6c, 0x6f,
+	0x67, 0x69, 0x6e, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02,
+	0x70, 0x62, 0x22, 0x2f, 0x0a, 0x17, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4c, 0x6f, 0x67,
+	0x69, 0x6e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x22, 0x1a, 0x0a, 0x18, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4c, 0x6f,
+	0x67, 0x69, 0x6e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x69,
+	0x6c, 0x68, 0x61, 0x6d, 0x68, 0x39, 0x35, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61,
+	0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_request_login_link_proto_rawDescOnce sync.Once
+	file_rpc_request_login_link_proto_rawDescData = file_rpc_request_login_link_proto_rawDesc
+)
+
+func file_rpc_request_login_link_proto_rawDescGZIP() []byte {
+	file_rpc_request_login_link_proto_rawDescOnce.Do(func() {
+		file_rpc_request_login_link_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_request_login_link_proto_rawDescData)
+	})
+	return file_rpc_request_login_link_proto_rawDescData
+}
+
+var file_rpc_request_login_link_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_request_login_link_proto_goTypes = []interface{}{
+	(*RequestLoginLinkRequest)(nil),  // 0: pb.RequestLoginLinkRequest
+	(*RequestLoginLinkResponse)(nil), // 1: pb.RequestLoginLinkResponse
+}
+var file_rpc_request_login_link_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_request_login_link_proto_init() }
+func file_rpc_request_login_link_proto_init() {
+	if File_rpc_request_login_link_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_request_login_link_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestLoginLinkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_request_login_link_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestLoginLinkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_request_login_link_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_request_login_link_proto_goTypes,
+		DependencyIndexes: file_rpc_request_login_link_proto_depIdxs,
+		MessageInfos:      file_rpc_request_login_link_proto_msgTypes,
+	}.Build()
+	File_rpc_request_login_link_proto = out.File
+	file_rpc_request_login_link_proto_rawDesc = nil
+	file_rpc_request_login_link_proto_goTypes = nil
+	file_rpc_request_login_link_proto_depIdxs = nil
+}
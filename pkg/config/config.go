@@ -20,6 +20,44 @@ type Config struct {
 	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
 	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
 	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	// PublicBaseURL is the address of the HTTP gateway used in the links of the emails
+	PublicBaseURL string `mapstructure:"PUBLIC_BASE_URL"`
+	// SessionCleanupSchedule is a cronspec, e.g. "@every 1h"
+	SessionCleanupSchedule string `mapstructure:"SESSION_CLEANUP_SCHEDULE"`
+	// TOTPEncryptionKey encrypts the TOTP secrets stored in the database, it must be 32 characters
+	TOTPEncryptionKey string `mapstructure:"TOTP_ENCRYPTION_KEY"`
+	// TokenFormat is paseto or jwt, paseto when it is empty
+	TokenFormat string `mapstructure:"TOKEN_FORMAT"`
+	// TokenPrivateKeyFile is a PEM file to sign tokens, Ed25519 for paseto, RSA or Ed25519 for jwt.
+	// When it or TokenPublicKeyFiles is set TokenSymmetricKey isn't used for tokens
+	TokenPrivateKeyFile string `mapstructure:"TOKEN_PRIVATE_KEY_FILE"`
+	// TokenPublicKeyFiles are comma separated PEM files, e.g. of rotated keys, to verify tokens
+	TokenPublicKeyFiles []string `mapstructure:"TOKEN_PUBLIC_KEY_FILES"`
+	// PasswordArgon2Memory in KiB, PasswordArgon2Iterations and PasswordArgon2Parallelism
+	// are the Argon2id parameters of new password hashes, the defaults are used when they are 0
+	PasswordArgon2Memory      uint32 `mapstructure:"PASSWORD_ARGON2_MEMORY"`
+	PasswordArgon2Iterations  uint32 `mapstructure:"PASSWORD_ARGON2_ITERATIONS"`
+	PasswordArgon2Parallelism uint8  `mapstructure:"PASSWORD_ARGON2_PARALLELISM"`
+	// PasswordMinLength, PasswordMinCharClasses and PasswordMaxRepeated are the password policy,
+	// see validator.PasswordPolicy. PasswordBreachedFile lists SHA-1 hashes of breached passwords
+	PasswordMinLength      int    `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordMinCharClasses int    `mapstructure:"PASSWORD_MIN_CHAR_CLASSES"`
+	PasswordMaxRepeated    int    `mapstructure:"PASSWORD_MAX_REPEATED"`
+	PasswordBreachedFile   string `mapstructure:"PASSWORD_BREACHED_FILE"`
+	// RequireVerifiedEmail blocks the login of users who haven't verified their email address
+	RequireVerifiedEmail bool `mapstructure:"REQUIRE_VERIFIED_EMAIL"`
+	// OutboxRelayInterval is how often pending outbox messages are published, 1s when it is 0
+	OutboxRelayInterval time.Duration `mapstructure:"OUTBOX_RELAY_INTERVAL"`
+	// VerifyEmailResendCooldown is the minimum time between two verification emails of a user,
+	// VerifyEmailDailyLimit the maximum number of them in 24 hours
+	VerifyEmailResendCooldown time.Duration `mapstructure:"VERIFY_EMAIL_RESEND_COOLDOWN"`
+	VerifyEmailDailyLimit     int64         `mapstructure:"VERIFY_EMAIL_DAILY_LIMIT"`
+	// ResetPasswordCooldown and ResetPasswordDailyLimit limit the reset password emails of a user the same way
+	ResetPasswordCooldown   time.Duration `mapstructure:"RESET_PASSWORD_COOLDOWN"`
+	ResetPasswordDailyLimit int64         `mapstructure:"RESET_PASSWORD_DAILY_LIMIT"`
+	// TrustedProxies are comma separated ips or CIDR ranges of the proxies in front of the servers.
+	// Their x-forwarded-for is used to find the client ip, it is ignored for other clients
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
 }
 
 func LoadConfig(path string) (cfg Config, err error) {
@@ -0,0 +1,69 @@
+// Package encryptor encrypts small secrets, like TOTP secrets, before they are stored in the database.
+package encryptor
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+const KeySize = 32
+
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// Encryptor uses AES-256-GCM, every ciphertext has its own random nonce
+type Encryptor struct {
+	aead cipher.AEAD
+}
+
+func NewEncryptor(key string) (*Encryptor, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid key size: must be exactly %d characters", KeySize)
+	}
+
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("new cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("new gcm: %w", err)
+	}
+
+	return &Encryptor{aead: aead}, nil
+}
+
+// Encrypt returns the base64 encoded nonce and ciphertext
+func (e *Encryptor) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("read nonce: %w", err)
+	}
+
+	ciphertext := e.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func (e *Encryptor) Decrypt(ciphertext string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonceSize := e.aead.NonceSize()
+	if len(data) < nonceSize {
+		return "", ErrInvalidCiphertext
+	}
+
+	plaintext, err := e.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,40 @@
+package encryptor
+
+import (
+	"testing"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptor(t *testing.T) {
+	encryptor, err := NewEncryptor(random.RandomString(KeySize))
+	require.NoError(t, err)
+
+	plaintext := random.RandomString(32)
+
+	ciphertext1, err := encryptor.Encrypt(plaintext)
+	require.NoError(t, err)
+
+	ciphertext2, err := encryptor.Encrypt(plaintext)
+	require.NoError(t, err)
+	require.NotEqual(t, ciphertext1, ciphertext2)
+
+	got, err := encryptor.Decrypt(ciphertext1)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, got)
+
+	otherEncryptor, err := NewEncryptor(random.RandomString(KeySize))
+	require.NoError(t, err)
+
+	_, err = otherEncryptor.Decrypt(ciphertext1)
+	require.EqualError(t, err, ErrInvalidCiphertext.Error())
+
+	_, err = encryptor.Decrypt("invalid")
+	require.EqualError(t, err, ErrInvalidCiphertext.Error())
+}
+
+func TestInvalidKeySize(t *testing.T) {
+	_, err := NewEncryptor(random.RandomString(16))
+	require.Error(t, err)
+}
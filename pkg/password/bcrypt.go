@@ -0,0 +1,60 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher was the only hasher before Argon2id, its hashes are still checked
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher uses bcrypt.DefaultCost when the cost is 0
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+
+	return &BcryptHasher{cost: cost}
+}
+
+func (h *BcryptHasher) HashPassword(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", fmt.Errorf("generate hash password: %w", err)
+	}
+
+	return string(hashedPassword), nil
+}
+
+func (h *BcryptHasher) CheckPassword(password string, hashedPassword string) error {
+	return checkPassword(password, hashedPassword)
+}
+
+func (h *BcryptHasher) NeedsRehash(hashedPassword string) bool {
+	if !isBcrypt(hashedPassword) {
+		return true
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != h.cost
+}
+
+func isBcrypt(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$2a$") ||
+		strings.HasPrefix(hashedPassword, "$2b$") ||
+		strings.HasPrefix(hashedPassword, "$2y$")
+}
+
+func checkBcrypt(password string, hashedPassword string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrMismatchedPassword
+	}
+
+	return err
+}
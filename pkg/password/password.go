@@ -1,20 +1,44 @@
 package password
 
 import (
-	"fmt"
+	"errors"
+	"strings"
+)
 
-	"golang.org/x/crypto/bcrypt"
+var (
+	ErrMismatchedPassword = errors.New("password does not match the hash")
+	ErrUnsupportedHash    = errors.New("unsupported password hash")
 )
 
-func HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return "", fmt.Errorf("generate hash password: %w", err)
-	}
+// Hasher hashes new passwords with its algorithm and parameters.
+// It checks passwords against hashes of every supported algorithm,
+// so hashes made before a change of algorithm keep working
+type Hasher interface {
+	HashPassword(password string) (string, error)
+	CheckPassword(password string, hashedPassword string) error
+	// NeedsRehash reports whether the hash was made with another algorithm or parameters
+	NeedsRehash(hashedPassword string) bool
+}
+
+var defaultHasher = NewArgon2idHasher(Argon2idParams{})
 
-	return string(hashedPassword), nil
+// HashPassword hashes the password with Argon2id and the default parameters
+func HashPassword(password string) (string, error) {
+	return defaultHasher.HashPassword(password)
 }
 
+// CheckPassword checks the password against an Argon2id or bcrypt hash
 func CheckPassword(password string, hashedPassword string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	return checkPassword(password, hashedPassword)
+}
+
+func checkPassword(password string, hashedPassword string) error {
+	switch {
+	case strings.HasPrefix(hashedPassword, argon2idPrefix):
+		return checkArgon2id(password, hashedPassword)
+	case isBcrypt(hashedPassword):
+		return checkBcrypt(password, hashedPassword)
+	default:
+		return ErrUnsupportedHash
+	}
 }
@@ -1,6 +1,7 @@
 package password
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/milhamh95/simplebank/pkg/random"
@@ -14,6 +15,7 @@ func TestPassword(t *testing.T) {
 	hashedPassword, err := HashPassword(password)
 	require.NoError(t, err)
 	require.NotEmpty(t, hashedPassword)
+	require.True(t, strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=65536,t=3,p=4$"))
 
 	t.Run("success", func(t *testing.T) {
 		err = CheckPassword(password, hashedPassword)
@@ -23,7 +25,7 @@ func TestPassword(t *testing.T) {
 	t.Run("wrong password", func(t *testing.T) {
 		wrongPassword := random.RandomString(6)
 		err = CheckPassword(wrongPassword, hashedPassword)
-		require.EqualError(t, err, bcrypt.ErrMismatchedHashAndPassword.Error())
+		require.EqualError(t, err, ErrMismatchedPassword.Error())
 	})
 
 	t.Run("hashed password is not equal", func(t *testing.T) {
@@ -32,4 +34,47 @@ func TestPassword(t *testing.T) {
 		require.NotEmpty(t, hashedPassword2)
 		require.NotEqual(t, hashedPassword, hashedPassword2)
 	})
+
+	t.Run("unsupported hash", func(t *testing.T) {
+		err = CheckPassword(password, "$1$salt$hash")
+		require.EqualError(t, err, ErrUnsupportedHash.Error())
+
+		err = CheckPassword(password, "$argon2id$v=19$m=65536,t=3,p=4$invalid")
+		require.EqualError(t, err, ErrUnsupportedHash.Error())
+	})
+}
+
+func TestCheckBcryptPassword(t *testing.T) {
+	password := random.RandomString(6)
+
+	bcryptHasher := NewBcryptHasher(bcrypt.MinCost)
+	hashedPassword, err := bcryptHasher.HashPassword(password)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hashedPassword, "$2a$"))
+
+	argon2idHasher := NewArgon2idHasher(Argon2idParams{})
+	require.NoError(t, argon2idHasher.CheckPassword(password, hashedPassword))
+	require.EqualError(t, argon2idHasher.CheckPassword(random.RandomString(6), hashedPassword), ErrMismatchedPassword.Error())
+}
+
+func TestNeedsRehash(t *testing.T) {
+	password := random.RandomString(6)
+
+	hasher := NewArgon2idHasher(Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1})
+	hashedPassword, err := hasher.HashPassword(password)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=1024,t=1,p=1$"))
+	require.False(t, hasher.NeedsRehash(hashedPassword))
+
+	strongerHasher := NewArgon2idHasher(Argon2idParams{Memory: 2048, Iterations: 1, Parallelism: 1})
+	require.True(t, strongerHasher.NeedsRehash(hashedPassword))
+	require.NoError(t, strongerHasher.CheckPassword(password, hashedPassword))
+
+	bcryptHasher := NewBcryptHasher(bcrypt.MinCost)
+	bcryptHashedPassword, err := bcryptHasher.HashPassword(password)
+	require.NoError(t, err)
+	require.True(t, hasher.NeedsRehash(bcryptHashedPassword))
+	require.False(t, bcryptHasher.NeedsRehash(bcryptHashedPassword))
+	require.True(t, NewBcryptHasher(bcrypt.MinCost+1).NeedsRehash(bcryptHashedPassword))
+	require.True(t, bcryptHasher.NeedsRehash(hashedPassword))
 }
@@ -1,7 +1,9 @@
 package random
 
 import (
+	cryptorand "crypto/rand"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"strings"
 	"time"
@@ -29,6 +31,23 @@ func RandomString(n int) string {
 	return sb.String()
 }
 
+// SecretString returns a random string of n letters read from crypto/rand.
+// RandomString is predictable, use SecretString for codes sent to users
+func SecretString(n int) (string, error) {
+	var sb strings.Builder
+	max := big.NewInt(int64(len(alphabet)))
+
+	for i := 0; i < n; i++ {
+		k, err := cryptorand.Int(cryptorand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("read random secret: %w", err)
+		}
+		sb.WriteByte(alphabet[k.Int64()])
+	}
+
+	return sb.String(), nil
+}
+
 func RandomOwner() string {
 	return RandomString(6)
 }
@@ -0,0 +1,16 @@
+package role
+
+const (
+	Depositor = "depositor"
+	Banker    = "banker"
+	Admin     = "admin"
+)
+
+func IsSupportedRole(r string) bool {
+	switch r {
+	case Depositor, Banker, Admin:
+		return true
+	}
+
+	return false
+}
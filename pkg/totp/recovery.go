@@ -0,0 +1,49 @@
+package totp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	RecoveryCodeCount = 10
+
+	// no 0/o or 1/l, so codes are easy to copy from paper
+	recoveryAlphabet   = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeLength = 10
+)
+
+// GenerateRecoveryCodes returns n random one-time recovery codes like "abcde-fghjk"
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	max := big.NewInt(int64(len(recoveryAlphabet)))
+
+	for i := range codes {
+		var sb strings.Builder
+		for j := 0; j < recoveryCodeLength; j++ {
+			if j == recoveryCodeLength/2 {
+				sb.WriteByte('-')
+			}
+
+			k, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return nil, fmt.Errorf("read random recovery code: %w", err)
+			}
+			sb.WriteByte(recoveryAlphabet[k.Int64()])
+		}
+		codes[i] = sb.String()
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash stored for a recovery code.
+// Recovery codes are random, so a fast hash is enough to protect them
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
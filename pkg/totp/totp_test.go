@@ -0,0 +1,106 @@
+package totp
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHOTPRFC6238(t *testing.T) {
+	// test vectors of RFC 6238 appendix B for HMAC-SHA1
+	key := []byte("12345678901234567890")
+
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tc := range testCases {
+		got := hotp(key, counter(time.Unix(tc.unix, 0)), 8)
+		require.Equal(t, tc.code, got, "time %d", tc.unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := GenerateCode(secret, now)
+	require.NoError(t, err)
+	require.Len(t, code, Digits)
+
+	require.True(t, Validate(code, secret, now))
+	require.True(t, Validate(code, secret, now.Add(Period)))
+	require.False(t, Validate(code, secret, now.Add(3*Period)))
+	require.False(t, Validate("12345", secret, now))
+
+	otherSecret, err := GenerateSecret()
+	require.NoError(t, err)
+	require.False(t, Validate(code, otherSecret, now))
+}
+
+func TestValidateStep(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Unix(1111111111, 0)
+	code, err := GenerateCode(secret, now)
+	require.NoError(t, err)
+
+	step, ok := ValidateStep(code, secret, now)
+	require.True(t, ok)
+	require.Equal(t, int64(counter(now)), step)
+
+	// the step of the code doesn't change when it is validated a period later
+	step, ok = ValidateStep(code, secret, now.Add(Period))
+	require.True(t, ok)
+	require.Equal(t, int64(counter(now)), step)
+}
+
+func TestGenerateCodeSecretEncoding(t *testing.T) {
+	key := []byte("12345678901234567890")
+	secret := encoding.EncodeToString(key)
+
+	code, err := GenerateCode(strings.ToLower(secret), time.Unix(59, 0))
+	require.NoError(t, err)
+	require.Equal(t, "287082", code)
+
+	_, err = GenerateCode("not base32!", time.Now())
+	require.Error(t, err)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(RecoveryCodeCount)
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		require.Len(t, code, recoveryCodeLength+1)
+		require.False(t, seen[code])
+		seen[code] = true
+
+		hash := HashRecoveryCode(code)
+		_, err := hex.DecodeString(hash)
+		require.NoError(t, err)
+		require.Equal(t, hash, HashRecoveryCode(" "+strings.ToUpper(code)+" "))
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Simple Bank", "alice", "JBSWY3DPEHPK3PXP")
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Simple%20Bank:alice?"))
+	require.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	require.Contains(t, uri, "issuer=Simple+Bank")
+}
@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	sha1HexLength      = 40
+	breachPrefixLength = 5
+)
+
+// BreachedPasswords is a list of SHA-1 hashes of breached passwords, grouped by
+// the first 5 hex characters like the k-anonymity range API of Have I Been Pwned.
+// A lookup only reads the range of its prefix, so a remote range query can replace the list
+type BreachedPasswords struct {
+	ranges map[string][]string
+}
+
+// LoadBreachedPasswords reads a file with a SHA-1 hex hash per line.
+// A ":count" after the hash, like in the Have I Been Pwned downloads,
+// empty lines and lines starting with # are ignored
+func LoadBreachedPasswords(file string) (*BreachedPasswords, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open breached passwords file: %w", err)
+	}
+	defer f.Close()
+
+	return NewBreachedPasswords(f)
+}
+
+func NewBreachedPasswords(r io.Reader) (*BreachedPasswords, error) {
+	b := &BreachedPasswords{ranges: map[string][]string{}}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(text, ":")
+		hash = strings.ToUpper(hash)
+
+		_, err := hex.DecodeString(hash)
+		if err != nil || len(hash) != sha1HexLength {
+			return nil, fmt.Errorf("line %d of breached passwords: not a sha-1 hex hash", line)
+		}
+
+		prefix := hash[:breachPrefixLength]
+		b.ranges[prefix] = append(b.ranges[prefix], hash[breachPrefixLength:])
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("read breached passwords: %w", err)
+	}
+
+	for _, suffixes := range b.ranges {
+		sort.Strings(suffixes)
+	}
+
+	return b, nil
+}
+
+func (b *BreachedPasswords) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes := b.ranges[hash[:breachPrefixLength]]
+	suffix := hash[breachPrefixLength:]
+
+	i := sort.SearchStrings(suffixes, suffix)
+	return i < len(suffixes) && suffixes[i] == suffix
+}
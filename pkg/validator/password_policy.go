@@ -0,0 +1,131 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// PasswordPolicy are the rules of new passwords. Zero fields turn their rule off,
+// except the lengths which default to 6-100 characters like ValidatePassword
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	// MinCharClasses is how many of lowercase letters, uppercase letters,
+	// digits and symbols a password must contain
+	MinCharClasses int
+	// MaxRepeated is the longest run of the same character
+	MaxRepeated int
+	// Breached rejects passwords found in known breaches, it can be nil
+	Breached *BreachedPasswords
+}
+
+const (
+	defaultPasswordMinLength = 6
+	defaultPasswordMaxLength = 100
+)
+
+// LoadPasswordPolicy loads the breached passwords file into the policy, if there is one
+func LoadPasswordPolicy(policy PasswordPolicy, breachedPasswordsFile string) (*PasswordPolicy, error) {
+	if policy.MinLength == 0 {
+		policy.MinLength = defaultPasswordMinLength
+	}
+
+	if policy.MaxLength == 0 {
+		policy.MaxLength = defaultPasswordMaxLength
+	}
+
+	if breachedPasswordsFile != "" {
+		breached, err := LoadBreachedPasswords(breachedPasswordsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		policy.Breached = breached
+	}
+
+	return &policy, nil
+}
+
+// Validate returns an error for each rule the password breaks.
+// The username and email are optional, the password must not contain them
+func (p *PasswordPolicy) Validate(password string, username string, email string) []error {
+	var errs []error
+
+	n := len([]rune(password))
+	if n < p.MinLength || n > p.MaxLength {
+		errs = append(errs, fmt.Errorf("must contain from %d-%d character", p.MinLength, p.MaxLength))
+	}
+
+	if p.MinCharClasses > 0 && countCharClasses(password) < p.MinCharClasses {
+		errs = append(errs, fmt.Errorf("must contain at least %d of lowercase letters, uppercase letters, digits and symbols", p.MinCharClasses))
+	}
+
+	if p.MaxRepeated > 0 && longestRun(password) > p.MaxRepeated {
+		errs = append(errs, fmt.Errorf("must not repeat a character more than %d times in a row", p.MaxRepeated))
+	}
+
+	lowerPassword := strings.ToLower(password)
+	if username != "" && strings.Contains(lowerPassword, strings.ToLower(username)) {
+		errs = append(errs, fmt.Errorf("must not contain the username"))
+	}
+
+	if email != "" && containsEmail(lowerPassword, strings.ToLower(email)) {
+		errs = append(errs, fmt.Errorf("must not contain the email"))
+	}
+
+	if p.Breached != nil && p.Breached.Contains(password) {
+		errs = append(errs, fmt.Errorf("was found in a data breach, choose another password"))
+	}
+
+	return errs
+}
+
+func countCharClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+
+	return lower + upper + digit + symbol
+}
+
+func longestRun(password string) int {
+	var longest, run int
+	var prev rune
+	for i, r := range password {
+		if i > 0 && r == prev {
+			run++
+		} else {
+			run = 1
+		}
+
+		if run > longest {
+			longest = run
+		}
+
+		prev = r
+	}
+
+	return longest
+}
+
+// containsEmail checks the whole email and its local part,
+// the local part is often the name of the user
+func containsEmail(lowerPassword string, lowerEmail string) bool {
+	if strings.Contains(lowerPassword, lowerEmail) {
+		return true
+	}
+
+	localPart, _, found := strings.Cut(lowerEmail, "@")
+	return found && len(localPart) >= 3 && strings.Contains(lowerPassword, localPart)
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sha-1 of "password1" and "P@ssw0rd!"
+const breachedPasswordsList = `# breached passwords
+E38AD214943DAAD1D64C102FAEC29DE4AFE9DA3D:2413945
+
+076d3e6c4b9f654b5b220b9045b7458ab6b4cbc6
+`
+
+func TestPasswordPolicy(t *testing.T) {
+	breached, err := NewBreachedPasswords(strings.NewReader(breachedPasswordsList))
+	require.NoError(t, err)
+
+	policy := PasswordPolicy{
+		MinLength:      8,
+		MaxLength:      64,
+		MinCharClasses: 3,
+		MaxRepeated:    3,
+		Breached:       breached,
+	}
+
+	testCases := []struct {
+		name     string
+		password string
+		errs     []string
+	}{
+		{
+			name:     "valid",
+			password: "Correct-Horse-9",
+		},
+		{
+			name:     "too short",
+			password: "aB1!",
+			errs:     []string{"must contain from 8-64 character"},
+		},
+		{
+			name:     "too few character classes",
+			password: "correcthorse",
+			errs:     []string{"must contain at least 3 of lowercase letters, uppercase letters, digits and symbols"},
+		},
+		{
+			name:     "repeated characters",
+			password: "Corrrrect-9",
+			errs:     []string{"must not repeat a character more than 3 times in a row"},
+		},
+		{
+			name:     "contains username",
+			password: "My-Alice-99",
+			errs:     []string{"must not contain the username"},
+		},
+		{
+			name:     "contains email",
+			password: "Wonderland-1",
+			errs:     []string{"must not contain the email"},
+		},
+		{
+			name:     "breached",
+			password: "P@ssw0rd!",
+			errs:     []string{"was found in a data breach, choose another password"},
+		},
+		{
+			name:     "every rule",
+			password: "aaaa",
+			errs: []string{
+				"must contain from 8-64 character",
+				"must contain at least 3 of lowercase letters, uppercase letters, digits and symbols",
+				"must not repeat a character more than 3 times in a row",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := policy.Validate(tc.password, "alice", "wonderland@example.com")
+
+			var messages []string
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			require.Equal(t, tc.errs, messages)
+		})
+	}
+}
+
+func TestLoadPasswordPolicy(t *testing.T) {
+	policy, err := LoadPasswordPolicy(PasswordPolicy{}, "")
+	require.NoError(t, err)
+	require.Equal(t, 6, policy.MinLength)
+	require.Equal(t, 100, policy.MaxLength)
+	require.Nil(t, policy.Breached)
+	require.Empty(t, policy.Validate("secret", "", ""))
+
+	file := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(file, []byte(breachedPasswordsList), 0o600))
+
+	policy, err = LoadPasswordPolicy(PasswordPolicy{}, file)
+	require.NoError(t, err)
+	require.True(t, policy.Breached.Contains("password1"))
+	require.False(t, policy.Breached.Contains("password2"))
+
+	_, err = NewBreachedPasswords(strings.NewReader("not a hash\n"))
+	require.EqualError(t, err, "line 1 of breached passwords: not a sha-1 hex hash")
+}
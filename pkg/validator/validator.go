@@ -4,11 +4,16 @@ import (
 	"fmt"
 	"net/mail"
 	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/milhamh95/simplebank/pkg/currency"
+	"github.com/milhamh95/simplebank/pkg/role"
 )
 
 var (
 	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
 	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidTOTPCode = regexp.MustCompile(`^[0-9]{6}$`).MatchString
 )
 
 func ValidateString(value string, minLength, maxLength int) error {
@@ -65,6 +70,102 @@ func ValidateEmailId(value int64) error {
 	return nil
 }
 
+func ValidateResetID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateLoginLinkID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	return nil
+}
+
 func ValidateSecretCode(value string) error {
 	return ValidateString(value, 32, 128)
 }
+
+func ValidateRefreshToken(value string) error {
+	return ValidateString(value, 1, 2048)
+}
+
+func ValidateSessionID(value string) error {
+	_, err := uuid.Parse(value)
+	if err != nil {
+		return fmt.Errorf("must be a valid uuid")
+	}
+
+	return nil
+}
+
+func ValidateTOTPCode(value string) error {
+	if !isValidTOTPCode(value) {
+		return fmt.Errorf("must contain exactly 6 digits")
+	}
+
+	return nil
+}
+
+// ValidateMFACode accepts a TOTP code or a recovery code
+func ValidateMFACode(value string) error {
+	return ValidateString(value, 6, 32)
+}
+
+func ValidateMFAToken(value string) error {
+	return ValidateString(value, 1, 2048)
+}
+
+func ValidateAccountID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !currency.IsSupportedCurrency(value) {
+		return fmt.Errorf("unsupported currency")
+	}
+
+	return nil
+}
+
+func ValidateRole(value string) error {
+	if !role.IsSupportedRole(value) {
+		return fmt.Errorf("unsupported role")
+	}
+
+	return nil
+}
+
+func ValidatePageID(value int32) error {
+	if value < 1 {
+		return fmt.Errorf("must be greater than 0")
+	}
+
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 5 || value > 10 {
+		return fmt.Errorf("must be from 5-10")
+	}
+
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+
+	return nil
+}
+
+func ValidateIdempotencyKey(value string) error {
+	return ValidateString(value, 8, 128)
+}
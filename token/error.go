@@ -3,6 +3,9 @@ package token
 import "errors"
 
 var (
-	ErrExpiredToken = errors.New("token has expired")
-	ErrInvalidToken = errors.New("token is invalid")
+	ErrExpiredToken     = errors.New("token has expired")
+	ErrInvalidToken     = errors.New("token is invalid")
+	ErrTokenNotValidYet = errors.New("token is not valid yet")
+	ErrWrongTokenType   = errors.New("token has the wrong type")
+	ErrMissingScope     = errors.New("token is missing a required scope")
 )
@@ -1,17 +1,41 @@
 package token
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"math/big"
+	"sort"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
-const minSecretKeySize = 32
+const (
+	minSecretKeySize = 32
+	minRSAKeyBits    = 2048
+)
 
+// JWT signs tokens with HS256 and a shared secret, or, when created with
+// NewAsymmetricJWT, with RS256 or EdDSA. Asymmetric tokens carry the key id in the
+// kid header, tokens of every public key in the key set are accepted
 type JWT struct {
 	secretKey string
+
+	signingKey    crypto.PrivateKey
+	signingMethod jwt.SigningMethod
+	keyID         string
+	publicKeys    map[string]jwtPublicKey
+}
+
+type jwtPublicKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
 }
 
 func NewJWT(secretKey string) (*JWT, error) {
@@ -22,14 +46,115 @@ func NewJWT(secretKey string) (*JWT, error) {
 	return &JWT{secretKey: secretKey}, nil
 }
 
-func (j *JWT) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, duration)
+// NewAsymmetricJWT returns a token maker that signs with signingKey, an *rsa.PrivateKey
+// or an ed25519.PrivateKey. signingKey can be nil for a verify only maker.
+// The public key of signingKey is always part of the key set
+func NewAsymmetricJWT(signingKey crypto.PrivateKey, publicKeys ...crypto.PublicKey) (*JWT, error) {
+	j := &JWT{
+		publicKeys: make(map[string]jwtPublicKey),
+	}
+
+	if signingKey != nil {
+		signer, ok := signingKey.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported signing key")
+		}
+
+		publicKey := signer.Public()
+		method, err := jwtSigningMethod(publicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		j.signingKey = signingKey
+		j.signingMethod = method
+		j.keyID = JWKThumbprint(publicKey)
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	for _, publicKey := range publicKeys {
+		method, err := jwtSigningMethod(publicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		j.publicKeys[JWKThumbprint(publicKey)] = jwtPublicKey{
+			method: method,
+			key:    publicKey,
+		}
+	}
+
+	if len(j.publicKeys) == 0 {
+		return nil, errors.New("missing verification key")
+	}
+
+	return j, nil
+}
+
+// LoadAsymmetricJWT reads the keys from PEM files, the private key in PKCS #8
+// and the public keys in PKIX form. privateKeyFile can be empty for a verify only maker
+func LoadAsymmetricJWT(privateKeyFile string, publicKeyFiles ...string) (*JWT, error) {
+	var privateKey crypto.PrivateKey
+	if privateKeyFile != "" {
+		var err error
+		privateKey, err = loadPrivateKey(privateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	publicKeys := make([]crypto.PublicKey, 0, len(publicKeyFiles))
+	for _, publicKeyFile := range publicKeyFiles {
+		publicKey, err := loadPublicKey(publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	return NewAsymmetricJWT(privateKey, publicKeys...)
+}
+
+func jwtSigningMethod(publicKey crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("invalid rsa key size: must be at least %d bits", minRSAKeyBits)
+		}
+
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", publicKey)
+	}
+}
+
+func (j *JWT) CreateToken(username string, role string, tokenType TokenType, duration time.Duration, scopes ...string) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, tokenType, duration, scopes...)
 	if err != nil {
 		return "", payload, err
 	}
 
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
-	token, err := jwtToken.SignedString([]byte(j.secretKey))
+	if j.publicKeys == nil {
+		jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, newJWTClaims(payload))
+		token, err := jwtToken.SignedString([]byte(j.secretKey))
+		if err != nil {
+			return "", payload, err
+		}
+
+		return token, payload, nil
+	}
+
+	if j.signingKey == nil {
+		return "", payload, errors.New("missing private key: token maker can only verify tokens")
+	}
+
+	jwtToken := jwt.NewWithClaims(j.signingMethod, newJWTClaims(payload))
+	jwtToken.Header["kid"] = j.keyID
+
+	token, err := jwtToken.SignedString(j.signingKey)
 	if err != nil {
 		return "", payload, err
 	}
@@ -37,30 +162,200 @@ func (j *JWT) CreateToken(username string, duration time.Duration) (string, *Pay
 	return token, payload, nil
 }
 
-func (j *JWT) VerifyToken(token string) (*Payload, error) {
+func (j *JWT) VerifyToken(token string, tokenType TokenType, scopes ...string) (*Payload, error) {
 	keyFunc := func(token *jwt.Token) (interface{}, error) {
-		_, ok := token.Method.(*jwt.SigningMethodHMAC)
+		if j.publicKeys == nil {
+			_, ok := token.Method.(*jwt.SigningMethodHMAC)
+			if !ok {
+				return nil, ErrInvalidToken
+			}
+
+			return []byte(j.secretKey), nil
+		}
+
+		keyID, _ := token.Header["kid"].(string)
+		publicKey, ok := j.publicKeys[keyID]
 		if !ok {
+			return nil, ErrUnknownKeyID
+		}
+
+		// the algorithm is taken from the key, never from the token
+		if token.Method.Alg() != publicKey.method.Alg() {
 			return nil, ErrInvalidToken
 		}
 
-		return []byte(j.secretKey), nil
+		return publicKey.key, nil
 	}
 
-	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+	jwtToken, err := jwt.ParseWithClaims(token, &jwtClaims{}, keyFunc)
 	if err != nil {
 		verr, ok := err.(*jwt.ValidationError)
 		if ok && errors.Is(verr.Inner, ErrExpiredToken) {
 			return nil, ErrExpiredToken
 		}
 
+		if ok && errors.Is(verr.Inner, ErrTokenNotValidYet) {
+			return nil, ErrTokenNotValidYet
+		}
+
+		if ok && errors.Is(verr.Inner, ErrUnknownKeyID) {
+			return nil, ErrUnknownKeyID
+		}
+
 		return nil, ErrInvalidToken
 	}
 
-	payload, ok := jwtToken.Claims.(*Payload)
+	claims, ok := jwtToken.Claims.(*jwtClaims)
 	if !ok {
 		return nil, ErrInvalidToken
 	}
 
+	payload, err := claims.payload()
+	if err != nil {
+		return nil, err
+	}
+
+	err = payload.verify(tokenType, scopes...)
+	if err != nil {
+		return nil, err
+	}
+
 	return payload, nil
 }
+
+// jwtClaims is the JWT form of Payload. The registered claims are encoded as RFC 7519
+// requires, the times as NumericDate, so other JWT libraries can read the tokens
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Type     TokenType `json:"token_type"`
+	Scopes   []string  `json:"scopes,omitempty"`
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
+}
+
+func newJWTClaims(payload *Payload) jwtClaims {
+	return jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        payload.ID.String(),
+			Issuer:    payload.Issuer,
+			Audience:  jwt.ClaimStrings{payload.Audience},
+			IssuedAt:  jwt.NewNumericDate(payload.IssuedAt),
+			NotBefore: jwt.NewNumericDate(payload.NotBefore),
+			ExpiresAt: jwt.NewNumericDate(payload.ExpiredAt),
+		},
+		Type:     payload.Type,
+		Scopes:   payload.Scopes,
+		Username: payload.Username,
+		Role:     payload.Role,
+	}
+}
+
+func (c jwtClaims) payload() (*Payload, error) {
+	id, err := uuid.Parse(c.ID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if len(c.Audience) != 1 || c.IssuedAt == nil || c.NotBefore == nil || c.ExpiresAt == nil {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{
+		ID:        id,
+		Issuer:    c.Issuer,
+		Audience:  c.Audience[0],
+		Type:      c.Type,
+		Scopes:    c.Scopes,
+		Username:  c.Username,
+		Role:      c.Role,
+		IssuedAt:  c.IssuedAt.Time,
+		NotBefore: c.NotBefore.Time,
+		ExpiredAt: c.ExpiresAt.Time,
+	}
+
+	return payload, nil
+}
+
+// Valid checks the claims the same way as Payload.Valid for the other token formats
+func (c jwtClaims) Valid() error {
+	payload, err := c.payload()
+	if err != nil {
+		return err
+	}
+
+	return payload.Valid()
+}
+
+// JWK is a public key in the JSON Web Key format of RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySet returns the public keys that verify the tokens, sorted by key id.
+// It is empty for HS256 tokens, their secret must not be published
+func (j *JWT) KeySet() JWKS {
+	keySet := JWKS{
+		Keys: make([]JWK, 0, len(j.publicKeys)),
+	}
+
+	for keyID, publicKey := range j.publicKeys {
+		jwk := newJWK(publicKey.key)
+		jwk.Use = "sig"
+		jwk.Alg = publicKey.method.Alg()
+		jwk.Kid = keyID
+		keySet.Keys = append(keySet.Keys, jwk)
+	}
+
+	sort.Slice(keySet.Keys, func(i, k int) bool {
+		return keySet.Keys[i].Kid < keySet.Keys[k].Kid
+	})
+
+	return keySet
+}
+
+func newJWK(publicKey crypto.PublicKey) JWK {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}
+	default:
+		return JWK{}
+	}
+}
+
+// JWKThumbprint returns the RFC 7638 thumbprint of the public key, it is used as the key id
+func JWKThumbprint(publicKey crypto.PublicKey) string {
+	jwk := newJWK(publicKey)
+
+	// the required members in lexicographic order, without whitespace
+	var members string
+	switch jwk.Kty {
+	case "RSA":
+		members = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, jwk.E, jwk.N)
+	case "OKP":
+		members = fmt.Sprintf(`{"crv":%q,"kty":"OKP","x":%q}`, jwk.Crv, jwk.X)
+	}
+
+	sum := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
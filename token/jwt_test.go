@@ -1,11 +1,17 @@
 package token
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/pkg/role"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,22 +20,27 @@ func TestJWTMaker(t *testing.T) {
 	require.NoError(t, err)
 
 	username := random.RandomOwner()
+	userRole := role.Depositor
 	durationTimeMinute := time.Minute
 
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(durationTimeMinute)
 
-	token, payload, err := jwt.CreateToken(username, durationTimeMinute)
+	token, payload, err := jwt.CreateToken(username, userRole, TokenTypeAccess, durationTimeMinute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
 
-	payload, err = jwt.VerifyToken(token)
+	payload, err = jwt.VerifyToken(token, TokenTypeAccess)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
 	require.NotZero(t, payload.ID)
+	require.Equal(t, Issuer, payload.Issuer)
+	require.Equal(t, Audience, payload.Audience)
+	require.Equal(t, TokenTypeAccess, payload.Type)
 	require.Equal(t, username, payload.Username)
+	require.Equal(t, userRole, payload.Role)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 }
@@ -38,30 +49,194 @@ func TestExpiredJWTToken(t *testing.T) {
 	jwt, err := NewJWT(random.RandomString(32))
 	require.NoError(t, err)
 
-	token, payload, err := jwt.CreateToken(random.RandomOwner(), -time.Minute)
+	token, payload, err := jwt.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
 
-	payload, err = jwt.VerifyToken(token)
+	payload, err = jwt.VerifyToken(token, TokenTypeAccess)
 	require.Error(t, err)
 	require.EqualError(t, err, ErrExpiredToken.Error())
 	require.Nil(t, payload)
 }
 
+func TestJWTRegisteredClaims(t *testing.T) {
+	secretKey := random.RandomString(32)
+	jwtMaker, err := NewJWT(secretKey)
+	require.NoError(t, err)
+
+	token, payload, err := jwtMaker.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	// the token is read with the claims of the JWT library, not with Payload
+	claims := &jwt.RegisteredClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secretKey), nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, payload.ID.String(), claims.ID)
+	require.Equal(t, Issuer, claims.Issuer)
+	require.True(t, claims.VerifyAudience(Audience, true))
+	require.WithinDuration(t, payload.IssuedAt, claims.IssuedAt.Time, time.Second)
+	require.WithinDuration(t, payload.NotBefore, claims.NotBefore.Time, time.Second)
+	require.WithinDuration(t, payload.ExpiredAt, claims.ExpiresAt.Time, time.Second)
+}
+
+func TestWrongTypeJWTToken(t *testing.T) {
+	jwt, err := NewJWT(random.RandomString(32))
+	require.NoError(t, err)
+
+	token, _, err := jwt.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeRefresh, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := jwt.VerifyToken(token, TokenTypeAccess)
+	require.EqualError(t, err, ErrWrongTokenType.Error())
+	require.Nil(t, payload)
+
+	payload, err = jwt.VerifyToken(token, TokenTypeRefresh)
+	require.NoError(t, err)
+	require.Equal(t, TokenTypeRefresh, payload.Type)
+}
+
+func TestNotYetValidJWTToken(t *testing.T) {
+	payload, err := NewPayload(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Hour)
+	require.NoError(t, err)
+	payload.NotBefore = time.Now().Add(time.Minute)
+
+	secretKey := random.RandomString(32)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, newJWTClaims(payload)).SignedString([]byte(secretKey))
+	require.NoError(t, err)
+
+	jwtMaker, err := NewJWT(secretKey)
+	require.NoError(t, err)
+
+	payload, err = jwtMaker.VerifyToken(token, TokenTypeAccess)
+	require.EqualError(t, err, ErrTokenNotValidYet.Error())
+	require.Nil(t, payload)
+}
+
 func TestInvalidJWTTokenAlgNone(t *testing.T) {
-	payload, err := NewPayload(random.RandomOwner(), time.Minute)
+	payload, err := NewPayload(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
 	require.NoError(t, err)
 
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, newJWTClaims(payload))
 	token, err := jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
 	require.NoError(t, err)
 
 	jwtMaker, err := NewJWT(random.RandomString(32))
 	require.NoError(t, err)
 
-	payload, err = jwtMaker.VerifyToken(token)
+	payload, err = jwtMaker.VerifyToken(token, TokenTypeAccess)
+	require.Error(t, err)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestAsymmetricJWTMaker(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	signingKeys := map[string]crypto.PrivateKey{
+		"RS256": rsaKey,
+		"EdDSA": randomPrivateKey(t),
+	}
+
+	for alg, signingKey := range signingKeys {
+		t.Run(alg, func(t *testing.T) {
+			jwtMaker, err := NewAsymmetricJWT(signingKey)
+			require.NoError(t, err)
+
+			username := random.RandomOwner()
+			token, _, err := jwtMaker.CreateToken(username, role.Depositor, TokenTypeAccess, time.Minute)
+			require.NoError(t, err)
+
+			jwtToken, _, err := new(jwt.Parser).ParseUnverified(token, &jwt.RegisteredClaims{})
+			require.NoError(t, err)
+			require.Equal(t, alg, jwtToken.Method.Alg())
+			require.Equal(t, jwtMaker.keyID, jwtToken.Header["kid"])
+
+			payload, err := jwtMaker.VerifyToken(token, TokenTypeAccess)
+			require.NoError(t, err)
+			require.Equal(t, username, payload.Username)
+			require.Equal(t, role.Depositor, payload.Role)
+
+			keySet := jwtMaker.KeySet()
+			require.Len(t, keySet.Keys, 1)
+			require.Equal(t, alg, keySet.Keys[0].Alg)
+			require.Equal(t, jwtMaker.keyID, keySet.Keys[0].Kid)
+		})
+	}
+}
+
+func TestAsymmetricJWTKeyRotation(t *testing.T) {
+	oldKey := randomPrivateKey(t)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	oldMaker, err := NewAsymmetricJWT(oldKey)
+	require.NoError(t, err)
+
+	oldToken, _, err := oldMaker.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	newMaker, err := NewAsymmetricJWT(newKey, oldKey.Public())
+	require.NoError(t, err)
+	require.Len(t, newMaker.KeySet().Keys, 2)
+
+	_, err = newMaker.VerifyToken(oldToken, TokenTypeAccess)
+	require.NoError(t, err)
+
+	newToken, _, err := newMaker.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	_, err = oldMaker.VerifyToken(newToken, TokenTypeAccess)
+	require.EqualError(t, err, ErrUnknownKeyID.Error())
+
+	// partners only get the public keys
+	verifier, err := NewAsymmetricJWT(nil, newKey.Public(), oldKey.Public())
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyToken(newToken, TokenTypeAccess)
+	require.NoError(t, err)
+
+	_, _, err = verifier.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
 	require.Error(t, err)
+}
+
+func TestAsymmetricJWTAlgConfusion(t *testing.T) {
+	privateKey := randomPrivateKey(t)
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+
+	jwtMaker, err := NewAsymmetricJWT(privateKey)
+	require.NoError(t, err)
+
+	// the public key is known, an attacker uses it as a HS256 secret
+	payload, err := NewPayload(random.RandomOwner(), role.Admin, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, newJWTClaims(payload))
+	jwtToken.Header["kid"] = jwtMaker.keyID
+	token, err := jwtToken.SignedString([]byte(publicKey))
+	require.NoError(t, err)
+
+	payload, err = jwtMaker.VerifyToken(token, TokenTypeAccess)
 	require.EqualError(t, err, ErrInvalidToken.Error())
 	require.Nil(t, payload)
 }
+
+func TestAsymmetricJWTWeakRSAKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	_, err = NewAsymmetricJWT(rsaKey)
+	require.Error(t, err)
+}
+
+// TestJWKThumbprint checks the thumbprint against the example of RFC 8037
+func TestJWKThumbprint(t *testing.T) {
+	x, err := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	require.NoError(t, err)
+
+	require.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", JWKThumbprint(ed25519.PublicKey(x)))
+}
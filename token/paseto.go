@@ -27,8 +27,8 @@ func NewPaseto(symmetricKey string) (*Paseto, error) {
 	return &pasetoToken, nil
 }
 
-func (p *Paseto) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, duration)
+func (p *Paseto) CreateToken(username string, role string, tokenType TokenType, duration time.Duration, scopes ...string) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, tokenType, duration, scopes...)
 	if err != nil {
 		return "", payload, err
 	}
@@ -41,7 +41,7 @@ func (p *Paseto) CreateToken(username string, duration time.Duration) (string, *
 	return token, payload, nil
 }
 
-func (p *Paseto) VerifyToken(token string) (*Payload, error) {
+func (p *Paseto) VerifyToken(token string, tokenType TokenType, scopes ...string) (*Payload, error) {
 	payload := &Payload{}
 
 	err := p.paseto.Decrypt(token, p.symmetricKey, payload, nil)
@@ -49,7 +49,7 @@ func (p *Paseto) VerifyToken(token string) (*Payload, error) {
 		return nil, ErrInvalidToken
 	}
 
-	err = payload.Valid()
+	err = payload.verify(tokenType, scopes...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,216 @@
+package token
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const pasetoPublicHeader = "v4.public."
+
+var ErrUnknownKeyID = errors.New("token key id is unknown")
+
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// PasetoPublic creates PASETO v4.public tokens, signed with Ed25519.
+// Every token carries the id of its signing key in the footer, so tokens signed with
+// an older key are still valid while its public key is kept in the verification keys.
+// A PasetoPublic without a private key can only verify tokens
+type PasetoPublic struct {
+	privateKey ed25519.PrivateKey
+	keyID      string
+	publicKeys map[string]ed25519.PublicKey
+}
+
+// NewPasetoPublic returns a token maker that signs with privateKey, it can be nil
+// for a verify only maker. The public key of privateKey is always a verification key
+func NewPasetoPublic(privateKey ed25519.PrivateKey, publicKeys ...ed25519.PublicKey) (*PasetoPublic, error) {
+	p := &PasetoPublic{
+		publicKeys: make(map[string]ed25519.PublicKey),
+	}
+
+	if privateKey != nil {
+		if len(privateKey) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("invalid private key size: must be exactly %d bytes", ed25519.PrivateKeySize)
+		}
+
+		p.privateKey = privateKey
+		publicKeys = append(publicKeys, privateKey.Public().(ed25519.PublicKey))
+	}
+
+	for _, publicKey := range publicKeys {
+		if len(publicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid public key size: must be exactly %d bytes", ed25519.PublicKeySize)
+		}
+
+		p.publicKeys[KeyID(publicKey)] = publicKey
+	}
+
+	if len(p.publicKeys) == 0 {
+		return nil, errors.New("missing verification key")
+	}
+
+	if privateKey != nil {
+		p.keyID = KeyID(privateKey.Public().(ed25519.PublicKey))
+	}
+
+	return p, nil
+}
+
+// LoadPasetoPublic reads the keys from PEM files, the private key in PKCS #8
+// and the public keys in PKIX form. privateKeyFile can be empty for a verify only maker
+func LoadPasetoPublic(privateKeyFile string, publicKeyFiles ...string) (*PasetoPublic, error) {
+	var privateKey ed25519.PrivateKey
+	if privateKeyFile != "" {
+		key, err := loadPrivateKey(privateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var ok bool
+		privateKey, ok = key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("private key %s is not an ed25519 key", privateKeyFile)
+		}
+	}
+
+	publicKeys := make([]ed25519.PublicKey, 0, len(publicKeyFiles))
+	for _, publicKeyFile := range publicKeyFiles {
+		key, err := loadPublicKey(publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key %s is not an ed25519 key", publicKeyFile)
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	return NewPasetoPublic(privateKey, publicKeys...)
+}
+
+// KeyID returns the PASERK id (k4.pid) of the public key
+func KeyID(publicKey ed25519.PublicKey) string {
+	const header = "k4.pid."
+
+	hash, _ := blake2b.New(33, nil)
+	hash.Write([]byte(header + "k4.public." + base64.RawURLEncoding.EncodeToString(publicKey)))
+
+	return header + base64.RawURLEncoding.EncodeToString(hash.Sum(nil))
+}
+
+func (p *PasetoPublic) CreateToken(username string, role string, tokenType TokenType, duration time.Duration, scopes ...string) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, tokenType, duration, scopes...)
+	if err != nil {
+		return "", payload, err
+	}
+
+	if p.privateKey == nil {
+		return "", payload, errors.New("missing private key: token maker can only verify tokens")
+	}
+
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", payload, err
+	}
+
+	footer, err := json.Marshal(pasetoFooter{KeyID: p.keyID})
+	if err != nil {
+		return "", payload, err
+	}
+
+	signature := ed25519.Sign(p.privateKey, pae([]byte(pasetoPublicHeader), message, footer, nil))
+
+	var token strings.Builder
+	token.WriteString(pasetoPublicHeader)
+	token.WriteString(base64.RawURLEncoding.EncodeToString(append(message, signature...)))
+	token.WriteString(".")
+	token.WriteString(base64.RawURLEncoding.EncodeToString(footer))
+
+	return token.String(), payload, nil
+}
+
+func (p *PasetoPublic) VerifyToken(token string, tokenType TokenType, scopes ...string) (*Payload, error) {
+	if !strings.HasPrefix(token, pasetoPublicHeader) {
+		return nil, ErrInvalidToken
+	}
+
+	parts := strings.Split(token[len(pasetoPublicHeader):], ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidToken
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(body) < ed25519.SignatureSize {
+		return nil, ErrInvalidToken
+	}
+
+	footer, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var f pasetoFooter
+	err = json.Unmarshal(footer, &f)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	publicKey, ok := p.publicKeys[f.KeyID]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	message := body[:len(body)-ed25519.SignatureSize]
+	signature := body[len(body)-ed25519.SignatureSize:]
+	if !ed25519.Verify(publicKey, pae([]byte(pasetoPublicHeader), message, footer, nil), signature) {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{}
+	err = json.Unmarshal(message, payload)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	err = payload.verify(tokenType, scopes...)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// pae is the pre-authentication encoding of PASETO,
+// it makes the signed pieces unambiguous
+func pae(pieces ...[]byte) []byte {
+	var buf bytes.Buffer
+
+	writeLE64 := func(n int) {
+		var b [8]byte
+		// the most significant bit has to be cleared
+		binary.LittleEndian.PutUint64(b[:], uint64(n)&(1<<63-1))
+		buf.Write(b[:])
+	}
+
+	writeLE64(len(pieces))
+	for _, piece := range pieces {
+		writeLE64(len(piece))
+		buf.Write(piece)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,189 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasetoPublicMaker(t *testing.T) {
+	maker, err := NewPasetoPublic(randomPrivateKey(t))
+	require.NoError(t, err)
+
+	username := random.RandomOwner()
+	userRole := role.Depositor
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(username, userRole, TokenTypeAccess, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+	require.True(t, strings.HasPrefix(token, "v4.public."))
+
+	payload, err = maker.VerifyToken(token, TokenTypeAccess)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, userRole, payload.Role)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+}
+
+func TestExpiredPasetoPublicToken(t *testing.T) {
+	maker, err := NewPasetoPublic(randomPrivateKey(t))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, -time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token, TokenTypeAccess)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestTamperedPasetoPublicToken(t *testing.T) {
+	maker, err := NewPasetoPublic(randomPrivateKey(t))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	// an attacker changes the role of the token
+	parts := strings.Split(token, ".")
+	body, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	tamperedBody := strings.Replace(string(body), role.Depositor, role.Admin, 1)
+	parts[2] = base64.RawURLEncoding.EncodeToString([]byte(tamperedBody))
+
+	payload, err := maker.VerifyToken(strings.Join(parts, "."), TokenTypeAccess)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	// and the symmetric token type is rejected
+	payload, err = maker.VerifyToken(strings.Replace(token, "v4.public.", "v2.local.", 1), TokenTypeAccess)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicKeyRotation(t *testing.T) {
+	oldKey := randomPrivateKey(t)
+	newKey := randomPrivateKey(t)
+
+	oldMaker, err := NewPasetoPublic(oldKey)
+	require.NoError(t, err)
+
+	oldToken, _, err := oldMaker.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	// the new maker signs with the new key, but still accepts tokens of the old key
+	newMaker, err := NewPasetoPublic(newKey, oldKey.Public().(ed25519.PublicKey))
+	require.NoError(t, err)
+
+	_, err = newMaker.VerifyToken(oldToken, TokenTypeAccess)
+	require.NoError(t, err)
+
+	newToken, _, err := newMaker.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	// once the old key is dropped, its tokens are rejected
+	_, err = oldMaker.VerifyToken(newToken, TokenTypeAccess)
+	require.EqualError(t, err, ErrUnknownKeyID.Error())
+}
+
+func TestVerifyOnlyPasetoPublic(t *testing.T) {
+	privateKey := randomPrivateKey(t)
+
+	maker, err := NewPasetoPublic(privateKey)
+	require.NoError(t, err)
+
+	verifier, err := NewPasetoPublic(nil, privateKey.Public().(ed25519.PublicKey))
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyToken(token, TokenTypeAccess)
+	require.NoError(t, err)
+
+	_, _, err = verifier.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+	require.Error(t, err)
+
+	_, err = NewPasetoPublic(nil)
+	require.Error(t, err)
+}
+
+func TestLoadPasetoPublic(t *testing.T) {
+	dir := t.TempDir()
+	privateKey := randomPrivateKey(t)
+	otherKey := randomPrivateKey(t)
+
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	privateKeyFile := filepath.Join(dir, "private.pem")
+	writePEM(t, privateKeyFile, "PRIVATE KEY", privateKeyDER)
+
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(otherKey.Public())
+	require.NoError(t, err)
+	publicKeyFile := filepath.Join(dir, "public.pem")
+	writePEM(t, publicKeyFile, "PUBLIC KEY", publicKeyDER)
+
+	maker, err := LoadPasetoPublic(privateKeyFile, publicKeyFile)
+	require.NoError(t, err)
+	require.Equal(t, KeyID(privateKey.Public().(ed25519.PublicKey)), maker.keyID)
+	require.Len(t, maker.publicKeys, 2)
+
+	_, err = LoadPasetoPublic(filepath.Join(dir, "missing.pem"))
+	require.Error(t, err)
+}
+
+// TestPasetoPublicVector checks the signature against the test vector 4-S-1 of the PASETO spec
+func TestPasetoPublicVector(t *testing.T) {
+	secretKey, err := hex.DecodeString("b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774" +
+		"1eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2")
+	require.NoError(t, err)
+
+	message := []byte(`{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`)
+	signature := ed25519.Sign(secretKey, pae([]byte(pasetoPublicHeader), message, nil, nil))
+
+	expected := "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9" +
+		"bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA"
+	require.Equal(t, expected, pasetoPublicHeader+base64.RawURLEncoding.EncodeToString(append(message, signature...)))
+}
+
+func TestPAE(t *testing.T) {
+	require.Equal(t, "\x00\x00\x00\x00\x00\x00\x00\x00", string(pae()))
+	require.Equal(t, "\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00", string(pae([]byte{})))
+	require.Equal(t,
+		"\x01\x00\x00\x00\x00\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00test",
+		string(pae([]byte("test"))),
+	)
+}
+
+func randomPrivateKey(t *testing.T) ed25519.PrivateKey {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return privateKey
+}
+
+func writePEM(t *testing.T, file string, blockType string, der []byte) {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(file, data, 0600))
+}
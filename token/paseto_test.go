@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/o1egl/paseto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,37 +15,76 @@ func TestPasetoMaker(t *testing.T) {
 	require.NoError(t, err)
 
 	username := random.RandomOwner()
+	userRole := role.Depositor
 	durationTimeMinute := time.Minute
 
 	issuedAt := time.Now()
 	expiredAt := issuedAt.Add(durationTimeMinute)
 
-	token, payload, err := pasetoToken.CreateToken(username, durationTimeMinute)
+	token, payload, err := pasetoToken.CreateToken(username, userRole, TokenTypeAccess, durationTimeMinute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
 
-	payload, err = pasetoToken.VerifyToken(token)
+	payload, err = pasetoToken.VerifyToken(token, TokenTypeAccess)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
 	require.NotZero(t, payload.ID)
+	require.Equal(t, Issuer, payload.Issuer)
+	require.Equal(t, Audience, payload.Audience)
+	require.Equal(t, TokenTypeAccess, payload.Type)
 	require.Equal(t, username, payload.Username)
+	require.Equal(t, userRole, payload.Role)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 }
 
+func TestPasetoRegisteredClaims(t *testing.T) {
+	symmetricKey := random.RandomString(32)
+	pasetoMaker, err := NewPaseto(symmetricKey)
+	require.NoError(t, err)
+
+	token, payload, err := pasetoMaker.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	// the token is read with the claims of the PASETO library, not with Payload
+	var claims paseto.JSONToken
+	err = paseto.NewV2().Decrypt(token, []byte(symmetricKey), &claims, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, payload.ID.String(), claims.Jti)
+	require.Equal(t, Issuer, claims.Issuer)
+	require.Equal(t, Audience, claims.Audience)
+	require.WithinDuration(t, payload.IssuedAt, claims.IssuedAt, time.Second)
+	require.WithinDuration(t, payload.NotBefore, claims.NotBefore, time.Second)
+	require.WithinDuration(t, payload.ExpiredAt, claims.Expiration, time.Second)
+	require.NoError(t, claims.Validate(paseto.IssuedBy(Issuer), paseto.ForAudience(Audience)))
+}
+
 func TestExpiredPasetoToken(t *testing.T) {
 	pasetoToken, err := NewPaseto(random.RandomString(32))
 	require.NoError(t, err)
 
-	token, payload, err := pasetoToken.CreateToken(random.RandomOwner(), -time.Minute)
+	token, payload, err := pasetoToken.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeAccess, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
 
-	payload, err = pasetoToken.VerifyToken(token)
+	payload, err = pasetoToken.VerifyToken(token, TokenTypeAccess)
 	require.Error(t, err)
 	require.EqualError(t, err, ErrExpiredToken.Error())
 	require.Nil(t, payload)
 }
+
+func TestWrongTypePasetoToken(t *testing.T) {
+	pasetoToken, err := NewPaseto(random.RandomString(32))
+	require.NoError(t, err)
+
+	token, _, err := pasetoToken.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeRefresh, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := pasetoToken.VerifyToken(token, TokenTypeAccess)
+	require.EqualError(t, err, ErrWrongTokenType.Error())
+	require.Nil(t, payload)
+}
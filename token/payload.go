@@ -6,33 +6,118 @@ import (
 	"github.com/google/uuid"
 )
 
+// TokenType tells what a token is for, a token is only accepted where its type is expected
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+	TokenTypeMFA     TokenType = "mfa"
+	TokenTypeReset   TokenType = "reset"
+)
+
+const (
+	// Issuer and Audience are checked on every token,
+	// so tokens of another service sharing the keys are rejected
+	Issuer   = "simplebank"
+	Audience = "simplebank-api"
+)
+
+// Payload is encoded with the registered claim names of RFC 7519 and PASETO,
+// the times are RFC 3339 strings as PASETO requires. JWT encodes them as NumericDate, see jwtClaims
 type Payload struct {
-	ID        uuid.UUID `json:"id"`
+	ID        uuid.UUID `json:"jti"`
+	Issuer    string    `json:"iss"`
+	Audience  string    `json:"aud"`
+	Type      TokenType `json:"token_type"`
+	Scopes    []string  `json:"scopes,omitempty"`
 	Username  string    `json:"username"`
-	IssuedAt  time.Time `json:"issued_at"`
-	ExpiredAt time.Time `json:"expired_at"`
+	Role      string    `json:"role"`
+	IssuedAt  time.Time `json:"iat"`
+	NotBefore time.Time `json:"nbf"`
+	ExpiredAt time.Time `json:"exp"`
 }
 
-func NewPayload(username string, duration time.Duration) (*Payload, error) {
+func NewPayload(username string, role string, tokenType TokenType, duration time.Duration, scopes ...string) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now()
 	payload := Payload{
 		ID:        tokenID,
+		Issuer:    Issuer,
+		Audience:  Audience,
+		Type:      tokenType,
+		Scopes:    scopes,
 		Username:  username,
-		IssuedAt:  time.Now(),
-		ExpiredAt: time.Now().Add(duration),
+		Role:      role,
+		IssuedAt:  now,
+		NotBefore: now,
+		ExpiredAt: now.Add(duration),
 	}
 
 	return &payload, nil
 }
 
 func (p *Payload) Valid() error {
-	if time.Now().After(p.ExpiredAt) {
+	if p.Issuer != Issuer || p.Audience != Audience {
+		return ErrInvalidToken
+	}
+
+	switch p.Type {
+	case TokenTypeAccess, TokenTypeRefresh, TokenTypeMFA, TokenTypeReset:
+	default:
+		return ErrInvalidToken
+	}
+
+	now := time.Now()
+	if now.Before(p.NotBefore) {
+		return ErrTokenNotValidYet
+	}
+
+	if now.After(p.ExpiredAt) {
 		return ErrExpiredToken
 	}
 
 	return nil
 }
+
+// IssuedBefore reports whether the token was issued before t. JWT keeps the
+// issue time in whole seconds, so t is compared at the same precision
+func (p *Payload) IssuedBefore(t time.Time) bool {
+	return p.IssuedAt.Before(t.Truncate(time.Second))
+}
+
+// HasScope reports whether the token was issued with the scope
+func (p *Payload) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verify checks the claims of the payload, that it has the expected type
+// and that it was issued with every one of the scopes
+func (p *Payload) verify(tokenType TokenType, scopes ...string) error {
+	err := p.Valid()
+	if err != nil {
+		return err
+	}
+
+	if p.Type != tokenType {
+		return ErrWrongTokenType
+	}
+
+	for _, scope := range scopes {
+		if !p.HasScope(scope) {
+			return ErrMissingScope
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadValid(t *testing.T) {
+	testCases := []struct {
+		name        string
+		editPayload func(payload *Payload)
+		err         error
+	}{
+		{
+			name:        "valid",
+			editPayload: func(payload *Payload) {},
+		},
+		{
+			name: "other issuer",
+			editPayload: func(payload *Payload) {
+				payload.Issuer = "other"
+			},
+			err: ErrInvalidToken,
+		},
+		{
+			name: "other audience",
+			editPayload: func(payload *Payload) {
+				payload.Audience = "other"
+			},
+			err: ErrInvalidToken,
+		},
+		{
+			name: "unknown type",
+			editPayload: func(payload *Payload) {
+				payload.Type = "other"
+			},
+			err: ErrInvalidToken,
+		},
+		{
+			name: "not valid yet",
+			editPayload: func(payload *Payload) {
+				payload.NotBefore = time.Now().Add(time.Minute)
+			},
+			err: ErrTokenNotValidYet,
+		},
+		{
+			name: "expired",
+			editPayload: func(payload *Payload) {
+				payload.ExpiredAt = time.Now().Add(-time.Second)
+			},
+			err: ErrExpiredToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := NewPayload(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+			require.NoError(t, err)
+
+			tc.editPayload(payload)
+			require.Equal(t, tc.err, payload.Valid())
+		})
+	}
+}
+
+func TestPayloadIssuedBefore(t *testing.T) {
+	payload, err := NewPayload(random.RandomOwner(), role.Depositor, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+
+	require.True(t, payload.IssuedBefore(payload.IssuedAt.Add(time.Second)))
+	require.False(t, payload.IssuedBefore(payload.IssuedAt.Add(-time.Second)))
+}
+
+func TestPayloadHasScope(t *testing.T) {
+	payload, err := NewPayload(random.RandomOwner(), role.Depositor, TokenTypeReset, time.Minute, "password:reset")
+	require.NoError(t, err)
+
+	require.True(t, payload.HasScope("password:reset"))
+	require.False(t, payload.HasScope("accounts:write"))
+}
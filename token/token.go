@@ -1,9 +1,47 @@
 package token
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type Tokener interface {
-	CreateToken(username string, duration time.Duration) (string, *Payload, error)
+	CreateToken(username string, role string, tokenType TokenType, duration time.Duration, scopes ...string) (string, *Payload, error)
 
-	VerifyToken(token string) (*Payload, error)
+	// VerifyToken rejects tokens that aren't of the token type or miss one of the scopes
+	VerifyToken(token string, tokenType TokenType, scopes ...string) (*Payload, error)
+}
+
+// KeySetProvider is implemented by token makers whose tokens can be verified with public keys
+type KeySetProvider interface {
+	KeySet() JWKS
+}
+
+const (
+	FormatPaseto = "paseto"
+	FormatJWT    = "jwt"
+)
+
+// NewTokener returns a maker of the format, PASETO when it is empty.
+// With key files the tokens are signed with the private key, PASETO v4.public or
+// JWT RS256/EdDSA, otherwise with the symmetric key, PASETO v2.local or JWT HS256
+func NewTokener(format string, symmetricKey string, privateKeyFile string, publicKeyFiles []string) (Tokener, error) {
+	hasKeyFiles := privateKeyFile != "" || len(publicKeyFiles) > 0
+
+	switch format {
+	case "", FormatPaseto:
+		if hasKeyFiles {
+			return LoadPasetoPublic(privateKeyFile, publicKeyFiles...)
+		}
+
+		return NewPaseto(symmetricKey)
+	case FormatJWT:
+		if hasKeyFiles {
+			return LoadAsymmetricJWT(privateKeyFile, publicKeyFiles...)
+		}
+
+		return NewJWT(symmetricKey)
+	default:
+		return nil, fmt.Errorf("unsupported token format: %s", format)
+	}
 }
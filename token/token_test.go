@@ -0,0 +1,50 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/milhamh95/simplebank/pkg/role"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenScopes(t *testing.T) {
+	pasetoMaker, err := NewPaseto(random.RandomString(32))
+	require.NoError(t, err)
+
+	pasetoPublicMaker, err := NewPasetoPublic(randomPrivateKey(t))
+	require.NoError(t, err)
+
+	jwtMaker, err := NewJWT(random.RandomString(32))
+	require.NoError(t, err)
+
+	asymmetricJWTMaker, err := NewAsymmetricJWT(randomPrivateKey(t))
+	require.NoError(t, err)
+
+	makers := map[string]Tokener{
+		"paseto":         pasetoMaker,
+		"paseto public":  pasetoPublicMaker,
+		"jwt":            jwtMaker,
+		"asymmetric jwt": asymmetricJWTMaker,
+	}
+
+	for name, maker := range makers {
+		t.Run(name, func(t *testing.T) {
+			token, _, err := maker.CreateToken(random.RandomOwner(), role.Depositor, TokenTypeReset, time.Minute, "password:reset")
+			require.NoError(t, err)
+
+			payload, err := maker.VerifyToken(token, TokenTypeReset, "password:reset")
+			require.NoError(t, err)
+			require.Equal(t, TokenTypeReset, payload.Type)
+			require.Equal(t, []string{"password:reset"}, payload.Scopes)
+
+			payload, err = maker.VerifyToken(token, TokenTypeReset, "password:reset", "accounts:write")
+			require.EqualError(t, err, ErrMissingScope.Error())
+			require.Nil(t, payload)
+
+			_, err = maker.VerifyToken(token, TokenTypeAccess)
+			require.EqualError(t, err, ErrWrongTokenType.Error())
+		})
+	}
+}
@@ -11,6 +11,21 @@ type TaskDistributor interface {
 		payload *PayloadSendVerifyEmail,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskSendResetPassword(
+		ctx context.Context,
+		payload *PayloadSendResetPassword,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendLoginLink(
+		ctx context.Context,
+		payload *PayloadSendLoginLink,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendEmailChangeNotice(
+		ctx context.Context,
+		payload *PayloadSendEmailChangeNotice,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisTaskDistributor struct {
@@ -10,6 +10,45 @@ import (
 )
 
 type FakeTaskDistributor struct {
+	DistributeTaskSendEmailChangeNoticeStub        func(context.Context, *worker.PayloadSendEmailChangeNotice, ...asynq.Option) error
+	distributeTaskSendEmailChangeNoticeMutex       sync.RWMutex
+	distributeTaskSendEmailChangeNoticeArgsForCall []struct {
+		arg1 context.Context
+		arg2 *worker.PayloadSendEmailChangeNotice
+		arg3 []asynq.Option
+	}
+	distributeTaskSendEmailChangeNoticeReturns struct {
+		result1 error
+	}
+	distributeTaskSendEmailChangeNoticeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DistributeTaskSendLoginLinkStub        func(context.Context, *worker.PayloadSendLoginLink, ...asynq.Option) error
+	distributeTaskSendLoginLinkMutex       sync.RWMutex
+	distributeTaskSendLoginLinkArgsForCall []struct {
+		arg1 context.Context
+		arg2 *worker.PayloadSendLoginLink
+		arg3 []asynq.Option
+	}
+	distributeTaskSendLoginLinkReturns struct {
+		result1 error
+	}
+	distributeTaskSendLoginLinkReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DistributeTaskSendResetPasswordStub        func(context.Context, *worker.PayloadSendResetPassword, ...asynq.Option) error
+	distributeTaskSendResetPasswordMutex       sync.RWMutex
+	distributeTaskSendResetPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 *worker.PayloadSendResetPassword
+		arg3 []asynq.Option
+	}
+	distributeTaskSendResetPasswordReturns struct {
+		result1 error
+	}
+	distributeTaskSendResetPasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DistributeTaskSenderVerifyEmailStub        func(context.Context, *worker.PayloadSendVerifyEmail, ...asynq.Option) error
 	distributeTaskSenderVerifyEmailMutex       sync.RWMutex
 	distributeTaskSenderVerifyEmailArgsForCall []struct {
@@ -27,6 +66,195 @@ type FakeTaskDistributor struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTaskDistributor) DistributeTaskSendEmailChangeNotice(arg1 context.Context, arg2 *worker.PayloadSendEmailChangeNotice, arg3 ...asynq.Option) error {
+	fake.distributeTaskSendEmailChangeNoticeMutex.Lock()
+	ret, specificReturn := fake.distributeTaskSendEmailChangeNoticeReturnsOnCall[len(fake.distributeTaskSendEmailChangeNoticeArgsForCall)]
+	fake.distributeTaskSendEmailChangeNoticeArgsForCall = append(fake.distributeTaskSendEmailChangeNoticeArgsForCall, struct {
+		arg1 context.Context
+		arg2 *worker.PayloadSendEmailChangeNotice
+		arg3 []asynq.Option
+	}{arg1, arg2, arg3})
+	stub := fake.DistributeTaskSendEmailChangeNoticeStub
+	fakeReturns := fake.distributeTaskSendEmailChangeNoticeReturns
+	fake.recordInvocation("DistributeTaskSendEmailChangeNotice", []interface{}{arg1, arg2, arg3})
+	fake.distributeTaskSendEmailChangeNoticeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendEmailChangeNoticeCallCount() int {
+	fake.distributeTaskSendEmailChangeNoticeMutex.RLock()
+	defer fake.distributeTaskSendEmailChangeNoticeMutex.RUnlock()
+	return len(fake.distributeTaskSendEmailChangeNoticeArgsForCall)
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendEmailChangeNoticeCalls(stub func(context.Context, *worker.PayloadSendEmailChangeNotice, ...asynq.Option) error) {
+	fake.distributeTaskSendEmailChangeNoticeMutex.Lock()
+	defer fake.distributeTaskSendEmailChangeNoticeMutex.Unlock()
+	fake.DistributeTaskSendEmailChangeNoticeStub = stub
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendEmailChangeNoticeArgsForCall(i int) (context.Context, *worker.PayloadSendEmailChangeNotice, []asynq.Option) {
+	fake.distributeTaskSendEmailChangeNoticeMutex.RLock()
+	defer fake.distributeTaskSendEmailChangeNoticeMutex.RUnlock()
+	argsForCall := fake.distributeTaskSendEmailChangeNoticeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendEmailChangeNoticeReturns(result1 error) {
+	fake.distributeTaskSendEmailChangeNoticeMutex.Lock()
+	defer fake.distributeTaskSendEmailChangeNoticeMutex.Unlock()
+	fake.DistributeTaskSendEmailChangeNoticeStub = nil
+	fake.distributeTaskSendEmailChangeNoticeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendEmailChangeNoticeReturnsOnCall(i int, result1 error) {
+	fake.distributeTaskSendEmailChangeNoticeMutex.Lock()
+	defer fake.distributeTaskSendEmailChangeNoticeMutex.Unlock()
+	fake.DistributeTaskSendEmailChangeNoticeStub = nil
+	if fake.distributeTaskSendEmailChangeNoticeReturnsOnCall == nil {
+		fake.distributeTaskSendEmailChangeNoticeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.distributeTaskSendEmailChangeNoticeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendLoginLink(arg1 context.Context, arg2 *worker.PayloadSendLoginLink, arg3 ...asynq.Option) error {
+	fake.distributeTaskSendLoginLinkMutex.Lock()
+	ret, specificReturn := fake.distributeTaskSendLoginLinkReturnsOnCall[len(fake.distributeTaskSendLoginLinkArgsForCall)]
+	fake.distributeTaskSendLoginLinkArgsForCall = append(fake.distributeTaskSendLoginLinkArgsForCall, struct {
+		arg1 context.Context
+		arg2 *worker.PayloadSendLoginLink
+		arg3 []asynq.Option
+	}{arg1, arg2, arg3})
+	stub := fake.DistributeTaskSendLoginLinkStub
+	fakeReturns := fake.distributeTaskSendLoginLinkReturns
+	fake.recordInvocation("DistributeTaskSendLoginLink", []interface{}{arg1, arg2, arg3})
+	fake.distributeTaskSendLoginLinkMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendLoginLinkCallCount() int {
+	fake.distributeTaskSendLoginLinkMutex.RLock()
+	defer fake.distributeTaskSendLoginLinkMutex.RUnlock()
+	return len(fake.distributeTaskSendLoginLinkArgsForCall)
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendLoginLinkCalls(stub func(context.Context, *worker.PayloadSendLoginLink, ...asynq.Option) error) {
+	fake.distributeTaskSendLoginLinkMutex.Lock()
+	defer fake.distributeTaskSendLoginLinkMutex.Unlock()
+	fake.DistributeTaskSendLoginLinkStub = stub
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendLoginLinkArgsForCall(i int) (context.Context, *worker.PayloadSendLoginLink, []asynq.Option) {
+	fake.distributeTaskSendLoginLinkMutex.RLock()
+	defer fake.distributeTaskSendLoginLinkMutex.RUnlock()
+	argsForCall := fake.distributeTaskSendLoginLinkArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendLoginLinkReturns(result1 error) {
+	fake.distributeTaskSendLoginLinkMutex.Lock()
+	defer fake.distributeTaskSendLoginLinkMutex.Unlock()
+	fake.DistributeTaskSendLoginLinkStub = nil
+	fake.distributeTaskSendLoginLinkReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendLoginLinkReturnsOnCall(i int, result1 error) {
+	fake.distributeTaskSendLoginLinkMutex.Lock()
+	defer fake.distributeTaskSendLoginLinkMutex.Unlock()
+	fake.DistributeTaskSendLoginLinkStub = nil
+	if fake.distributeTaskSendLoginLinkReturnsOnCall == nil {
+		fake.distributeTaskSendLoginLinkReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.distributeTaskSendLoginLinkReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendResetPassword(arg1 context.Context, arg2 *worker.PayloadSendResetPassword, arg3 ...asynq.Option) error {
+	fake.distributeTaskSendResetPasswordMutex.Lock()
+	ret, specificReturn := fake.distributeTaskSendResetPasswordReturnsOnCall[len(fake.distributeTaskSendResetPasswordArgsForCall)]
+	fake.distributeTaskSendResetPasswordArgsForCall = append(fake.distributeTaskSendResetPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 *worker.PayloadSendResetPassword
+		arg3 []asynq.Option
+	}{arg1, arg2, arg3})
+	stub := fake.DistributeTaskSendResetPasswordStub
+	fakeReturns := fake.distributeTaskSendResetPasswordReturns
+	fake.recordInvocation("DistributeTaskSendResetPassword", []interface{}{arg1, arg2, arg3})
+	fake.distributeTaskSendResetPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendResetPasswordCallCount() int {
+	fake.distributeTaskSendResetPasswordMutex.RLock()
+	defer fake.distributeTaskSendResetPasswordMutex.RUnlock()
+	return len(fake.distributeTaskSendResetPasswordArgsForCall)
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendResetPasswordCalls(stub func(context.Context, *worker.PayloadSendResetPassword, ...asynq.Option) error) {
+	fake.distributeTaskSendResetPasswordMutex.Lock()
+	defer fake.distributeTaskSendResetPasswordMutex.Unlock()
+	fake.DistributeTaskSendResetPasswordStub = stub
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendResetPasswordArgsForCall(i int) (context.Context, *worker.PayloadSendResetPassword, []asynq.Option) {
+	fake.distributeTaskSendResetPasswordMutex.RLock()
+	defer fake.distributeTaskSendResetPasswordMutex.RUnlock()
+	argsForCall := fake.distributeTaskSendResetPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendResetPasswordReturns(result1 error) {
+	fake.distributeTaskSendResetPasswordMutex.Lock()
+	defer fake.distributeTaskSendResetPasswordMutex.Unlock()
+	fake.DistributeTaskSendResetPasswordStub = nil
+	fake.distributeTaskSendResetPasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDistributor) DistributeTaskSendResetPasswordReturnsOnCall(i int, result1 error) {
+	fake.distributeTaskSendResetPasswordMutex.Lock()
+	defer fake.distributeTaskSendResetPasswordMutex.Unlock()
+	fake.DistributeTaskSendResetPasswordStub = nil
+	if fake.distributeTaskSendResetPasswordReturnsOnCall == nil {
+		fake.distributeTaskSendResetPasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.distributeTaskSendResetPasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTaskDistributor) DistributeTaskSenderVerifyEmail(arg1 context.Context, arg2 *worker.PayloadSendVerifyEmail, arg3 ...asynq.Option) error {
 	fake.distributeTaskSenderVerifyEmailMutex.Lock()
 	ret, specificReturn := fake.distributeTaskSenderVerifyEmailReturnsOnCall[len(fake.distributeTaskSenderVerifyEmailArgsForCall)]
@@ -93,6 +321,12 @@ func (fake *FakeTaskDistributor) DistributeTaskSenderVerifyEmailReturnsOnCall(i
 func (fake *FakeTaskDistributor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.distributeTaskSendEmailChangeNoticeMutex.RLock()
+	defer fake.distributeTaskSendEmailChangeNoticeMutex.RUnlock()
+	fake.distributeTaskSendLoginLinkMutex.RLock()
+	defer fake.distributeTaskSendLoginLinkMutex.RUnlock()
+	fake.distributeTaskSendResetPasswordMutex.RLock()
+	defer fake.distributeTaskSendResetPasswordMutex.RUnlock()
 	fake.distributeTaskSenderVerifyEmailMutex.RLock()
 	defer fake.distributeTaskSenderVerifyEmailMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
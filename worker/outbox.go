@@ -0,0 +1,188 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultOutboxRelayInterval = time.Second
+	outboxBatchSize            = 100
+	// outboxLockDuration hides claimed messages from other relays while they are published.
+	// A relay that dies before marking them sent or failed leaves them to be retried after it
+	outboxLockDuration  = time.Minute
+	outboxMaxRetryDelay = 5 * time.Minute
+)
+
+// NewOutboxMessage returns the params to write a task to the outbox
+// inside a db transaction, e.g. in db.CreateUserTxParams
+func NewOutboxMessage(taskType string, payload interface{}) (db.CreateOutboxMessageParams, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return db.CreateOutboxMessageParams{}, fmt.Errorf("marshal task payload: %w", err)
+	}
+
+	return db.CreateOutboxMessageParams{
+		TaskType: taskType,
+		Payload:  jsonPayload,
+	}, nil
+}
+
+// OutboxRelay publishes the tasks written to the outbox through the TaskDistributor.
+// A message that fails to publish, e.g. because redis is down, is retried with a backoff
+// until it is sent, so a task is only enqueued once its transaction was committed
+type OutboxRelay struct {
+	store           db.Store
+	taskDistributor TaskDistributor
+	interval        time.Duration
+}
+
+func NewOutboxRelay(store db.Store, taskDistributor TaskDistributor, interval time.Duration) *OutboxRelay {
+	if interval <= 0 {
+		interval = defaultOutboxRelayInterval
+	}
+
+	return &OutboxRelay{
+		store:           store,
+		taskDistributor: taskDistributor,
+		interval:        interval,
+	}
+}
+
+// Start polls the outbox every interval until ctx is done
+func (r *OutboxRelay) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		for {
+			n, err := r.RelayPending(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("relay outbox messages")
+			}
+
+			// drain a backlog without waiting for the next tick
+			if err != nil || n < outboxBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// RelayPending publishes one batch of pending messages and returns how many were claimed
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	msgs, err := r.store.ClaimOutboxMessages(ctx, db.ClaimOutboxMessagesParams{
+		LockedUntil: time.Now().Add(outboxLockDuration),
+		BatchSize:   outboxBatchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("claim outbox messages: %w", err)
+	}
+
+	for _, msg := range msgs {
+		err = r.publish(ctx, msg)
+		if errors.Is(err, asynq.ErrTaskIDConflict) {
+			// the task was enqueued before, e.g. by a relay that died before marking it sent
+			log.Info().
+				Int64("id", msg.ID).
+				Str("type", msg.TaskType).
+				Msg("outbox message already published")
+			err = nil
+		}
+		if err != nil {
+			log.Error().
+				Err(err).
+				Int64("id", msg.ID).
+				Str("type", msg.TaskType).
+				Int32("attempts", msg.Attempts+1).
+				Msg("publish outbox message")
+
+			err = r.store.MarkOutboxMessageFailed(ctx, db.MarkOutboxMessageFailedParams{
+				ID:            msg.ID,
+				LastError:     err.Error(),
+				NextAttemptAt: time.Now().Add(outboxRetryDelay(msg.Attempts)),
+			})
+			if err != nil {
+				return len(msgs), fmt.Errorf("mark outbox message %d failed: %w", msg.ID, err)
+			}
+
+			continue
+		}
+
+		err = r.store.MarkOutboxMessageSent(ctx, msg.ID)
+		if err != nil {
+			return len(msgs), fmt.Errorf("mark outbox message %d sent: %w", msg.ID, err)
+		}
+	}
+
+	return len(msgs), nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, msg db.Outbox) error {
+	// the task id is unique per message, so publishing a message twice enqueues a single task
+	taskID := asynq.TaskID(outboxTaskID(msg.ID))
+
+	switch msg.TaskType {
+	case TaskSendVerifyEmail:
+		var payload PayloadSendVerifyEmail
+		err := json.Unmarshal(msg.Payload, &payload)
+		if err != nil {
+			return fmt.Errorf("unmarshal payload: %w", err)
+		}
+
+		return r.taskDistributor.DistributeTaskSenderVerifyEmail(
+			ctx,
+			&payload,
+			taskID,
+			asynq.MaxRetry(10),
+			asynq.Queue(QueueCritical),
+		)
+	case TaskSendEmailChangeNotice:
+		var payload PayloadSendEmailChangeNotice
+		err := json.Unmarshal(msg.Payload, &payload)
+		if err != nil {
+			return fmt.Errorf("unmarshal payload: %w", err)
+		}
+
+		return r.taskDistributor.DistributeTaskSendEmailChangeNotice(
+			ctx,
+			&payload,
+			taskID,
+			asynq.MaxRetry(10),
+			asynq.Queue(QueueDefault),
+		)
+	default:
+		return fmt.Errorf("unknown task type: %s", msg.TaskType)
+	}
+}
+
+func outboxTaskID(id int64) string {
+	return fmt.Sprintf("outbox:%d", id)
+}
+
+// outboxRetryDelay doubles from 1 second with every failed attempt up to outboxMaxRetryDelay
+func outboxRetryDelay(attempts int32) time.Duration {
+	if attempts >= 9 {
+		return outboxMaxRetryDelay
+	}
+
+	delay := time.Second << attempts
+	if delay > outboxMaxRetryDelay {
+		return outboxMaxRetryDelay
+	}
+
+	return delay
+}
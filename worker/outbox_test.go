@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTaskDistributor records the verify email tasks, worker/fake can't be imported here
+type stubTaskDistributor struct {
+	TaskDistributor
+	err      error
+	payloads []*PayloadSendVerifyEmail
+	opts     [][]asynq.Option
+}
+
+func (d *stubTaskDistributor) DistributeTaskSenderVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opts ...asynq.Option) error {
+	d.payloads = append(d.payloads, payload)
+	d.opts = append(d.opts, opts)
+	return d.err
+}
+
+func TestOutboxRelayPending(t *testing.T) {
+	msg, err := NewOutboxMessage(TaskSendVerifyEmail, &PayloadSendVerifyEmail{Username: "alice"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		taskType      string
+		publishErr    error
+		checkResponse func(t *testing.T, store *fake.FakeStore, distributor *stubTaskDistributor)
+	}{
+		{
+			name:     "published",
+			taskType: TaskSendVerifyEmail,
+			checkResponse: func(t *testing.T, store *fake.FakeStore, distributor *stubTaskDistributor) {
+				require.Len(t, distributor.payloads, 1)
+				require.Equal(t, "alice", distributor.payloads[0].Username)
+				require.Contains(t, distributor.opts[0], asynq.TaskID("outbox:1"))
+
+				require.Equal(t, 1, store.MarkOutboxMessageSentCallCount())
+				_, id := store.MarkOutboxMessageSentArgsForCall(0)
+				require.Equal(t, int64(1), id)
+				require.Equal(t, 0, store.MarkOutboxMessageFailedCallCount())
+			},
+		},
+		{
+			name:       "redis is down",
+			taskType:   TaskSendVerifyEmail,
+			publishErr: errors.New("redis is down"),
+			checkResponse: func(t *testing.T, store *fake.FakeStore, distributor *stubTaskDistributor) {
+				require.Len(t, distributor.payloads, 1)
+				require.Equal(t, 0, store.MarkOutboxMessageSentCallCount())
+
+				require.Equal(t, 1, store.MarkOutboxMessageFailedCallCount())
+				_, arg := store.MarkOutboxMessageFailedArgsForCall(0)
+				require.Equal(t, int64(1), arg.ID)
+				require.Contains(t, arg.LastError, "redis is down")
+				// the third attempt waits 4 seconds
+				require.WithinDuration(t, time.Now().Add(4*time.Second), arg.NextAttemptAt, time.Second)
+			},
+		},
+		{
+			name:       "already published",
+			taskType:   TaskSendVerifyEmail,
+			publishErr: fmt.Errorf("enqueue task: %w", asynq.ErrTaskIDConflict),
+			checkResponse: func(t *testing.T, store *fake.FakeStore, distributor *stubTaskDistributor) {
+				require.Len(t, distributor.payloads, 1)
+				require.Equal(t, 1, store.MarkOutboxMessageSentCallCount())
+				require.Equal(t, 0, store.MarkOutboxMessageFailedCallCount())
+			},
+		},
+		{
+			name:     "unknown task type",
+			taskType: "task:unknown",
+			checkResponse: func(t *testing.T, store *fake.FakeStore, distributor *stubTaskDistributor) {
+				require.Empty(t, distributor.payloads)
+				require.Equal(t, 0, store.MarkOutboxMessageSentCallCount())
+				require.Equal(t, 1, store.MarkOutboxMessageFailedCallCount())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &fake.FakeStore{}
+			store.ClaimOutboxMessagesReturns([]db.Outbox{
+				{
+					ID:       1,
+					TaskType: tc.taskType,
+					Payload:  msg.Payload,
+					Attempts: 2,
+				},
+			}, nil)
+			distributor := &stubTaskDistributor{err: tc.publishErr}
+
+			relay := NewOutboxRelay(store, distributor, 0)
+			n, err := relay.RelayPending(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, 1, n)
+
+			tc.checkResponse(t, store, distributor)
+		})
+	}
+}
+
+func TestOutboxRetryDelay(t *testing.T) {
+	require.Equal(t, time.Second, outboxRetryDelay(0))
+	require.Equal(t, 8*time.Second, outboxRetryDelay(3))
+	require.Equal(t, outboxMaxRetryDelay, outboxRetryDelay(9))
+	require.Equal(t, outboxMaxRetryDelay, outboxRetryDelay(100))
+}
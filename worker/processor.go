@@ -19,15 +19,20 @@ const (
 type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskDeleteExpiredSessions(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendResetPassword(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendLoginLink(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendEmailChangeNotice(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
-	mailer mail.EmailSender
+	server   *asynq.Server
+	store    db.Store
+	mailer   mail.EmailSender
+	renderer *mail.Renderer
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender, renderer *mail.Renderer) TaskProcessor {
 	logger := NewLogger()
 	redis.SetLogger(logger)
 
@@ -49,9 +54,10 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer
 	)
 
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
-		mailer: mailer,
+		server:   server,
+		store:    store,
+		mailer:   mailer,
+		renderer: renderer,
 	}
 }
 
@@ -59,6 +65,10 @@ func (p *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 
 	mux.HandleFunc(TaskSendVerifyEmail, p.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskDeleteExpiredSessions, p.ProcessTaskDeleteExpiredSessions)
+	mux.HandleFunc(TaskSendResetPassword, p.ProcessTaskSendResetPassword)
+	mux.HandleFunc(TaskSendLoginLink, p.ProcessTaskSendLoginLink)
+	mux.HandleFunc(TaskSendEmailChangeNotice, p.ProcessTaskSendEmailChangeNotice)
 
 	return p.server.Start(mux)
 }
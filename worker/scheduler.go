@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+type TaskScheduler interface {
+	Start() error
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+	// cronspec for TaskDeleteExpiredSessions, e.g. "@every 1h"
+	sessionCleanupSchedule string
+}
+
+// NewRedisTaskScheduler returns a scheduler that enqueues periodic tasks.
+// The tasks are processed by the RedisTaskProcessor like any other task
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt, sessionCleanupSchedule string) TaskScheduler {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+		EnqueueErrorHandler: func(task *asynq.Task, opts []asynq.Option, err error) {
+			log.Error().
+				Err(err).
+				Str("type", task.Type()).
+				Msg("enqueue periodic task")
+		},
+	})
+
+	return &RedisTaskScheduler{
+		scheduler:              scheduler,
+		sessionCleanupSchedule: sessionCleanupSchedule,
+	}
+}
+
+func (s *RedisTaskScheduler) Start() error {
+	_, err := s.scheduler.Register(
+		s.sessionCleanupSchedule,
+		asynq.NewTask(TaskDeleteExpiredSessions, nil),
+		asynq.Queue(QueueDefault),
+	)
+	if err != nil {
+		return fmt.Errorf("register task %s: %w", TaskDeleteExpiredSessions, err)
+	}
+
+	return s.scheduler.Start()
+}
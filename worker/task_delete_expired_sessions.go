@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskDeleteExpiredSessions = "task:delete_expired_sessions"
+
+// ProcessTaskDeleteExpiredSessions deletes sessions whose refresh token expired.
+// The task is enqueued periodically by the TaskScheduler, it has no payload
+func (p *RedisTaskProcessor) ProcessTaskDeleteExpiredSessions(ctx context.Context, task *asynq.Task) error {
+	deleted, err := p.store.DeleteExpiredSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("delete expired sessions: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int64("deleted", deleted).
+		Msg("processed task")
+
+	return nil
+}
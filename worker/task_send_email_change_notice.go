@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/milhamh95/simplebank/mail"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendEmailChangeNotice = "task:send_email_change_notice"
+
+// PayloadSendEmailChangeNotice tells the old address of the user that a new email was requested
+type PayloadSendEmailChangeNotice struct {
+	Username string `json:"username"`
+	OldEmail string `json:"old_email"`
+	NewEmail string `json:"new_email"`
+	// Locale is the Accept-Language of the request, the email is rendered in it
+	Locale string `json:"locale,omitempty"`
+}
+
+func (d *RedisTaskDistributor) DistributeTaskSendEmailChangeNotice(
+	ctx context.Context,
+	payload *PayloadSendEmailChangeNotice,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendEmailChangeNotice, jsonPayload, opts...)
+	info, err := d.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+func (p *RedisTaskProcessor) ProcessTaskSendEmailChangeNotice(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendEmailChangeNotice
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	content, err := p.renderer.Render(mail.MessageEmailChangeNotice, payload.Locale, mail.EmailChangeNoticeData{
+		Username: payload.Username,
+		NewEmail: payload.NewEmail,
+	})
+	if err != nil {
+		return fmt.Errorf("render email change notice: %v: %w", err, asynq.SkipRetry)
+	}
+
+	content.To = []string{payload.OldEmail}
+	err = p.mailer.SendEmail(content)
+	if err != nil {
+		return fmt.Errorf("send email change notice: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", payload.OldEmail).
+		Msg("processed task")
+
+	return nil
+}
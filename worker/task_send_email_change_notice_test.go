@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessTaskSendEmailChangeNotice(t *testing.T) {
+	payload, err := json.Marshal(&PayloadSendEmailChangeNotice{
+		Username: "alice",
+		OldEmail: "alice@email.com",
+		NewEmail: "alice@new-email.com",
+		Locale:   "id-ID,id;q=0.9",
+	})
+	require.NoError(t, err)
+	task := asynq.NewTask(TaskSendEmailChangeNotice, payload)
+
+	mailer := &stubMailer{}
+	processor := newTestTaskProcessor(t, nil, mailer)
+
+	err = processor.ProcessTaskSendEmailChangeNotice(context.Background(), task)
+	require.NoError(t, err)
+	require.Len(t, mailer.emails, 1)
+	require.Equal(t, []string{"alice@email.com"}, mailer.emails[0].To)
+	require.Equal(t, "Email simple bank Anda sedang diubah", mailer.emails[0].Subject)
+	require.Contains(t, mailer.emails[0].Content, "alice@new-email.com")
+
+	mailer.err = errors.New("smtp is down")
+	err = processor.ProcessTaskSendEmailChangeNotice(context.Background(), task)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, asynq.SkipRetry)
+}
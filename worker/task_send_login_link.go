@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hibiken/asynq"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/mail"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendLoginLink = "task:send_login_link"
+
+// LoginLinkPath is the page the emailed link opens. The page posts the link to
+// ConsumeLoginLink, so mail scanners that prefetch links can't use it up
+const LoginLinkPath = "/login_link"
+
+type PayloadSendLoginLink struct {
+	Username string `json:"username"`
+	// Locale is the Accept-Language of the request, the email is rendered in it
+	Locale string `json:"locale,omitempty"`
+}
+
+func (d *RedisTaskDistributor) DistributeTaskSendLoginLink(
+	ctx context.Context,
+	payload *PayloadSendLoginLink,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendLoginLink, jsonPayload, opts...)
+	info, err := d.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// LoginLinkURL returns the url of the login link page the email points to
+func LoginLinkURL(renderer *mail.Renderer, linkID int64, secretCode string) string {
+	return renderer.Link(LoginLinkPath, url.Values{
+		"link_id":     []string{strconv.FormatInt(linkID, 10)},
+		"secret_code": []string{secretCode},
+	})
+}
+
+// ProcessTaskSendLoginLink creates the secret of the link here, so it never
+// goes through the queue and its logs
+func (p *RedisTaskProcessor) ProcessTaskSendLoginLink(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendLoginLink
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := p.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("user doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	secretCode, err := random.SecretString(32)
+	if err != nil {
+		return fmt.Errorf("generate secret code: %w", err)
+	}
+
+	// only the hash is stored, the secret code is only in the email
+	loginLink, err := p.store.CreateLoginLink(ctx, db.CreateLoginLinkParams{
+		Username:         user.Username,
+		HashedSecretCode: db.HashLoginLinkSecret(secretCode),
+	})
+	if err != nil {
+		return fmt.Errorf("create login link: %w", err)
+	}
+
+	content, err := p.renderer.Render(mail.MessageLoginLink, payload.Locale, mail.LoginLinkData{
+		Username: user.Username,
+		LoginURL: LoginLinkURL(p.renderer, loginLink.ID, secretCode),
+	})
+	if err != nil {
+		return fmt.Errorf("render login link email: %v: %w", err, asynq.SkipRetry)
+	}
+
+	content.To = []string{user.Email}
+	err = p.mailer.SendEmail(content)
+	if err != nil {
+		return fmt.Errorf("send login link email: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", user.Email).
+		Msg("processed task")
+
+	return nil
+}
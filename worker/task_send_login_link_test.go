@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessTaskSendLoginLink(t *testing.T) {
+	user := db.User{
+		Username: "alice",
+		Email:    "alice@email.com",
+	}
+
+	payload, err := json.Marshal(&PayloadSendLoginLink{Username: user.Username})
+	require.NoError(t, err)
+
+	store := &fake.FakeStore{}
+	store.GetUserReturns(user, nil)
+	store.CreateLoginLinkStub = func(ctx context.Context, arg db.CreateLoginLinkParams) (db.LoginLink, error) {
+		return db.LoginLink{
+			ID:               7,
+			Username:         arg.Username,
+			HashedSecretCode: arg.HashedSecretCode,
+		}, nil
+	}
+
+	mailer := &stubMailer{}
+	processor := newTestTaskProcessor(t, store, mailer)
+
+	err = processor.ProcessTaskSendLoginLink(context.Background(), asynq.NewTask(TaskSendLoginLink, payload))
+	require.NoError(t, err)
+
+	require.Len(t, mailer.emails, 1)
+	require.Equal(t, []string{user.Email}, mailer.emails[0].To)
+
+	loginURL := regexp.MustCompile(`https://bank\.example\.com/login_link\?\S+`).FindString(mailer.emails[0].PlainContent)
+	require.NotEmpty(t, loginURL)
+
+	link, err := url.Parse(loginURL)
+	require.NoError(t, err)
+	require.Equal(t, "7", link.Query().Get("link_id"))
+
+	// the email has the secret code, the store only its hash
+	secretCode := link.Query().Get("secret_code")
+	require.Len(t, secretCode, 32)
+
+	_, arg := store.CreateLoginLinkArgsForCall(0)
+	require.Equal(t, user.Username, arg.Username)
+	require.Equal(t, db.HashLoginLinkSecret(secretCode), arg.HashedSecretCode)
+}
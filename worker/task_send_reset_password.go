@@ -0,0 +1,120 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/mail"
+	"github.com/milhamh95/simplebank/pkg/random"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendResetPassword = "task:send_reset_password"
+
+type PayloadSendResetPassword struct {
+	Username string `json:"username"`
+	// Locale is the Accept-Language of the request, the email is rendered in it
+	Locale string `json:"locale,omitempty"`
+}
+
+func (d *RedisTaskDistributor) DistributeTaskSendResetPassword(
+	ctx context.Context,
+	payload *PayloadSendResetPassword,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendResetPassword, jsonPayload, opts...)
+	info, err := d.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+func (p *RedisTaskProcessor) ProcessTaskSendResetPassword(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendResetPassword
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := p.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("user doesn't exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	resetPassword, err := p.getOrCreateResetPassword(ctx, user.Username)
+	if err != nil {
+		return err
+	}
+
+	content, err := p.renderer.Render(mail.MessageResetPassword, payload.Locale, mail.ResetPasswordData{
+		Username:   user.Username,
+		ResetID:    resetPassword.ID,
+		SecretCode: resetPassword.SecretCode,
+	})
+	if err != nil {
+		return fmt.Errorf("render reset password email: %v: %w", err, asynq.SkipRetry)
+	}
+
+	content.To = []string{user.Email}
+	err = p.mailer.SendEmail(content)
+	if err != nil {
+		return fmt.Errorf("send reset password email: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", user.Email).
+		Msg("processed task")
+
+	return nil
+}
+
+// getOrCreateResetPassword reuses the unused code of the user while it hasn't expired,
+// so a retried task sends the same code instead of creating a new code every time
+func (p *RedisTaskProcessor) getOrCreateResetPassword(ctx context.Context, username string) (db.ResetPassword, error) {
+	resetPassword, err := p.store.GetActiveResetPassword(ctx, username)
+	if err == nil {
+		return resetPassword, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return db.ResetPassword{}, fmt.Errorf("get active reset password: %w", err)
+	}
+
+	secretCode, err := random.SecretString(32)
+	if err != nil {
+		return db.ResetPassword{}, fmt.Errorf("generate secret code: %w", err)
+	}
+
+	resetPassword, err = p.store.CreateResetPassword(ctx, db.CreateResetPasswordParams{
+		Username:   username,
+		SecretCode: secretCode,
+	})
+	if err != nil {
+		return db.ResetPassword{}, fmt.Errorf("create reset password: %w", err)
+	}
+
+	return resetPassword, nil
+}
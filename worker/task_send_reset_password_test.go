@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessTaskSendResetPassword(t *testing.T) {
+	user := db.User{
+		Username: "alice",
+		Email:    "alice@email.com",
+	}
+
+	payload, err := json.Marshal(&PayloadSendResetPassword{Username: user.Username})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *fake.FakeStore)
+		checkResponse func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer)
+	}{
+		{
+			name: "reuse active code",
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetActiveResetPasswordReturns(db.ResetPassword{ID: 7, SecretCode: "active-secret-code"}, nil)
+			},
+			checkResponse: func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer) {
+				require.NoError(t, err)
+				require.Equal(t, 0, store.CreateResetPasswordCallCount())
+
+				_, username := store.GetActiveResetPasswordArgsForCall(0)
+				require.Equal(t, user.Username, username)
+
+				require.Len(t, mailer.emails, 1)
+				require.Contains(t, mailer.emails[0].PlainContent, "active-secret-code")
+				require.Equal(t, []string{user.Email}, mailer.emails[0].To)
+			},
+		},
+		{
+			name: "create code",
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetActiveResetPasswordReturns(db.ResetPassword{}, sql.ErrNoRows)
+				store.CreateResetPasswordReturns(db.ResetPassword{ID: 8, SecretCode: "new-secret-code"}, nil)
+			},
+			checkResponse: func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer) {
+				require.NoError(t, err)
+				require.Equal(t, 1, store.CreateResetPasswordCallCount())
+				_, arg := store.CreateResetPasswordArgsForCall(0)
+				require.Equal(t, user.Username, arg.Username)
+				require.Len(t, arg.SecretCode, 32)
+
+				require.Len(t, mailer.emails, 1)
+				require.Contains(t, mailer.emails[0].PlainContent, "new-secret-code")
+			},
+		},
+		{
+			name: "get active code error",
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetActiveResetPasswordReturns(db.ResetPassword{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer) {
+				require.Error(t, err)
+				require.Equal(t, 0, store.CreateResetPasswordCallCount())
+				require.Empty(t, mailer.emails)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &fake.FakeStore{}
+			store.GetUserReturns(user, nil)
+			tc.buildStubs(store)
+
+			mailer := &stubMailer{}
+			processor := newTestTaskProcessor(t, store, mailer)
+
+			err := processor.ProcessTaskSendResetPassword(context.Background(), asynq.NewTask(TaskSendResetPassword, payload))
+			tc.checkResponse(t, err, store, mailer)
+		})
+	}
+}
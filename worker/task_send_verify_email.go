@@ -4,18 +4,23 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/hibiken/asynq"
 	db "github.com/milhamh95/simplebank/db/sqlc"
 	"github.com/milhamh95/simplebank/mail"
 	"github.com/milhamh95/simplebank/pkg/random"
 	"github.com/rs/zerolog/log"
+	"net/url"
+	"strconv"
 )
 
 const TaskSendVerifyEmail = "task:send_verify_email"
 
 type PayloadSendVerifyEmail struct {
 	Username string `json:"username"`
+	// Locale is the Accept-Language of the request, the email is rendered in it
+	Locale string `json:"locale,omitempty"`
 }
 
 func (d *RedisTaskDistributor) DistributeTaskSenderVerifyEmail(
@@ -59,33 +64,79 @@ func (p *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, tas
 		return fmt.Errorf("get user: %w", err)
 	}
 
-	verifyEmail, err := p.store.CreateVerifyEmail(ctx, db.CreateVerifyEmailParams{
-		Username:   user.Username,
-		Email:      user.Email,
-		SecretCode: random.RandomString(32),
-	})
+	// a pending email change is verified before the current email
+	email := user.PendingEmail
+	if email == "" {
+		if user.IsEmailVerified {
+			log.Info().
+				Str("type", task.Type()).
+				Bytes("payload", task.Payload()).
+				Msg("email is already verified")
+			return nil
+		}
+
+		email = user.Email
+	}
+
+	verifyEmail, err := p.getOrCreateVerifyEmail(ctx, user.Username, email)
 	if err != nil {
-		return fmt.Errorf("create verify email: %w", err)
+		return err
 	}
 
-	subject := "Welcome to simple bank"
-	verifyUrl := fmt.Sprintf("http://localhost:8080/v1/verify_email?email_id=%d&secret_code=%s", verifyEmail.ID, verifyEmail.SecretCode)
-	content := fmt.Sprintf(`Hello %s, <br />
-	Thank you for registering with use!. <br />
-	Please <a href=%s>click here</a> to verify your email address <br />
-	`, user.Username, verifyUrl)
-	to := []string{user.Email}
-	p.mailer.SendEmail(mail.EmailContent{
-		Subject: subject,
-		Content: content,
-		To:      to,
+	content, err := p.renderer.Render(mail.MessageVerifyEmail, payload.Locale, mail.VerifyEmailData{
+		Username: user.Username,
+		NewEmail: user.PendingEmail != "",
+		VerifyURL: p.renderer.Link("/v1/verify_email", url.Values{
+			"email_id":    []string{strconv.FormatInt(verifyEmail.ID, 10)},
+			"secret_code": []string{verifyEmail.SecretCode},
+		}),
 	})
+	if err != nil {
+		return fmt.Errorf("render verify email: %v: %w", err, asynq.SkipRetry)
+	}
+
+	content.To = []string{email}
+	err = p.mailer.SendEmail(content)
+	if err != nil {
+		return fmt.Errorf("send verify email: %w", err)
+	}
 
 	log.Info().
 		Str("type", task.Type()).
 		Bytes("payload", task.Payload()).
-		Str("email", user.Email).
-		Msg("enqueued task")
+		Str("email", email).
+		Msg("processed task")
 
 	return nil
 }
+
+// getOrCreateVerifyEmail reuses the unused code of the email while it hasn't expired,
+// so a retried task sends the same link instead of creating a new code every time
+func (p *RedisTaskProcessor) getOrCreateVerifyEmail(ctx context.Context, username string, email string) (db.VerifyEmail, error) {
+	verifyEmail, err := p.store.GetActiveVerifyEmail(ctx, db.GetActiveVerifyEmailParams{
+		Username: username,
+		Email:    email,
+	})
+	if err == nil {
+		return verifyEmail, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return db.VerifyEmail{}, fmt.Errorf("get active verify email: %w", err)
+	}
+
+	secretCode, err := random.SecretString(32)
+	if err != nil {
+		return db.VerifyEmail{}, fmt.Errorf("generate secret code: %w", err)
+	}
+
+	verifyEmail, err = p.store.CreateVerifyEmail(ctx, db.CreateVerifyEmailParams{
+		Username:   username,
+		Email:      email,
+		SecretCode: secretCode,
+	})
+	if err != nil {
+		return db.VerifyEmail{}, fmt.Errorf("create verify email: %w", err)
+	}
+
+	return verifyEmail, nil
+}
@@ -0,0 +1,166 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/milhamh95/simplebank/db/fake"
+	db "github.com/milhamh95/simplebank/db/sqlc"
+	"github.com/milhamh95/simplebank/mail"
+	"github.com/stretchr/testify/require"
+)
+
+type stubMailer struct {
+	err    error
+	emails []mail.EmailContent
+}
+
+func (m *stubMailer) SendEmail(emailContent mail.EmailContent) error {
+	m.emails = append(m.emails, emailContent)
+	return m.err
+}
+
+func newTestTaskProcessor(t *testing.T, store db.Store, mailer mail.EmailSender) *RedisTaskProcessor {
+	renderer, err := mail.NewRenderer("https://bank.example.com")
+	require.NoError(t, err)
+
+	return &RedisTaskProcessor{
+		store:    store,
+		mailer:   mailer,
+		renderer: renderer,
+	}
+}
+
+func TestProcessTaskSendVerifyEmail(t *testing.T) {
+	user := db.User{
+		Username: "alice",
+		Email:    "alice@email.com",
+	}
+	activeVerifyEmail := db.VerifyEmail{
+		ID:         7,
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: "active-secret-code",
+	}
+
+	payload, err := json.Marshal(&PayloadSendVerifyEmail{Username: user.Username})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		sendErr       error
+		buildStubs    func(store *fake.FakeStore)
+		checkResponse func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer)
+	}{
+		{
+			name: "reuse active code",
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetActiveVerifyEmailReturns(activeVerifyEmail, nil)
+			},
+			checkResponse: func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer) {
+				require.NoError(t, err)
+				require.Equal(t, 0, store.CreateVerifyEmailCallCount())
+
+				_, arg := store.GetActiveVerifyEmailArgsForCall(0)
+				require.Equal(t, user.Username, arg.Username)
+				require.Equal(t, user.Email, arg.Email)
+
+				require.Len(t, mailer.emails, 1)
+				require.Equal(t, "Welcome to simple bank", mailer.emails[0].Subject)
+				require.Contains(t, mailer.emails[0].Content, "https://bank.example.com/v1/verify_email?email_id=7&amp;secret_code=active-secret-code")
+				require.Contains(t, mailer.emails[0].PlainContent, "https://bank.example.com/v1/verify_email?email_id=7&secret_code=active-secret-code")
+				require.Equal(t, []string{user.Email}, mailer.emails[0].To)
+			},
+		},
+		{
+			name: "create code",
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetActiveVerifyEmailReturns(db.VerifyEmail{}, sql.ErrNoRows)
+				store.CreateVerifyEmailReturns(db.VerifyEmail{ID: 8, SecretCode: "new-secret-code"}, nil)
+			},
+			checkResponse: func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer) {
+				require.NoError(t, err)
+				require.Equal(t, 1, store.CreateVerifyEmailCallCount())
+				_, arg := store.CreateVerifyEmailArgsForCall(0)
+				require.Len(t, arg.SecretCode, 32)
+
+				require.Len(t, mailer.emails, 1)
+				require.Contains(t, mailer.emails[0].Content, "new-secret-code")
+			},
+		},
+		{
+			name: "pending email",
+			buildStubs: func(store *fake.FakeStore) {
+				pendingUser := user
+				pendingUser.IsEmailVerified = true
+				pendingUser.PendingEmail = "alice@new-email.com"
+				store.GetUserReturns(pendingUser, nil)
+				store.GetActiveVerifyEmailReturns(db.VerifyEmail{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer) {
+				require.NoError(t, err)
+
+				_, arg := store.CreateVerifyEmailArgsForCall(0)
+				require.Equal(t, "alice@new-email.com", arg.Email)
+
+				require.Len(t, mailer.emails, 1)
+				require.Equal(t, "Verify your new email address", mailer.emails[0].Subject)
+				require.Equal(t, []string{"alice@new-email.com"}, mailer.emails[0].To)
+			},
+		},
+		{
+			name: "already verified",
+			buildStubs: func(store *fake.FakeStore) {
+				verifiedUser := user
+				verifiedUser.IsEmailVerified = true
+				store.GetUserReturns(verifiedUser, nil)
+			},
+			checkResponse: func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer) {
+				require.NoError(t, err)
+				require.Equal(t, 0, store.GetActiveVerifyEmailCallCount())
+				require.Empty(t, mailer.emails)
+			},
+		},
+		{
+			name:    "send email failed",
+			sendErr: errors.New("smtp is down"),
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetActiveVerifyEmailReturns(activeVerifyEmail, nil)
+			},
+			checkResponse: func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer) {
+				// asynq retries the task, the retry sends the same code
+				require.Error(t, err)
+				require.NotErrorIs(t, err, asynq.SkipRetry)
+				require.Equal(t, 0, store.CreateVerifyEmailCallCount())
+			},
+		},
+		{
+			name: "get active code failed",
+			buildStubs: func(store *fake.FakeStore) {
+				store.GetActiveVerifyEmailReturns(db.VerifyEmail{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, err error, store *fake.FakeStore, mailer *stubMailer) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+				require.Equal(t, 0, store.CreateVerifyEmailCallCount())
+				require.Empty(t, mailer.emails)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &fake.FakeStore{}
+			store.GetUserReturns(user, nil)
+			tc.buildStubs(store)
+			mailer := &stubMailer{err: tc.sendErr}
+
+			processor := newTestTaskProcessor(t, store, mailer)
+			err := processor.ProcessTaskSendVerifyEmail(context.Background(), asynq.NewTask(TaskSendVerifyEmail, payload))
+			tc.checkResponse(t, err, store, mailer)
+		})
+	}
+}